@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	// minAlbumItems is the fewest media an album can have, anything less is a media post
+	minAlbumItems = 2
+	// maxAlbumItems is the most media an album can have, matching the binding on createPostRequest
+	maxAlbumItems = 10
+	// maxAltTextLength is the most characters an album item's alt text can have once sanitized
+	maxAltTextLength = 300
+)
+
+var (
+	errAlbumItems     = fmt.Errorf("albums need between %d and %d media items and no media_url", minAlbumItems, maxAlbumItems)
+	errAlbumOnlyMedia = errors.New("only album posts can have media items")
+	errAlbumDuplicate = errors.New("album media items must be different uploads")
+	errAlbumEdit      = errors.New("album media cannot be changed after posting")
+	errAltTextTooLong = fmt.Errorf("alt text is longer than %d characters", maxAltTextLength)
+)
+
+type albumMediaRequest struct {
+	MediaURL string `json:"media_url" binding:"required"`
+	Width    int32  `json:"width" binding:"required,min=1,max=20000"`
+	Height   int32  `json:"height" binding:"required,min=1,max=20000"`
+	AltText  string `json:"alt_text"`
+}
+
+type postMediaResponse struct {
+	ID       string `json:"id"`
+	Position int32  `json:"position"`
+	MediaURL string `json:"media_url"`
+	Width    int32  `json:"width"`
+	Height   int32  `json:"height"`
+	AltText  string `json:"alt_text"`
+}
+
+func newPostMediaResponses(media []db.PostMedium) []postMediaResponse {
+	rsp := make([]postMediaResponse, 0, len(media))
+	for _, medium := range media {
+		rsp = append(rsp, postMediaResponse{
+			ID:       medium.ID.String(),
+			Position: medium.Position,
+			MediaURL: medium.MediaUrl,
+			Width:    medium.Width,
+			Height:   medium.Height,
+			AltText:  medium.AltText,
+		})
+	}
+	return rsp
+}
+
+// albumMediaParams checks an album's items and turns them into rows, with
+// alt text stripped of markup. The upload keys to claim come back alongside.
+// It writes the error response itself and returns false if the items can't be used.
+func (s *Server) albumMediaParams(ctx *gin.Context, user db.User, items []albumMediaRequest) ([]db.CreatePostMediaParams, []string, bool) {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	media := make([]db.CreatePostMediaParams, 0, len(items))
+	keys := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+
+	for _, item := range items {
+		if seen[item.MediaURL] {
+			log.Error("Invalid album", errAlbumDuplicate)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errAlbumDuplicate))
+			return nil, nil, false
+		}
+		seen[item.MediaURL] = true
+
+		altText := util.StripMarkup(item.AltText)
+		if len([]rune(altText)) > maxAltTextLength {
+			log.Error("Invalid alt text", errAltTextTooLong)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errAltTextTooLong))
+			return nil, nil, false
+		}
+
+		key, ok := s.checkPostUpload(ctx, user, item.MediaURL, pgtype.UUID{})
+		if !ok {
+			return nil, nil, false
+		}
+		keys = append(keys, key)
+
+		media = append(media, db.CreatePostMediaParams{
+			MediaUrl: item.MediaURL,
+			Width:    item.Width,
+			Height:   item.Height,
+			AltText:  altText,
+		})
+	}
+
+	return media, keys, true
+}
+
+// albumMedia loads the media of the given posts, keyed by post and in order.
+// Only album posts are looked up.
+func (s *Server) albumMedia(ctx context.Context, posts map[pgtype.UUID]db.NullPostType) map[pgtype.UUID][]postMediaResponse {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	var ids []pgtype.UUID
+	for id, postType := range posts {
+		if postType.PostType == db.PostTypeAlbum {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	media, err := s.hub.ListPostMediaByPosts(ctx, ids)
+	if err != nil {
+		log.Error("Failed to list album media", err)
+		return nil
+	}
+
+	byPost := make(map[pgtype.UUID][]db.PostMedium, len(ids))
+	for _, medium := range media {
+		byPost[medium.PostID] = append(byPost[medium.PostID], medium)
+	}
+
+	rsp := make(map[pgtype.UUID][]postMediaResponse, len(byPost))
+	for id, items := range byPost {
+		rsp[id] = newPostMediaResponses(items)
+	}
+	return rsp
+}
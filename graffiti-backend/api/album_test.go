@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func randomAlbumItems(n int) []gin.H {
+	items := make([]gin.H, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, gin.H{
+			"media_url": randomUploadURL(),
+			"width":     1080,
+			"height":    1350,
+			"alt_text":  fmt.Sprintf("Photo %d", i+1),
+		})
+	}
+	return items
+}
+
+func TestCreateAlbumPostAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	items := randomAlbumItems(3)
+
+	album := randomPost(t, wall.ID, user.ID)
+	album.PostType = db.NullPostType{PostType: db.PostTypeAlbum, Valid: true}
+	album.MediaUrl = pgtype.Text{String: items[0]["media_url"].(string), Valid: true}
+
+	media := make([]db.PostMedium, 0, len(items))
+	for i, item := range items {
+		media = append(media, db.PostMedium{
+			ID:       randomWall(t, user.ID).ID,
+			PostID:   album.ID,
+			Position: int32(i),
+			MediaUrl: item["media_url"].(string),
+			Width:    1080,
+			Height:   1350,
+			AltText:  item["alt_text"].(string),
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"wall_id": wall.ID, "post_type": "album", "media": items},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreateAlbumPostTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAlbumPostTxParams) (db.CreateAlbumPostTxResult, error) {
+						require.Equal(t, db.PostTypeAlbum, arg.Post.PostType.PostType)
+						require.Equal(t, album.MediaUrl, arg.Post.MediaUrl)
+						require.Len(t, arg.Media, 3)
+						require.Len(t, arg.UploadKeys, 3)
+						for i, item := range items {
+							require.Equal(t, item["media_url"], arg.Media[i].MediaUrl)
+							require.Equal(t, util.ExtractKeyFromMediaURL(item["media_url"].(string)), arg.UploadKeys[i])
+						}
+						return db.CreateAlbumPostTxResult{Post: album, Media: media}, nil
+					})
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var rsp postResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, "album", rsp.PostType)
+				require.Len(t, rsp.Media, 3)
+				for i, medium := range rsp.Media {
+					require.Equal(t, int32(i), medium.Position)
+					require.Equal(t, items[i]["media_url"], medium.MediaURL)
+					require.Equal(t, items[i]["alt_text"], medium.AltText)
+				}
+			},
+		},
+		{
+			name: "BadRequest_TooFewItems",
+			body: gin.H{"wall_id": wall.ID, "post_type": "album", "media": items[:1]},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreateAlbumPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_TooManyItems",
+			body: gin.H{"wall_id": wall.ID, "post_type": "album", "media": randomAlbumItems(maxAlbumItems + 1)},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreateAlbumPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_DuplicateItems",
+			body: gin.H{"wall_id": wall.ID, "post_type": "album", "media": []gin.H{items[0], items[1], items[0]}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreateAlbumPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_MissingDimensions",
+			body: gin.H{"wall_id": wall.ID, "post_type": "album", "media": []gin.H{
+				items[0],
+				{"media_url": randomUploadURL()},
+			}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreateAlbumPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_ItemsOnMediaPost",
+			body: gin.H{"wall_id": wall.ID, "post_type": "media", "media_url": randomUploadURL(), "media": items},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+			expectPostUpload(server, mockHub, user.ID)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createPost(ctx)
+			})
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetAlbumPostAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	album := randomPost(t, randomWall(t, user.ID).ID, user.ID)
+	album.PostType = db.NullPostType{PostType: db.PostTypeAlbum, Valid: true}
+	album.Status = db.PostStatusApproved
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().GetPost(gomock.Any(), album.ID).Return(album, nil)
+	mockHub.EXPECT().
+		ListPostMediaByPosts(gomock.Any(), []pgtype.UUID{album.ID}).
+		Times(1).
+		Return([]db.PostMedium{
+			{PostID: album.ID, Position: 0, MediaUrl: album.MediaUrl.String, Width: 800, Height: 600},
+			{PostID: album.ID, Position: 1, MediaUrl: randomUploadURL(), Width: 600, Height: 800},
+		}, nil)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/posts/%s", album.ID.String()), nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp postResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp.Media, 2)
+	require.Equal(t, album.MediaUrl.String, rsp.Media[0].MediaURL)
+	require.Equal(t, int32(1), rsp.Media[1].Position)
+}
+
+func TestUpdateAlbumPostMediaAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	album := randomPost(t, randomWall(t, user.ID).ID, user.ID)
+	album.PostType = db.NullPostType{PostType: db.PostTypeAlbum, Valid: true}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().GetPost(gomock.Any(), album.ID).Return(album, nil)
+	mockHub.EXPECT().
+		UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server.router.PUT("/test/posts/:id", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.updatePost(ctx)
+	})
+
+	data, err := json.Marshal(gin.H{"media_url": randomUploadURL()})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/test/posts/%s", album.ID.String()), bytes.NewReader(data))
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
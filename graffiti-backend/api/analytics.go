@@ -0,0 +1,205 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	topContributorsLimit = 10
+)
+
+type wallAnalyticsRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+type dailyViewsResponse struct {
+	Date  string `json:"date"`
+	Views int64  `json:"views"`
+}
+
+type contributorResponse struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	Fullname       string `json:"fullname"`
+	ProfilePicture string `json:"profile_picture"`
+	PostCount      int64  `json:"post_count"`
+	LikesReceived  int64  `json:"likes_received"`
+}
+
+type postLikesResponse struct {
+	ID         string    `json:"id"`
+	PostType   string    `json:"post_type"`
+	MediaURL   string    `json:"media_url"`
+	Caption    string    `json:"caption"`
+	LikesCount int32     `json:"likes_count"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type wallAnalyticsResponse struct {
+	WallID          string                `json:"wall_id"`
+	Days            int                   `json:"days"`
+	TotalViews      int64                 `json:"total_views"`
+	UniqueVisitors  int64                 `json:"unique_visitors"`
+	ViewsOverTime   []dailyViewsResponse  `json:"views_over_time"`
+	TopContributors []contributorResponse `json:"top_contributors"`
+	Posts           []postLikesResponse   `json:"posts"`
+}
+
+// fillDailyViews returns one entry per day from since to today, with zero for days without views
+func fillDailyViews(rows []db.ListWallViewsByDayRow, since time.Time, days int) []dailyViewsResponse {
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ViewDate.Time.Format(time.DateOnly)] = row.Views
+	}
+
+	series := make([]dailyViewsResponse, 0, days)
+	for i := 0; i < days; i++ {
+		date := since.AddDate(0, 0, i).Format(time.DateOnly)
+		series = append(series, dailyViewsResponse{Date: date, Views: counts[date]})
+	}
+	return series
+}
+
+// GetWallAnalytics handler
+func (s *Server) getWallAnalytics(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received get wall analytics request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req wallAnalyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Days == 0 {
+		req.Days = defaultAnalyticsDays
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to view wall analytics", errors.New("user not authorized to view analytics for this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to view analytics for this wall")))
+		return
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, -(req.Days - 1))
+	sinceDate := pgtype.Date{Time: since, Valid: true}
+
+	views, err := s.hub.ListWallViewsByDay(ctx, db.ListWallViewsByDayParams{
+		WallID:   id,
+		ViewDate: sinceDate,
+	})
+	if err != nil {
+		log.Error("Failed to list wall views", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	uniqueVisitors, err := s.hub.CountUniqueWallVisitors(ctx, db.CountUniqueWallVisitorsParams{
+		WallID:   id,
+		ViewDate: sinceDate,
+	})
+	if err != nil {
+		log.Error("Failed to count unique visitors", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	contributors, err := s.hub.ListTopWallContributors(ctx, db.ListTopWallContributorsParams{
+		WallID: id,
+		Limit:  topContributorsLimit,
+	})
+	if err != nil {
+		log.Error("Failed to list top contributors", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	posts, err := s.hub.ListPostLikesByWall(ctx, id)
+	if err != nil {
+		log.Error("Failed to list post likes", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := wallAnalyticsResponse{
+		WallID:          wall.ID.String(),
+		Days:            req.Days,
+		UniqueVisitors:  uniqueVisitors,
+		ViewsOverTime:   fillDailyViews(views, since, req.Days),
+		TopContributors: make([]contributorResponse, 0, len(contributors)),
+		Posts:           make([]postLikesResponse, 0, len(posts)),
+	}
+	for _, day := range views {
+		rsp.TotalViews += day.Views
+	}
+	for _, contributor := range contributors {
+		rsp.TopContributors = append(rsp.TopContributors, contributorResponse{
+			UserID:         contributor.ID.String(),
+			Username:       contributor.Username,
+			Fullname:       contributor.Fullname.String,
+			ProfilePicture: contributor.ProfilePicture.String,
+			PostCount:      contributor.PostCount,
+			LikesReceived:  contributor.LikesReceived,
+		})
+	}
+	for _, post := range posts {
+		rsp.Posts = append(rsp.Posts, postLikesResponse{
+			ID:         post.ID.String(),
+			PostType:   string(post.PostType.PostType),
+			MediaURL:   post.MediaUrl.String,
+			Caption:    post.Caption.String,
+			LikesCount: post.LikesCount.Int32,
+			CreatedAt:  post.CreatedAt.Time,
+		})
+	}
+
+	log.Info("Wall analytics retrieved successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestGetWallAnalyticsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	post := randomPost(t, wall.ID, otherUser.ID)
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: user,
+			query:       "?days=7",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListWallViewsByDay(gomock.Any(), db.ListWallViewsByDayParams{
+						WallID:   wall.ID,
+						ViewDate: pgtype.Date{Time: today.AddDate(0, 0, -6), Valid: true},
+					}).
+					Times(1).
+					Return([]db.ListWallViewsByDayRow{
+						{ViewDate: pgtype.Date{Time: today.AddDate(0, 0, -2), Valid: true}, Views: 3},
+						{ViewDate: pgtype.Date{Time: today, Valid: true}, Views: 2},
+					}, nil)
+				mockHub.EXPECT().
+					CountUniqueWallVisitors(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(4), nil)
+				mockHub.EXPECT().
+					ListTopWallContributors(gomock.Any(), db.ListTopWallContributorsParams{
+						WallID: wall.ID,
+						Limit:  topContributorsLimit,
+					}).
+					Times(1).
+					Return([]db.ListTopWallContributorsRow{{
+						ID:            otherUser.ID,
+						Username:      otherUser.Username,
+						PostCount:     1,
+						LikesReceived: 5,
+					}}, nil)
+				mockHub.EXPECT().
+					ListPostLikesByWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return([]db.ListPostLikesByWallRow{{
+						ID:         post.ID,
+						PostType:   post.PostType,
+						MediaUrl:   post.MediaUrl,
+						LikesCount: pgtype.Int4{Int32: 5, Valid: true},
+						CreatedAt:  post.CreatedAt,
+					}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp wallAnalyticsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, 7, rsp.Days)
+				require.Equal(t, int64(5), rsp.TotalViews)
+				require.Equal(t, int64(4), rsp.UniqueVisitors)
+				require.Len(t, rsp.ViewsOverTime, 7)
+				require.Equal(t, today.Format(time.DateOnly), rsp.ViewsOverTime[6].Date)
+				require.Equal(t, int64(2), rsp.ViewsOverTime[6].Views)
+				require.Equal(t, int64(3), rsp.ViewsOverTime[4].Views)
+				require.Equal(t, int64(0), rsp.ViewsOverTime[5].Views)
+				require.Len(t, rsp.TopContributors, 1)
+				require.Equal(t, otherUser.Username, rsp.TopContributors[0].Username)
+				require.Len(t, rsp.Posts, 1)
+				require.Equal(t, int32(5), rsp.Posts[0].LikesCount)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListWallViewsByDay(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_Days",
+			currentUser: user,
+			query:       "?days=1000",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.Wall{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/walls/:id/analytics", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.getWallAnalytics(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/analytics%s", wall.ID.String(), tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestViewTrackerFlush(t *testing.T) {
+	user, _ := randomUser(t)
+	viewer, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	ctrl := gomock.NewController(t)
+	mockHub := mockdb.NewMockHub(ctrl)
+	tracker := newViewTracker(mockHub)
+
+	// Repeat views on the same day are only sent once
+	tracker.Record(wall.ID, viewer.ID)
+	tracker.Record(wall.ID, viewer.ID)
+
+	gomock.InOrder(
+		mockHub.EXPECT().
+			RecordWallViews(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(errors.New("connection lost")),
+		mockHub.EXPECT().
+			RecordWallViews(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.RecordWallViewsParams) error {
+				require.Equal(t, []pgtype.UUID{wall.ID}, arg.WallIds)
+				require.Equal(t, []pgtype.UUID{viewer.ID}, arg.ViewerIds)
+				require.Len(t, arg.ViewDates, 1)
+				return nil
+			}),
+	)
+
+	// A failed flush keeps the views for the next attempt
+	require.Error(t, tracker.Flush(context.Background()))
+	require.NoError(t, tracker.Flush(context.Background()))
+
+	// Nothing left to write
+	require.NoError(t, tracker.Flush(context.Background()))
+}
+
+func TestNilViewTracker(t *testing.T) {
+	var tracker *viewTracker
+
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	tracker.Record(wall.ID, user.ID)
+	tracker.Start()
+	require.NoError(t, tracker.Flush(context.Background()))
+	require.NoError(t, tracker.Stop(context.Background()))
+}
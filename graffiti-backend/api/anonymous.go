@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// anonymousUsername stands in for the author of an anonymous post
+const anonymousUsername = "Anonymous"
+
+var (
+	errAnonymousNotAllowed = errors.New("this wall does not allow anonymous posts")
+	errNotAdmin            = errors.New("only admins can see who wrote an anonymous post")
+)
+
+type setWallAnonymousRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+type postAuthorResponse struct {
+	PostID         string `json:"post_id"`
+	AuthorID       string `json:"author_id"`
+	Username       string `json:"username"`
+	Fullname       string `json:"fullname"`
+	ProfilePicture string `json:"profile_picture"`
+}
+
+// newPostResponseFor is newPostResponse for a post shown to viewer. Anonymous
+// posts still show their author to the author themselves.
+func newPostResponseFor(post db.Post, viewer pgtype.UUID) postResponse {
+	response := newPostResponse(post)
+	if post.Author == viewer {
+		response.Author = post.Author.String()
+	}
+	return response
+}
+
+// hideAnonymousAuthor strips the author's details from an anonymous post shown
+// to anyone but its author. The author is still recorded on the post itself.
+func hideAnonymousAuthor(post db.ListPostsByWallWithAuthorsDetailsRow, viewer pgtype.UUID) db.ListPostsByWallWithAuthorsDetailsRow {
+	if !post.IsAnonymous || post.Author == viewer {
+		return post
+	}
+	post.Author = pgtype.UUID{}
+	post.Username = anonymousUsername
+	post.ProfilePicture = pgtype.Text{}
+	post.Fullname = pgtype.Text{}
+	return post
+}
+
+// postNotificationSender is who a notification about post is sent from. The
+// author of an anonymous post can't be looked up from the notification, so it
+// comes from the recipient instead.
+func postNotificationSender(post db.Post, recipient string) string {
+	if post.IsAnonymous {
+		return recipient
+	}
+	return post.Author.String()
+}
+
+// postAuthorName is how notifications about post refer to its author
+func postAuthorName(post db.Post, author db.User) string {
+	if post.IsAnonymous {
+		return "Someone"
+	}
+	return author.Username
+}
+
+// SetWallAnonymous handler lets the wall owner allow or stop anonymous posts on a
+// wall. Posts already made anonymously stay anonymous.
+func (s *Server) setWallAnonymous(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set wall anonymous request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setWallAnonymousRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to change anonymous posting", errors.New("user not authorized to change anonymous posting for this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to change anonymous posting for this wall")))
+		return
+	}
+
+	wall, err = s.hub.SetWallAllowAnonymous(ctx, db.SetWallAllowAnonymousParams{
+		ID:             id,
+		AllowAnonymous: *req.Enabled,
+	})
+	if err != nil {
+		log.Error("Failed to set wall anonymous posting", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall anonymous posting updated successfully")
+	ctx.JSON(http.StatusOK, newWallResponse(wall))
+}
+
+// GetPostAuthor handler reveals who wrote a post, anonymous or not, so admins
+// can deal with abuse. Every lookup is logged.
+func (s *Server) getPostAuthor(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received get post author request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	if currentUser.Role != roleAdmin {
+		log.Error("Unauthorized to reveal post author", errNotAdmin)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errNotAdmin))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	author, err := s.hub.GetUser(ctx, post.Author)
+	if err != nil {
+		log.Error("Failed to get post author", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Admin %s revealed the author of post %s", currentUser.ID.String(), post.ID.String())
+	ctx.JSON(http.StatusOK, postAuthorResponse{
+		PostID:         post.ID.String(),
+		AuthorID:       author.ID.String(),
+		Username:       author.Username,
+		Fullname:       author.Fullname.String,
+		ProfilePicture: author.ProfilePicture.String,
+	})
+}
@@ -0,0 +1,419 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestSetWallAnonymousAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	anonymous := wall
+	anonymous.AllowAnonymous = true
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					SetWallAllowAnonymous(gomock.Any(), db.SetWallAllowAnonymousParams{
+						ID:             wall.ID,
+						AllowAnonymous: true,
+					}).
+					Times(1).
+					Return(anonymous, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got wallResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.AllowAnonymous)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					SetWallAllowAnonymous(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.Wall{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					SetWallAllowAnonymous(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/anonymous", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.setWallAnonymous(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"enabled": true})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/anonymous", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateAnonymousPostAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	anonymousWall := wall
+	anonymousWall.AllowAnonymous = true
+
+	post := randomPost(t, anonymousWall.ID, author.ID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeText, Valid: true}
+	post.MediaUrl = pgtype.Text{}
+	post.Caption = pgtype.Text{String: "You always make my day", Valid: true}
+	post.IsAnonymous = true
+
+	testCases := []struct {
+		name          string
+		wall          db.Wall
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			wall: anonymousWall,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), anonymousWall.ID).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePostParams, _ string) (db.Post, error) {
+						require.True(t, arg.IsAnonymous)
+						require.Equal(t, author.ID, arg.Author)
+						return post, nil
+					})
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				// The author still sees the post as their own
+				var got postResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.IsAnonymous)
+				require.Equal(t, author.ID.String(), got.Author)
+			},
+		},
+		{
+			name: "BadRequest_NotAllowed",
+			wall: wall,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().
+				GetWall(gomock.Any(), tc.wall.ID).
+				Times(1).
+				Return(tc.wall, nil)
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", author)
+				server.createPost(ctx)
+			})
+
+			data, err := json.Marshal(gin.H{
+				"wall_id":   tc.wall.ID.String(),
+				"post_type": "text",
+				"caption":   post.Caption.String,
+				"anonymous": true,
+			})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListAnonymousPostsAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	wall.AllowAnonymous = true
+
+	post := randomPost(t, wall.ID, author.ID)
+	row := db.ListPostsByWallWithAuthorsDetailsRow{
+		ID:             post.ID,
+		WallID:         post.WallID,
+		Author:         post.Author,
+		MediaUrl:       post.MediaUrl,
+		PostType:       post.PostType,
+		Status:         db.PostStatusApproved,
+		IsAnonymous:    true,
+		Username:       author.Username,
+		ProfilePicture: author.ProfilePicture,
+		Fullname:       author.Fullname,
+	}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		checkResponse func(t *testing.T, got PostResponseWithAuthor)
+	}{
+		{
+			name:        "Masked_WallOwner",
+			currentUser: owner,
+			checkResponse: func(t *testing.T, got PostResponseWithAuthor) {
+				require.True(t, got.IsAnonymous)
+				require.Equal(t, anonymousUsername, got.Username)
+				require.False(t, got.ProfilePicture.Valid)
+				require.False(t, got.Fullname.Valid)
+			},
+		},
+		{
+			name:        "Visible_Author",
+			currentUser: author,
+			checkResponse: func(t *testing.T, got PostResponseWithAuthor) {
+				require.True(t, got.IsAnonymous)
+				require.Equal(t, author.Username, got.Username)
+				require.Equal(t, author.Fullname, got.Fullname)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().
+				ListPostsByWallWithAuthorsDetails(gomock.Any(), db.ListPostsByWallWithAuthorsDetailsParams{
+					WallID: wall.ID,
+					Author: tc.currentUser.ID,
+				}).
+				Times(1).
+				Return([]db.ListPostsByWallWithAuthorsDetailsRow{row}, nil)
+
+			server.router.GET("/test/walls/:id/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.listPostsByWallWithAuthorsDetails(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/posts", wall.ID.String())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var got []PostResponseWithAuthor
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Len(t, got, 1)
+			tc.checkResponse(t, got[0])
+		})
+	}
+}
+
+func TestGetPostAuthorAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = roleAdmin
+	moderator, _ := randomUser(t)
+	moderator.Role = roleModerator
+	author, _ := randomUser(t)
+
+	wall := randomWall(t, author.ID)
+	post := randomPost(t, wall.ID, author.ID)
+	post.IsAnonymous = true
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: admin,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), author.ID).
+					Times(1).
+					Return(author, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got postAuthorResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, post.ID.String(), got.PostID)
+				require.Equal(t, author.ID.String(), got.AuthorID)
+				require.Equal(t, author.Username, got.Username)
+				require.Equal(t, author.Fullname.String, got.Fullname)
+				require.Equal(t, author.ProfilePicture.String, got.ProfilePicture)
+			},
+		},
+		{
+			name:        "Unauthorized_Moderator",
+			currentUser: moderator,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: admin,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(db.Post{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/posts/:id/author", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.getPostAuthor(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/posts/%s/author", post.ID.String())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestNewPostResponseHidesAnonymousAuthor(t *testing.T) {
+	author, _ := randomUser(t)
+	other, _ := randomUser(t)
+	wall := randomWall(t, other.ID)
+
+	post := randomPost(t, wall.ID, author.ID)
+	post.IsAnonymous = true
+
+	require.Empty(t, newPostResponse(post).Author)
+	require.Empty(t, newPostResponseFor(post, other.ID).Author)
+	require.Equal(t, author.ID.String(), newPostResponseFor(post, author.ID).Author)
+
+	post.IsAnonymous = false
+	require.Equal(t, author.ID.String(), newPostResponse(post).Author)
+}
@@ -93,6 +93,14 @@ func (s *Server) Login(ctx *gin.Context) {
 		return
 	}
 
+	if isSuspended(user) {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":           "Account suspended",
+			"suspended_until": user.SuspendedUntil.Time,
+		})
+		return
+	}
+
 	token, _, err := s.tokenMaker.CreateToken(user.Username, time.Hour)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -156,6 +164,7 @@ func (s *Server) Me(ctx *gin.Context) {
 		Bio:             user.Bio.String,
 		ProfilePicture:  user.ProfilePicture.String,
 		BackgroundImage: user.BackgroundImage.String,
+		Role:            user.Role,
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
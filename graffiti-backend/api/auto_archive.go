@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	// autoArchiveWarningDays is how long before archiving the owner is warned
+	autoArchiveWarningDays = 3
+	minAutoArchiveDays     = 7
+	maxAutoArchiveDays     = 365
+)
+
+// autoArchiveResult counts what an auto-archive run did
+type autoArchiveResult struct {
+	Warned   int
+	Archived int
+}
+
+type setAutoArchiveRequest struct {
+	// Days of inactivity before a wall is archived, 0 turns auto-archiving off
+	Days *int32 `json:"days" binding:"required,min=0,max=365"`
+}
+
+type autoArchiveSettingsResponse struct {
+	Enabled bool  `json:"enabled"`
+	Days    int32 `json:"days"`
+}
+
+type autoArchivePreviewRequest struct {
+	Days int32 `form:"days" binding:"omitempty,min=7,max=365"`
+}
+
+type autoArchivePreviewWallResponse struct {
+	wallResponse
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ArchiveAt      time.Time `json:"archive_at"`
+}
+
+type autoArchivePreviewResponse struct {
+	Days  int32                            `json:"days"`
+	Walls []autoArchivePreviewWallResponse `json:"walls"`
+}
+
+// archiveWarned reports whether the owner was warned since the wall's last activity.
+// A new post or an unarchive moves the last activity past the warning, which resets it.
+func archiveWarned(warnedAt, lastActivityAt pgtype.Timestamp) bool {
+	return warnedAt.Valid && !warnedAt.Time.Before(lastActivityAt.Time)
+}
+
+// archiveDueAt is the earliest time a wall can be archived: once it has been
+// inactive for the full period and the owner was warned long enough ago.
+func archiveDueAt(lastActivityAt, warnedAt pgtype.Timestamp, days int32, now time.Time) time.Time {
+	due := lastActivityAt.Time.AddDate(0, 0, int(days))
+
+	notice := now.AddDate(0, 0, autoArchiveWarningDays)
+	if archiveWarned(warnedAt, lastActivityAt) {
+		notice = warnedAt.Time.AddDate(0, 0, autoArchiveWarningDays)
+	}
+
+	if notice.After(due) {
+		return notice
+	}
+	return due
+}
+
+// autoArchiveWalls warns owners whose walls are close to their inactivity limit
+// and archives the walls that were warned about and are now past it.
+func (s *Server) autoArchiveWalls(ctx context.Context) (autoArchiveResult, error) {
+	log := logger.Global()
+
+	var result autoArchiveResult
+	walls, err := s.hub.ListAutoArchiveCandidates(ctx, autoArchiveWarningDays)
+	if err != nil {
+		return result, err
+	}
+
+	now := time.Now()
+	for _, wall := range walls {
+		if !archiveWarned(wall.ArchiveWarnedAt, wall.LastActivityAt) {
+			if err := s.hub.MarkWallArchiveWarned(ctx, wall.ID); err != nil {
+				return result, err
+			}
+			result.Warned++
+
+			owner := wall.UserID.String()
+			message := fmt.Sprintf("Your wall \"%s\" will be archived in %d days because it has no new posts", wall.Title, autoArchiveWarningDays)
+			if err := s.SendNotification(ctx, owner, owner, "wall_archive_warning", wall.ID.String(), message); err != nil {
+				log.Error("Failed to send archive warning notification", err)
+			}
+			continue
+		}
+
+		if now.Before(archiveDueAt(wall.LastActivityAt, wall.ArchiveWarnedAt, wall.AutoArchiveDays.Int32, now)) {
+			continue
+		}
+
+		if err := s.hub.ArchiveWall(ctx, wall.ID); err != nil {
+			return result, err
+		}
+		result.Archived++
+	}
+
+	log.Info("Auto-archive warned %d walls and archived %d walls", result.Warned, result.Archived)
+	return result, nil
+}
+
+// SetAutoArchive handler
+func (s *Server) setAutoArchive(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set auto-archive request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var req setAutoArchiveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	days := *req.Days
+	if days != 0 && days < minAutoArchiveDays {
+		err := fmt.Errorf("auto-archive must be between %d and %d days", minAutoArchiveDays, maxAutoArchiveDays)
+		log.Error("Invalid auto-archive days", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := s.hub.SetUserAutoArchiveDays(ctx, db.SetUserAutoArchiveDaysParams{
+		ID:              currentUser.ID,
+		AutoArchiveDays: pgtype.Int4{Int32: days, Valid: days != 0},
+	})
+	if err != nil {
+		log.Error("Failed to update auto-archive setting", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Auto-archive setting updated successfully")
+	ctx.JSON(http.StatusOK, autoArchiveSettingsResponse{
+		Enabled: user.AutoArchiveDays.Valid,
+		Days:    user.AutoArchiveDays.Int32,
+	})
+}
+
+// PreviewAutoArchive handler lists the walls that would be archived with the
+// given setting, defaulting to the user's current one
+func (s *Server) previewAutoArchive(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received preview auto-archive request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var req autoArchivePreviewRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Days == 0 {
+		if !currentUser.AutoArchiveDays.Valid {
+			log.Error("Auto-archive not enabled", errors.New("auto-archive is not enabled"))
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("auto-archive is not enabled, pass days to preview a setting")))
+			return
+		}
+		req.Days = currentUser.AutoArchiveDays.Int32
+	}
+
+	// Walls that would get a warning on the next run are included, since they
+	// are archived a few days after it
+	now := time.Now()
+	walls, err := s.hub.ListInactiveWallsByUser(ctx, db.ListInactiveWallsByUserParams{
+		UserID:        currentUser.ID,
+		InactiveSince: pgtype.Timestamp{Time: now.AddDate(0, 0, -int(req.Days-autoArchiveWarningDays)), Valid: true},
+	})
+	if err != nil {
+		log.Error("Failed to list inactive walls", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := autoArchivePreviewResponse{
+		Days:  req.Days,
+		Walls: make([]autoArchivePreviewWallResponse, 0, len(walls)),
+	}
+	for _, wall := range walls {
+		rsp.Walls = append(rsp.Walls, autoArchivePreviewWallResponse{
+			wallResponse: newWallResponse(db.Wall{
+				ID:                wall.ID,
+				UserID:            wall.UserID,
+				Title:             wall.Title,
+				Description:       wall.Description,
+				BackgroundImage:   wall.BackgroundImage,
+				IsPublic:          wall.IsPublic,
+				IsArchived:        wall.IsArchived,
+				IsDeleted:         wall.IsDeleted,
+				PopularityScore:   wall.PopularityScore,
+				CreatedAt:         wall.CreatedAt,
+				UpdatedAt:         wall.UpdatedAt,
+				IsPinned:          wall.IsPinned,
+				ModerationEnabled: wall.ModerationEnabled,
+				DeletedAt:         wall.DeletedAt,
+				FollowerCount:     wall.FollowerCount,
+				PinOrder:          wall.PinOrder,
+				ArchiveWarnedAt:   wall.ArchiveWarnedAt,
+				UnarchivedAt:      wall.UnarchivedAt,
+				IsHidden:          wall.IsHidden,
+				AllowAnonymous:    wall.AllowAnonymous,
+			}),
+			LastActivityAt: wall.LastActivityAt.Time,
+			ArchiveAt:      archiveDueAt(wall.LastActivityAt, wall.ArchiveWarnedAt, req.Days, now),
+		})
+	}
+
+	log.Info("Auto-archive preview retrieved successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,328 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestAutoArchiveWalls(t *testing.T) {
+	user, _ := randomUser(t)
+	days := pgtype.Int4{Int32: 30, Valid: true}
+	now := time.Now()
+
+	timestamp := func(daysAgo int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: now.AddDate(0, 0, -daysAgo), Valid: true}
+	}
+
+	// Close to the limit and never warned
+	unwarned := db.ListAutoArchiveCandidatesRow{
+		ID:              randomWall(t, user.ID).ID,
+		UserID:          user.ID,
+		Title:           "unwarned",
+		AutoArchiveDays: days,
+		LastActivityAt:  timestamp(28),
+	}
+	// Past the limit and warned long enough ago
+	due := db.ListAutoArchiveCandidatesRow{
+		ID:              randomWall(t, user.ID).ID,
+		UserID:          user.ID,
+		Title:           "due",
+		AutoArchiveDays: days,
+		LastActivityAt:  timestamp(40),
+		ArchiveWarnedAt: timestamp(5),
+	}
+	// Past the limit but only just warned
+	recentlyWarned := db.ListAutoArchiveCandidatesRow{
+		ID:              randomWall(t, user.ID).ID,
+		UserID:          user.ID,
+		Title:           "recently warned",
+		AutoArchiveDays: days,
+		LastActivityAt:  timestamp(40),
+		ArchiveWarnedAt: timestamp(1),
+	}
+	// Warned, then posted on again, so the warning no longer counts
+	staleWarning := db.ListAutoArchiveCandidatesRow{
+		ID:              randomWall(t, user.ID).ID,
+		UserID:          user.ID,
+		Title:           "stale warning",
+		AutoArchiveDays: days,
+		LastActivityAt:  timestamp(28),
+		ArchiveWarnedAt: timestamp(35),
+	}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListAutoArchiveCandidates(gomock.Any(), int32(autoArchiveWarningDays)).
+		Times(1).
+		Return([]db.ListAutoArchiveCandidatesRow{unwarned, due, recentlyWarned, staleWarning}, nil)
+	mockHub.EXPECT().
+		MarkWallArchiveWarned(gomock.Any(), unwarned.ID).
+		Times(1).
+		Return(nil)
+	mockHub.EXPECT().
+		MarkWallArchiveWarned(gomock.Any(), staleWarning.ID).
+		Times(1).
+		Return(nil)
+	mockHub.EXPECT().
+		ArchiveWall(gomock.Any(), due.ID).
+		Times(1).
+		Return(nil)
+
+	result, err := server.autoArchiveWalls(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Warned)
+	require.Equal(t, 1, result.Archived)
+}
+
+func TestSetAutoArchiveAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"days": 30},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				updated := user
+				updated.AutoArchiveDays = pgtype.Int4{Int32: 30, Valid: true}
+				mockHub.EXPECT().
+					SetUserAutoArchiveDays(gomock.Any(), db.SetUserAutoArchiveDaysParams{
+						ID:              user.ID,
+						AutoArchiveDays: pgtype.Int4{Int32: 30, Valid: true},
+					}).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp autoArchiveSettingsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.True(t, rsp.Enabled)
+				require.Equal(t, int32(30), rsp.Days)
+			},
+		},
+		{
+			name: "OK_Disable",
+			body: gin.H{"days": 0},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetUserAutoArchiveDays(gomock.Any(), db.SetUserAutoArchiveDaysParams{ID: user.ID}).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp autoArchiveSettingsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.False(t, rsp.Enabled)
+			},
+		},
+		{
+			name: "BadRequest_TooShort",
+			body: gin.H{"days": 3},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetUserAutoArchiveDays(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_MissingDays",
+			body: gin.H{},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetUserAutoArchiveDays(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/users/me/auto-archive", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.setAutoArchive(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/test/users/me/auto-archive", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPreviewAutoArchiveAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	enabledUser := user
+	enabledUser.AutoArchiveDays = pgtype.Int4{Int32: 30, Valid: true}
+	wall := randomWall(t, user.ID)
+
+	lastActivity := pgtype.Timestamp{Time: time.Now().AddDate(0, 0, -29), Valid: true}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_UserSetting",
+			currentUser: enabledUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListInactiveWallsByUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ListInactiveWallsByUserParams) ([]db.ListInactiveWallsByUserRow, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, -27), arg.InactiveSince.Time, time.Minute)
+						return []db.ListInactiveWallsByUserRow{{
+							ID:             wall.ID,
+							UserID:         wall.UserID,
+							Title:          wall.Title,
+							IsHidden:       true,
+							AllowAnonymous: true,
+							LastActivityAt: lastActivity,
+						}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp autoArchivePreviewResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, int32(30), rsp.Days)
+				require.Len(t, rsp.Walls, 1)
+				require.Equal(t, wall.ID.String(), rsp.Walls[0].ID)
+				require.True(t, rsp.Walls[0].IsHidden)
+				require.True(t, rsp.Walls[0].AllowAnonymous)
+
+				// Not warned yet, so archiving waits for the warning period
+				require.WithinDuration(t, time.Now().AddDate(0, 0, autoArchiveWarningDays), rsp.Walls[0].ArchiveAt, time.Minute)
+			},
+		},
+		{
+			name:        "OK_QueryDays",
+			currentUser: user,
+			query:       "?days=90",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListInactiveWallsByUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListInactiveWallsByUserRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp autoArchivePreviewResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, int32(90), rsp.Days)
+				require.Empty(t, rsp.Walls)
+			},
+		},
+		{
+			name:        "BadRequest_NotEnabled",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListInactiveWallsByUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_Days",
+			currentUser: enabledUser,
+			query:       "?days=2",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListInactiveWallsByUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/users/me/auto-archive/preview", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.previewAutoArchive(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/test/users/me/auto-archive/preview"+tc.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
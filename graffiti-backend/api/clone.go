@@ -0,0 +1,293 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+type cloneWallRequest struct {
+	Title        string `json:"title"`
+	IncludePosts bool   `json:"include_posts"`
+}
+
+type cloneWallResponse struct {
+	wallResponse
+	PostsCopied int `json:"posts_copied"`
+}
+
+// CloneWall handler copies one of the current user's walls, including its settings, tags and sections.
+// With include_posts, the user's own approved posts come along too, each with its own copy
+// of the media so the two walls can be deleted independently.
+func (s *Server) cloneWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received clone wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req cloneWallRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to clone wall", errors.New("user not authorized to clone this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to clone this wall")))
+		return
+	}
+
+	if wall.IsDeleted.Bool {
+		log.Error("Cannot clone deleted wall", errors.New("wall is deleted"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("cannot clone a deleted wall")))
+		return
+	}
+
+	// Otherwise cloning would bring back what moderators took down
+	if wall.IsHidden {
+		log.Error("Cannot clone hidden wall", errors.New("wall is hidden"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("cannot clone a wall hidden by moderators")))
+		return
+	}
+
+	tags, err := s.hub.ListTagsByWall(ctx, wall.ID)
+	if err != nil {
+		log.Error("Failed to list wall tags", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	sections, err := s.hub.ListWallSections(ctx, wall.ID)
+	if err != nil {
+		log.Error("Failed to list wall sections", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var posts []db.Post
+	if req.IncludePosts {
+		posts, err = s.hub.ListClonablePostsByWall(ctx, db.ListClonablePostsByWallParams{
+			WallID: wall.ID,
+			Author: currentUser.ID,
+		})
+		if err != nil {
+			log.Error("Failed to list posts to clone", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	// The background gets its own copy too, so purging the original wall
+	// doesn't take the clone's background with it
+	backgroundImage, backgroundKey, err := s.copyUploadedMedia(ctx, wall.BackgroundImage)
+	if err != nil {
+		log.Error("Failed to copy background image", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	title := req.Title
+	if title == "" {
+		title = fmt.Sprintf("%s (copy)", wall.Title)
+	}
+
+	arg := db.CreateWallTxParams{
+		Wall: db.CreateTestWallParams{
+			UserID:          currentUser.ID,
+			Title:           title,
+			Description:     wall.Description,
+			IsPublic:        wall.IsPublic,
+			BackgroundImage: backgroundImage,
+		},
+		ModerationEnabled: wall.ModerationEnabled.Bool,
+		Tags:              tagNames(tags),
+		Sections:          make([]db.WallSection, 0, len(sections)),
+	}
+	for _, section := range sections {
+		arg.Sections = append(arg.Sections, db.WallSection{
+			ID:        section.ID,
+			Name:      section.Name,
+			IsDefault: section.IsDefault,
+		})
+	}
+
+	copiedKeys, err := s.copyPosts(ctx, posts, &arg)
+	if backgroundKey != "" {
+		copiedKeys = append(copiedKeys, backgroundKey)
+	}
+	if err != nil {
+		log.Error("Failed to copy posts", err)
+		s.discardCopiedMedia(ctx, copiedKeys)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := s.hub.CreateWallTx(ctx, arg)
+	if err != nil {
+		log.Error("Failed to clone wall", err)
+		s.discardCopiedMedia(ctx, copiedKeys)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := cloneWallResponse{
+		wallResponse: newWallResponse(result.Wall),
+		PostsCopied:  len(result.Posts),
+	}
+	rsp.Tags = tagNames(result.Tags)
+
+	log.Info("Wall cloned successfully")
+	ctx.JSON(http.StatusCreated, rsp)
+}
+
+// copyPosts prepares new posts from existing ones, along with their sections, album
+// items and drawing strokes. Media that belongs to a single post is copied to a fresh
+// key; anything else, such as external URLs, is shared.
+// The keys copied so far are returned even on error so they can be cleaned up.
+func (s *Server) copyPosts(ctx *gin.Context, posts []db.Post, arg *db.CreateWallTxParams) ([]string, error) {
+	arg.Posts = make([]db.CreatePostParams, 0, len(posts))
+	arg.PostMedia = make(map[int][]db.CreatePostMediaParams)
+	arg.PostDrawings = make(map[int]db.CreatePostDrawingParams)
+	copied := make([]string, 0, len(posts))
+
+	var albumIDs []pgtype.UUID
+	for _, post := range posts {
+		if post.PostType.PostType == db.PostTypeAlbum {
+			albumIDs = append(albumIDs, post.ID)
+		}
+	}
+	albumMedia := make(map[pgtype.UUID][]db.PostMedium, len(albumIDs))
+	if len(albumIDs) > 0 {
+		media, err := s.hub.ListPostMediaByPosts(ctx, albumIDs)
+		if err != nil {
+			return copied, err
+		}
+		for _, medium := range media {
+			albumMedia[medium.PostID] = append(albumMedia[medium.PostID], medium)
+		}
+	}
+
+	for i, post := range posts {
+		mediaURL, newKey, err := s.copyUploadedMedia(ctx, post.MediaUrl)
+		if err != nil {
+			return copied, err
+		}
+		if newKey != "" {
+			copied = append(copied, newKey)
+		}
+
+		for _, medium := range albumMedia[post.ID] {
+			itemURL, newKey, err := s.copyUploadedMedia(ctx, pgtype.Text{String: medium.MediaUrl, Valid: true})
+			if err != nil {
+				return copied, err
+			}
+			if newKey != "" {
+				copied = append(copied, newKey)
+			}
+			arg.PostMedia[i] = append(arg.PostMedia[i], db.CreatePostMediaParams{
+				MediaUrl: itemURL.String,
+				Width:    medium.Width,
+				Height:   medium.Height,
+				AltText:  medium.AltText,
+			})
+		}
+
+		if post.PostType.PostType == db.PostTypeDrawing {
+			drawing, err := s.hub.GetPostDrawing(ctx, post.ID)
+			if err != nil {
+				return copied, err
+			}
+			arg.PostDrawings[i] = db.CreatePostDrawingParams{
+				Width:   drawing.Width,
+				Height:  drawing.Height,
+				Strokes: drawing.Strokes,
+			}
+		}
+
+		arg.Posts = append(arg.Posts, db.CreatePostParams{
+			Author:    post.Author,
+			MediaUrl:  mediaURL,
+			PostType:  post.PostType,
+			Caption:   post.Caption,
+			PosX:      post.PosX,
+			PosY:      post.PosY,
+			Rotation:  post.Rotation,
+			Scale:     post.Scale,
+			ZIndex:    post.ZIndex,
+			Status:    db.PostStatusApproved,
+			SectionID: post.SectionID,
+			// Cloning keeps anonymous posts anonymous, whatever the new wall allows
+			IsAnonymous: post.IsAnonymous,
+		})
+	}
+
+	return copied, nil
+}
+
+// copyUploadedMedia copies media stored under uploads/ to a fresh key and returns
+// its URL along with the new key. Anything else is returned as is, with no key.
+func (s *Server) copyUploadedMedia(ctx *gin.Context, mediaURL pgtype.Text) (pgtype.Text, string, error) {
+	if !mediaURL.Valid {
+		return mediaURL, "", nil
+	}
+	key := util.ExtractKeyFromMediaURL(mediaURL.String)
+	if !purgeableKey(key) {
+		return mediaURL, "", nil
+	}
+
+	newKey := "uploads/" + uuid.New().String() + getFileExtension(key)
+	if err := s.copyFile(ctx, key, newKey); err != nil {
+		return mediaURL, "", err
+	}
+	return pgtype.Text{String: s.publicURL(newKey), Valid: true}, newKey, nil
+}
+
+// discardCopiedMedia removes media copied for a clone that didn't go through
+func (s *Server) discardCopiedMedia(ctx *gin.Context, keys []string) {
+	if err := s.DeleteFiles(ctx, keys); err != nil {
+		logger.GetMetadata(ctx.Request.Context()).GetLogger().Error("Failed to remove copied media", err)
+	}
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestCloneWallAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	wall.ModerationEnabled = pgtype.Bool{Bool: true, Valid: true}
+	wall.BackgroundImage = pgtype.Text{String: "https://cdn.example.com/uploads/background.jpg", Valid: true}
+
+	uploaded := randomPost(t, wall.ID, user.ID)
+	uploaded.MediaUrl = pgtype.Text{String: "https://cdn.example.com/uploads/original.png", Valid: true}
+	external := randomPost(t, wall.ID, user.ID)
+	external.MediaUrl = pgtype.Text{String: "https://media.giphy.com/media/abc/giphy.gif", Valid: true}
+
+	section := db.ListWallSectionsRow{ID: randomWall(t, user.ID).ID, WallID: wall.ID, Name: "Wishes", Position: 1}
+	uploaded.SectionID = section.ID
+
+	album := randomPost(t, wall.ID, user.ID)
+	album.PostType = db.NullPostType{PostType: db.PostTypeAlbum, Valid: true}
+	albumMedia := []db.PostMedium{
+		{PostID: album.ID, Position: 0, MediaUrl: "https://cdn.example.com/uploads/first.png", Width: 640, Height: 480},
+		{PostID: album.ID, Position: 1, MediaUrl: "https://cdn.example.com/uploads/second.png", Width: 480, Height: 640, AltText: "cake"},
+	}
+	drawing := randomPost(t, wall.ID, user.ID)
+	drawing.PostType = db.NullPostType{PostType: db.PostTypeDrawing, Valid: true}
+	strokes := db.PostDrawing{PostID: drawing.ID, Width: 800, Height: 600, Strokes: []byte(`[{"color":"#000000","width":4,"points":[[0,0],[10,10]]}]`)}
+
+	clone := randomWall(t, user.ID)
+
+	deletedWall := wall
+	deletedWall.IsDeleted = pgtype.Bool{Bool: true, Valid: true}
+	hiddenWall := wall
+	hiddenWall.IsHidden = true
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_WithPosts",
+			currentUser: user,
+			body:        gin.H{"include_posts": true},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListTagsByWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return([]db.Tag{{Name: "birthday"}}, nil)
+				mockHub.EXPECT().
+					ListWallSections(gomock.Any(), wall.ID).
+					Times(1).
+					Return([]db.ListWallSectionsRow{section}, nil)
+				mockHub.EXPECT().
+					ListClonablePostsByWall(gomock.Any(), db.ListClonablePostsByWallParams{
+						WallID: wall.ID,
+						Author: user.ID,
+					}).
+					Times(1).
+					Return([]db.Post{uploaded, external, album, drawing}, nil)
+				mockHub.EXPECT().
+					ListPostMediaByPosts(gomock.Any(), []pgtype.UUID{album.ID}).
+					Times(1).
+					Return(albumMedia, nil)
+				mockHub.EXPECT().
+					GetPostDrawing(gomock.Any(), drawing.ID).
+					Times(1).
+					Return(strokes, nil)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateWallTxParams) (db.CreateWallTxResult, error) {
+						require.Equal(t, user.ID, arg.Wall.UserID)
+						require.Equal(t, wall.Title+" (copy)", arg.Wall.Title)
+						require.True(t, arg.ModerationEnabled)
+						require.Equal(t, []string{"birthday"}, arg.Tags)
+						require.Len(t, arg.Posts, 4)
+
+						// The background is copied as well, purging the original mustn't break the clone
+						require.Contains(t, arg.Wall.BackgroundImage.String, "/uploads/")
+						require.NotEqual(t, wall.BackgroundImage, arg.Wall.BackgroundImage)
+
+						// Uploaded media gets its own copy, external media is shared
+						require.Contains(t, arg.Posts[0].MediaUrl.String, "/uploads/")
+						require.NotEqual(t, uploaded.MediaUrl, arg.Posts[0].MediaUrl)
+						require.Equal(t, external.MediaUrl, arg.Posts[1].MediaUrl)
+						require.Equal(t, db.PostStatusApproved, arg.Posts[0].Status)
+
+						// Posts keep their section, which is recreated on the clone
+						require.Len(t, arg.Sections, 1)
+						require.Equal(t, section.ID, arg.Sections[0].ID)
+						require.Equal(t, section.Name, arg.Sections[0].Name)
+						require.Equal(t, section.ID, arg.Posts[0].SectionID)
+
+						// Albums keep every item, each with its own copy
+						require.Equal(t, db.PostTypeAlbum, arg.Posts[2].PostType.PostType)
+						require.Len(t, arg.PostMedia[2], 2)
+						for i, medium := range arg.PostMedia[2] {
+							require.Contains(t, medium.MediaUrl, "/uploads/")
+							require.NotEqual(t, albumMedia[i].MediaUrl, medium.MediaUrl)
+							require.Equal(t, albumMedia[i].AltText, medium.AltText)
+						}
+
+						// Drawings keep their strokes
+						require.Equal(t, db.PostTypeDrawing, arg.Posts[3].PostType.PostType)
+						require.Equal(t, strokes.Strokes, arg.PostDrawings[3].Strokes)
+						require.Equal(t, strokes.Width, arg.PostDrawings[3].Width)
+
+						return db.CreateWallTxResult{
+							Wall:  clone,
+							Tags:  []db.Tag{{Name: "birthday"}},
+							Posts: []db.Post{uploaded, external, album, drawing},
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp cloneWallResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, clone.ID.String(), rsp.ID)
+				require.Equal(t, 4, rsp.PostsCopied)
+				require.Equal(t, []string{"birthday"}, rsp.Tags)
+			},
+		},
+		{
+			name:        "OK_SettingsOnly",
+			currentUser: user,
+			body:        gin.H{"title": "Next year"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListTagsByWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return([]db.Tag{}, nil)
+				mockHub.EXPECT().
+					ListWallSections(gomock.Any(), wall.ID).
+					Times(1).
+					Return([]db.ListWallSectionsRow{}, nil)
+				mockHub.EXPECT().
+					ListClonablePostsByWall(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateWallTxParams) (db.CreateWallTxResult, error) {
+						require.Equal(t, "Next year", arg.Wall.Title)
+						require.Empty(t, arg.Posts)
+						return db.CreateWallTxResult{Wall: clone}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			body:        gin.H{},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_Deleted",
+			currentUser: user,
+			body:        gin.H{},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(deletedWall, nil)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_Hidden",
+			currentUser: user,
+			body:        gin.H{"include_posts": true},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(hiddenWall, nil)
+				mockHub.EXPECT().
+					ListClonablePostsByWall(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: user,
+			body:        gin.H{},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.Wall{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.config.Env = "unit-test"
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/walls/:id/clone", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.cloneWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/clone", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,685 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+type createCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+	// Optional top-level comment on the same post to reply to
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+type updateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type commentResponse struct {
+	ID             string            `json:"id"`
+	PostID         string            `json:"post_id"`
+	ParentID       string            `json:"parent_id,omitempty"`
+	Author         string            `json:"author,omitempty"`
+	Username       string            `json:"username,omitempty"`
+	Fullname       string            `json:"fullname,omitempty"`
+	ProfilePicture string            `json:"profile_picture,omitempty"`
+	Body           string            `json:"body"`
+	IsDeleted      bool              `json:"is_deleted"`
+	EditedAt       *time.Time        `json:"edited_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	ReplyCount     int64             `json:"reply_count"`
+	Mentions       []mentionResponse `json:"mentions,omitempty"`
+}
+
+type commentsResponse struct {
+	Page     int32             `json:"page"`
+	PageSize int32             `json:"page_size"`
+	HasMore  bool              `json:"has_more"`
+	Comments []commentResponse `json:"comments"`
+}
+
+// newCommentResponse builds a comment response. A deleted comment is only
+// returned as a placeholder for its replies, so its body and author are left out.
+func newCommentResponse(comment db.Comment, username string, fullname, profilePicture pgtype.Text) commentResponse {
+	rsp := commentResponse{
+		ID:        comment.ID.String(),
+		PostID:    comment.PostID.String(),
+		ParentID:  optionalUUID(comment.ParentID),
+		IsDeleted: comment.IsDeleted,
+		CreatedAt: comment.CreatedAt.Time,
+	}
+	if comment.IsDeleted {
+		return rsp
+	}
+
+	rsp.Author = comment.Author.String()
+	rsp.Username = username
+	rsp.Fullname = fullname.String
+	rsp.ProfilePicture = profilePicture.String
+	rsp.Body = comment.Body
+	if comment.EditedAt.Valid {
+		rsp.EditedAt = &comment.EditedAt.Time
+	}
+	return rsp
+}
+
+// canViewWall reports whether a user can see a wall and its posts. Owners always
+// can; anyone else needs the wall to be public or to be friends with the owner,
+// and nobody sees a wall hidden by moderators or the wall of someone they
+// blocked or were blocked by.
+func (s *Server) canViewWall(ctx context.Context, wall db.Wall, user db.User) (bool, error) {
+	if wall.IsDeleted.Bool {
+		return false, nil
+	}
+	if wall.UserID == user.ID {
+		return true, nil
+	}
+	if wall.IsHidden {
+		return false, nil
+	}
+
+	friendship, err := s.hub.ListFriendshipByUserPairs(ctx, db.ListFriendshipByUserPairsParams{
+		FromUser: user.ID,
+		ToUser:   wall.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return wall.IsPublic.Bool, nil
+		}
+		return false, err
+	}
+
+	switch friendship.Status.Status {
+	case db.StatusBlocked:
+		return false, nil
+	case db.StatusFriends:
+		return true, nil
+	}
+	return wall.IsPublic.Bool, nil
+}
+
+// getVisiblePost loads a live post and checks the current user can see its wall.
+// It writes the error response itself and returns false if not.
+func (s *Server) getVisiblePost(ctx *gin.Context, postID pgtype.UUID, user db.User) (db.Post, bool) {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	post, err := s.hub.GetPost(ctx, postID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return post, false
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return post, false
+	}
+
+	if post.IsDeleted.Bool || post.Status != db.PostStatusApproved || post.IsHidden {
+		log.Error("Post not available", errors.New("post not found"))
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("post not found")))
+		return post, false
+	}
+
+	wall, err := s.hub.GetWall(ctx, post.WallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return post, false
+	}
+
+	canView, err := s.canViewWall(ctx, wall, user)
+	if err != nil {
+		log.Error("Failed to check wall visibility", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return post, false
+	}
+	if !canView {
+		log.Error("Unauthorized to view wall", errors.New("user not authorized to view this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to view this wall")))
+		return post, false
+	}
+
+	return post, true
+}
+
+// notifyComment tells the post author about a new comment, and the parent
+// comment's author about a reply. Nobody is notified about their own comment
+// or twice about the same one.
+func (s *Server) notifyComment(ctx context.Context, post db.Post, parent *db.Comment, author db.User) {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	notified := map[pgtype.UUID]bool{author.ID: true}
+	if parent != nil && !notified[parent.Author] {
+		notified[parent.Author] = true
+		err := s.SendNotification(
+			ctx,
+			parent.Author.String(),
+			author.ID.String(),
+			"comment_reply",
+			post.ID.String(),
+			fmt.Sprintf("%s replied to your comment", author.Username),
+		)
+		if err != nil {
+			log.Error("Failed to send comment reply notification", err)
+		}
+	}
+
+	if !notified[post.Author] {
+		err := s.SendNotification(
+			ctx,
+			post.Author.String(),
+			author.ID.String(),
+			"post_comment",
+			post.ID.String(),
+			fmt.Sprintf("%s commented on your post", author.Username),
+		)
+		if err != nil {
+			log.Error("Failed to send post comment notification", err)
+		}
+	}
+}
+
+// ListPostComments handler returns a page of top-level comments on a post, oldest first
+func (s *Server) listPostComments(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list post comments request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req paginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := s.getVisiblePost(ctx, id, currentUser); !ok {
+		return
+	}
+
+	// Fetch one extra comment to know whether there is another page
+	comments, err := s.hub.ListCommentsByPost(ctx, db.ListCommentsByPostParams{
+		PostID:   id,
+		ViewerID: currentUser.ID,
+		Limit:    req.PageSize + 1,
+		Offset:   req.offset(),
+	})
+	if err != nil {
+		log.Error("Failed to list comments", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := commentsResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		HasMore:  len(comments) > int(req.PageSize),
+		Comments: make([]commentResponse, 0, len(comments)),
+	}
+	if rsp.HasMore {
+		comments = comments[:req.PageSize]
+	}
+	page := make([]db.Comment, 0, len(comments))
+	for _, row := range comments {
+		page = append(page, db.Comment{
+			ID:        row.ID,
+			PostID:    row.PostID,
+			Author:    row.Author,
+			ParentID:  row.ParentID,
+			Body:      row.Body,
+			IsDeleted: row.IsDeleted,
+			DeletedAt: row.DeletedAt,
+			EditedAt:  row.EditedAt,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	mentions := s.commentMentions(ctx, page)
+
+	for i, row := range comments {
+		comment := newCommentResponse(page[i], row.Username, row.Fullname, row.ProfilePicture)
+		comment.ReplyCount = row.ReplyCount
+		comment.Mentions = mentions[row.ID]
+		rsp.Comments = append(rsp.Comments, comment)
+	}
+
+	log.Info("Comments listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// ListCommentReplies handler returns a page of replies to a comment, oldest first
+func (s *Server) listCommentReplies(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list comment replies request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req paginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	parent, err := s.hub.GetComment(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Comment not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get comment", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if _, ok := s.getVisiblePost(ctx, parent.PostID, currentUser); !ok {
+		return
+	}
+
+	// Fetch one extra reply to know whether there is another page
+	replies, err := s.hub.ListCommentReplies(ctx, db.ListCommentRepliesParams{
+		ParentID: id,
+		ViewerID: currentUser.ID,
+		Limit:    req.PageSize + 1,
+		Offset:   req.offset(),
+	})
+	if err != nil {
+		log.Error("Failed to list comment replies", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := commentsResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		HasMore:  len(replies) > int(req.PageSize),
+		Comments: make([]commentResponse, 0, len(replies)),
+	}
+	if rsp.HasMore {
+		replies = replies[:req.PageSize]
+	}
+	page := make([]db.Comment, 0, len(replies))
+	for _, row := range replies {
+		page = append(page, db.Comment{
+			ID:        row.ID,
+			PostID:    row.PostID,
+			Author:    row.Author,
+			ParentID:  row.ParentID,
+			Body:      row.Body,
+			IsDeleted: row.IsDeleted,
+			DeletedAt: row.DeletedAt,
+			EditedAt:  row.EditedAt,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	mentions := s.commentMentions(ctx, page)
+
+	for i, row := range replies {
+		reply := newCommentResponse(page[i], row.Username, row.Fullname, row.ProfilePicture)
+		reply.Mentions = mentions[row.ID]
+		rsp.Comments = append(rsp.Comments, reply)
+	}
+
+	log.Info("Comment replies listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// CreateComment handler adds a comment to a post, or a reply to one of its top-level comments
+func (s *Server) createComment(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received create comment request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req createCommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	body, err := util.SanitizeComment(req.Body)
+	if err != nil {
+		log.Error("Invalid comment", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, ok := s.getVisiblePost(ctx, id, currentUser)
+	if !ok {
+		return
+	}
+
+	// Authors who blocked the current user can't be commented on or replied to
+	authors := []pgtype.UUID{post.Author}
+
+	var parent *db.Comment
+	if req.ParentID != "" {
+		var parentID pgtype.UUID
+		if err := parentID.Scan(req.ParentID); err != nil {
+			log.Error("Invalid parent_id", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		comment, err := s.hub.GetComment(ctx, parentID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Failed to get parent comment", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if err != nil || comment.PostID != post.ID || comment.IsDeleted {
+			log.Error("Parent comment not found", errors.New("parent comment not found on this post"))
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("parent comment not found on this post")))
+			return
+		}
+		if comment.ParentID.Valid {
+			log.Error("Cannot reply to a reply", errors.New("replies can only be made to top-level comments"))
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("replies can only be made to top-level comments")))
+			return
+		}
+
+		parent = &comment
+		if comment.Author != post.Author {
+			authors = append(authors, comment.Author)
+		}
+	}
+
+	for _, author := range authors {
+		if author == currentUser.ID {
+			continue
+		}
+		blocked, err := s.hub.IsUserBlockedTx(ctx, author, currentUser.ID)
+		if err != nil {
+			log.Error("Failed to check block status", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if blocked {
+			log.Error("Unauthorized to comment", errors.New("user not authorized to comment on this post"))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to comment on this post")))
+			return
+		}
+	}
+
+	arg := db.CreateCommentParams{
+		PostID: post.ID,
+		Author: currentUser.ID,
+		Body:   body,
+	}
+	if parent != nil {
+		arg.ParentID = parent.ID
+	}
+
+	comment, err := s.hub.CreateCommentTx(ctx, arg)
+	if err != nil {
+		log.Error("Failed to create comment", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	s.notifyComment(ctx.Request.Context(), post, parent, currentUser)
+
+	rsp := newCommentResponse(comment, currentUser.Username, currentUser.Fullname, currentUser.ProfilePicture)
+	mentions, added, err := s.saveMentions(ctx, currentUser.ID, mentionTarget{CommentID: comment.ID}, "", comment.Body)
+	if err != nil {
+		log.Error("Failed to save comment mentions", err)
+	} else {
+		rsp.Mentions = mentions
+		s.notifyMentions(ctx.Request.Context(), post.ID, currentUser, added, true, false)
+	}
+
+	log.Info("Comment created successfully")
+	ctx.JSON(http.StatusCreated, rsp)
+}
+
+// UpdateComment handler lets an author edit their comment
+func (s *Server) updateComment(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received update comment request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateCommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	body, err := util.SanitizeComment(req.Body)
+	if err != nil {
+		log.Error("Invalid comment", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	comment, err := s.hub.GetComment(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Comment not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get comment", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if comment.Author != currentUser.ID {
+		log.Error("Unauthorized to edit comment", errors.New("user not authorized to edit this comment"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to edit this comment")))
+		return
+	}
+
+	if comment.IsDeleted {
+		log.Error("Comment is deleted", errors.New("comment not found"))
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("comment not found")))
+		return
+	}
+
+	oldBody := comment.Body
+	comment, err = s.hub.UpdateCommentBody(ctx, db.UpdateCommentBodyParams{
+		ID:   id,
+		Body: body,
+	})
+	if err != nil {
+		log.Error("Failed to update comment", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newCommentResponse(comment, currentUser.Username, currentUser.Fullname, currentUser.ProfilePicture)
+	mentions, added, err := s.saveMentions(ctx, currentUser.ID, mentionTarget{CommentID: comment.ID}, oldBody, comment.Body)
+	if err != nil {
+		log.Error("Failed to save comment mentions", err)
+	} else {
+		rsp.Mentions = mentions
+		// Only people newly mentioned by the edit hear about it
+		s.notifyMentions(ctx.Request.Context(), comment.PostID, currentUser, added, true, false)
+	}
+
+	log.Info("Comment updated successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// DeleteComment handler lets the author, or the owner of the wall it is on, delete a comment
+func (s *Server) deleteComment(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received delete comment request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	comment, err := s.hub.GetComment(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Comment not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get comment", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if comment.Author != currentUser.ID {
+		post, err := s.hub.GetPost(ctx, comment.PostID)
+		if err != nil {
+			log.Error("Failed to get post", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		wall, err := s.hub.GetWall(ctx, post.WallID)
+		if err != nil {
+			log.Error("Failed to get wall", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if wall.UserID != currentUser.ID {
+			log.Error("Unauthorized to delete comment", errors.New("user not authorized to delete this comment"))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to delete this comment")))
+			return
+		}
+	}
+
+	if err := s.hub.DeleteCommentTx(ctx, comment.ID, comment.PostID); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Comment already deleted", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("comment not found")))
+			return
+		}
+		log.Error("Failed to delete comment", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Comment deleted successfully")
+	ctx.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
@@ -0,0 +1,518 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func randomComment(t *testing.T, postID, authorID pgtype.UUID) db.Comment {
+	return db.Comment{
+		ID:        randomWall(t, authorID).ID,
+		PostID:    postID,
+		Author:    authorID,
+		Body:      util.RandomString(20),
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	}
+}
+
+func TestCreateCommentAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	user, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	wall.IsPublic = pgtype.Bool{Bool: true, Valid: true}
+	post := randomPost(t, wall.ID, owner.ID)
+
+	privateWall := wall
+	privateWall.IsPublic = pgtype.Bool{Bool: false, Valid: true}
+
+	parent := randomComment(t, post.ID, owner.ID)
+	reply := randomComment(t, post.ID, user.ID)
+	reply.ParentID = parent.ID
+
+	noFriendship := func(mockHub *mockdb.MockHub) {
+		mockHub.EXPECT().
+			ListFriendshipByUserPairs(gomock.Any(), db.ListFriendshipByUserPairsParams{FromUser: user.ID, ToUser: owner.ID}).
+			Times(1).
+			Return(db.Friendship{}, db.ErrRecordNotFound)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"body": "  <b>Love</b> this <3 "},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				noFriendship(mockHub)
+				mockHub.EXPECT().
+					IsUserBlockedTx(gomock.Any(), owner.ID, user.ID).
+					Times(1).
+					Return(false, nil)
+				mockHub.EXPECT().
+					CreateCommentTx(gomock.Any(), db.CreateCommentParams{
+						PostID: post.ID,
+						Author: user.ID,
+						Body:   "Love this <3",
+					}).
+					Times(1).
+					Return(db.Comment{ID: reply.ID, PostID: post.ID, Author: user.ID, Body: "Love this <3"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp commentResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, "Love this <3", rsp.Body)
+				require.Equal(t, user.Username, rsp.Username)
+				require.Empty(t, rsp.ParentID)
+			},
+		},
+		{
+			name: "OK_Reply",
+			body: gin.H{"body": reply.Body, "parent_id": parent.ID.String()},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				noFriendship(mockHub)
+				mockHub.EXPECT().GetComment(gomock.Any(), parent.ID).Times(1).Return(parent, nil)
+				// The post and parent comment share an author, who is only checked once
+				mockHub.EXPECT().
+					IsUserBlockedTx(gomock.Any(), owner.ID, user.ID).
+					Times(1).
+					Return(false, nil)
+				mockHub.EXPECT().
+					CreateCommentTx(gomock.Any(), db.CreateCommentParams{
+						PostID:   post.ID,
+						Author:   user.ID,
+						ParentID: parent.ID,
+						Body:     reply.Body,
+					}).
+					Times(1).
+					Return(reply, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp commentResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, parent.ID.String(), rsp.ParentID)
+			},
+		},
+		{
+			name: "BadRequest_ReplyToReply",
+			body: gin.H{"body": "Nested", "parent_id": reply.ID.String()},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				noFriendship(mockHub)
+				mockHub.EXPECT().GetComment(gomock.Any(), reply.ID).Times(1).Return(reply, nil)
+				mockHub.EXPECT().
+					CreateCommentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_EmptyBody",
+			body: gin.H{"body": "<p></p>"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized_PrivateWall",
+			body: gin.H{"body": "Hello"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(privateWall, nil)
+				noFriendship(mockHub)
+				mockHub.EXPECT().
+					CreateCommentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized_Blocked",
+			body: gin.H{"body": "Hello"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				mockHub.EXPECT().
+					ListFriendshipByUserPairs(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Friendship{Status: db.NullStatus{Status: db.StatusBlocked, Valid: true}}, nil)
+				mockHub.EXPECT().
+					CreateCommentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound_PendingPost",
+			body: gin.H{"body": "Hello"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				pending := post
+				pending.Status = db.PostStatusPending
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(pending, nil)
+				mockHub.EXPECT().
+					CreateCommentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/posts/:id/comments", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createComment(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/posts/%s/comments", post.ID.String())
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListPostCommentsAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	post := randomPost(t, wall.ID, owner.ID)
+
+	visible := randomComment(t, post.ID, owner.ID)
+	deleted := randomComment(t, post.ID, owner.ID)
+	deleted.IsDeleted = true
+
+	rows := []db.ListCommentsByPostRow{
+		{ID: deleted.ID, PostID: post.ID, Author: owner.ID, Body: deleted.Body, IsDeleted: true, Username: owner.Username, ReplyCount: 2},
+		{ID: visible.ID, PostID: post.ID, Author: owner.ID, Body: visible.Body, Username: owner.Username},
+		{ID: randomWall(t, owner.ID).ID, PostID: post.ID, Author: owner.ID, Body: "extra"},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?page_size=2",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				mockHub.EXPECT().
+					ListCommentsByPost(gomock.Any(), db.ListCommentsByPostParams{
+						PostID:   post.ID,
+						ViewerID: owner.ID,
+						Limit:    3,
+						Offset:   0,
+					}).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp commentsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.True(t, rsp.HasMore)
+				require.Len(t, rsp.Comments, 2)
+
+				// A deleted comment with replies is kept as an empty placeholder
+				require.True(t, rsp.Comments[0].IsDeleted)
+				require.Empty(t, rsp.Comments[0].Body)
+				require.Empty(t, rsp.Comments[0].Username)
+				require.Equal(t, int64(2), rsp.Comments[0].ReplyCount)
+
+				require.Equal(t, visible.Body, rsp.Comments[1].Body)
+				require.Equal(t, owner.Username, rsp.Comments[1].Username)
+			},
+		},
+		{
+			name:  "BadRequest_InvalidPageSize",
+			query: "?page_size=100",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListCommentsByPost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/posts/:id/comments", func(ctx *gin.Context) {
+				ctx.Set("currentUser", owner)
+				server.listPostComments(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/posts/%s/comments%s", post.ID.String(), tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCommentAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	post := randomPost(t, wall.ID, user.ID)
+	comment := randomComment(t, post.ID, user.ID)
+
+	edited := comment
+	edited.Body = "Edited"
+	edited.EditedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Times(1).Return(comment, nil)
+				mockHub.EXPECT().
+					UpdateCommentBody(gomock.Any(), db.UpdateCommentBodyParams{ID: comment.ID, Body: "Edited"}).
+					Times(1).
+					Return(edited, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp commentResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, "Edited", rsp.Body)
+				require.NotNil(t, rsp.EditedAt)
+			},
+		},
+		{
+			name:        "Unauthorized_NotAuthor",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Times(1).Return(comment, nil)
+				mockHub.EXPECT().
+					UpdateCommentBody(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/comments/:id", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.updateComment(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"body": "<i>Edited</i>"})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/comments/%s", comment.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteCommentAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	post := randomPost(t, wall.ID, owner.ID)
+	comment := randomComment(t, post.ID, author.ID)
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_Author",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Times(1).Return(comment, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					DeleteCommentTx(gomock.Any(), comment.ID, post.ID).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "OK_WallOwner",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Times(1).Return(comment, nil)
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				mockHub.EXPECT().
+					DeleteCommentTx(gomock.Any(), comment.ID, post.ID).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Times(1).Return(comment, nil)
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+				mockHub.EXPECT().
+					DeleteCommentTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound_AlreadyDeleted",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Times(1).Return(comment, nil)
+				mockHub.EXPECT().
+					DeleteCommentTx(gomock.Any(), comment.ID, post.ID).
+					Times(1).
+					Return(db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.DELETE("/test/comments/:id", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.deleteComment(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/comments/%s", comment.ID.String())
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/drawing"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+var (
+	errDrawingRequired = errors.New("drawing posts need strokes and no media_url")
+	errDrawingOnly     = errors.New("only drawing posts can have strokes")
+	errDrawingEdit     = errors.New("a drawing's strokes cannot be changed after posting")
+)
+
+type postStrokesResponse struct {
+	PostID  string          `json:"post_id"`
+	Width   int32           `json:"width"`
+	Height  int32           `json:"height"`
+	Strokes json.RawMessage `json:"strokes"`
+}
+
+// drawingParams validates the strokes of a new drawing and stores the PNG
+// preview rendered from them, whose key comes back alongside. The post is
+// left for the caller to fill in.
+// It writes the error response itself and returns false if the drawing can't be used.
+func (s *Server) drawingParams(ctx *gin.Context, raw json.RawMessage) (db.CreateDrawingPostTxParams, string, bool) {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	d, err := drawing.Parse(raw)
+	if err != nil {
+		log.Error("Invalid drawing", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.CreateDrawingPostTxParams{}, "", false
+	}
+
+	strokes, err := json.Marshal(d.Strokes)
+	if err != nil {
+		log.Error("Failed to encode strokes", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CreateDrawingPostTxParams{}, "", false
+	}
+
+	preview, err := drawing.Render(d)
+	if err != nil {
+		log.Error("Failed to render drawing", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CreateDrawingPostTxParams{}, "", false
+	}
+
+	// Previews live with the other uploads so deleting and purging the post
+	// cleans them up the same way
+	key := "uploads/drawings/" + uuid.New().String() + ".png"
+	if err := s.uploadFile(ctx, key, "image/png", preview); err != nil {
+		log.Error("Failed to store drawing preview", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CreateDrawingPostTxParams{}, "", false
+	}
+
+	return db.CreateDrawingPostTxParams{
+		Width:   d.Width,
+		Height:  d.Height,
+		Strokes: strokes,
+	}, key, true
+}
+
+// GetPostStrokes handler returns the strokes a drawing was made with, for
+// replaying or editing it
+func (s *Server) getPostStrokes(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received get post strokes request")
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Strokes are as visible as the post itself
+	if post.IsDeleted.Bool || post.Status != db.PostStatusApproved || post.IsHidden || post.PostType.PostType != db.PostTypeDrawing {
+		ctx.JSON(http.StatusNotFound, errorResponse(db.ErrRecordNotFound))
+		return
+	}
+
+	d, err := s.hub.GetPostDrawing(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Drawing not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get drawing", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, postStrokesResponse{
+		PostID:  d.PostID.String(),
+		Width:   d.Width,
+		Height:  d.Height,
+		Strokes: d.Strokes,
+	})
+}
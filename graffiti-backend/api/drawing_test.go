@@ -0,0 +1,305 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func randomDrawing() gin.H {
+	return gin.H{
+		"width":  400,
+		"height": 300,
+		"strokes": []gin.H{
+			{"points": [][2]float64{{10, 10}, {200, 150}, {390, 10}}, "color": "#FF3B30", "size": 5},
+			{"points": [][2]float64{{200, 150}}, "size": 20, "eraser": true},
+		},
+	}
+}
+
+func TestCreateDrawingPostAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"wall_id": wall.ID, "post_type": "drawing", "drawing": randomDrawing()},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreateDrawingPostTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateDrawingPostTxParams) (db.Post, error) {
+						require.Equal(t, db.PostTypeDrawing, arg.Post.PostType.PostType)
+						require.True(t, strings.HasPrefix(arg.Post.MediaUrl.String, "https://"+testCloudfrontDomain+"/uploads/drawings/"))
+						require.True(t, strings.HasSuffix(arg.Post.MediaUrl.String, ".png"))
+						require.Equal(t, int32(400), arg.Width)
+						require.Equal(t, int32(300), arg.Height)
+
+						// Strokes are stored normalized
+						var strokes []map[string]interface{}
+						require.NoError(t, json.Unmarshal(arg.Strokes, &strokes))
+						require.Len(t, strokes, 2)
+						require.Equal(t, "#ff3b30", strokes[0]["color"])
+
+						post := randomPost(t, arg.Post.WallID, arg.Post.Author)
+						post.PostType = arg.Post.PostType
+						post.MediaUrl = arg.Post.MediaUrl
+						return post, nil
+					})
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var rsp postResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, "drawing", rsp.PostType)
+				require.Contains(t, rsp.MediaURL, "/uploads/drawings/")
+			},
+		},
+		{
+			name: "BadRequest_MissingStrokes",
+			body: gin.H{"wall_id": wall.ID, "post_type": "drawing"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreateDrawingPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_MediaURL",
+			body: gin.H{"wall_id": wall.ID, "post_type": "drawing", "media_url": randomUploadURL(), "drawing": randomDrawing()},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreateDrawingPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_InvalidStrokes",
+			body: gin.H{"wall_id": wall.ID, "post_type": "drawing", "drawing": gin.H{
+				"width":   400,
+				"height":  300,
+				"strokes": []gin.H{{"points": [][2]float64{{500, 10}}, "color": "#000", "size": 5}},
+			}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreateDrawingPostTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_StrokesOnMediaPost",
+			body: gin.H{"wall_id": wall.ID, "post_type": "media", "media_url": randomUploadURL(), "drawing": randomDrawing()},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+			expectPostUpload(server, mockHub, user.ID)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createPost(ctx)
+			})
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetPostStrokesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	post := randomPost(t, randomWall(t, user.ID).ID, user.ID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeDrawing, Valid: true}
+	post.Status = db.PostStatusApproved
+
+	strokes := []byte(`[{"points":[[10,10],[20,20]],"color":"#000000","size":3}]`)
+
+	testCases := []struct {
+		name          string
+		post          func() db.Post
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			post: func() db.Post { return post },
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPostDrawing(gomock.Any(), post.ID).
+					Times(1).
+					Return(db.PostDrawing{PostID: post.ID, Width: 100, Height: 50, Strokes: strokes}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp postStrokesResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, post.ID.String(), rsp.PostID)
+				require.Equal(t, int32(100), rsp.Width)
+				require.JSONEq(t, string(strokes), string(rsp.Strokes))
+			},
+		},
+		{
+			name: "NotFound_NotADrawing",
+			post: func() db.Post {
+				media := post
+				media.PostType = db.NullPostType{PostType: db.PostTypeMedia, Valid: true}
+				return media
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPostDrawing(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound_Hidden",
+			post: func() db.Post {
+				hidden := post
+				hidden.IsHidden = true
+				return hidden
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPostDrawing(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound_Deleted",
+			post: func() db.Post {
+				deleted := post
+				deleted.IsDeleted = pgtype.Bool{Bool: true, Valid: true}
+				return deleted
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPostDrawing(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(tc.post(), nil)
+			tc.setupMock(mockHub)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/posts/%s/strokes", post.ID.String()), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateDrawingPostMediaAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	post := randomPost(t, randomWall(t, user.ID).ID, user.ID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeDrawing, Valid: true}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(post, nil)
+	mockHub.EXPECT().
+		UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server.router.PUT("/test/posts/:id", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.updatePost(ctx)
+	})
+
+	data, err := json.Marshal(gin.H{"post_type": "media", "media_url": randomUploadURL()})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/test/posts/%s", post.ID.String()), bytes.NewReader(data))
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
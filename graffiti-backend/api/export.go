@@ -0,0 +1,408 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/export"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// exportTimeout is how long an export can run before the user may start another
+const exportTimeout = time.Hour
+
+var errExportInProgress = errors.New("an export is already in progress, wait for it to finish")
+
+type wallExportResponse struct {
+	ID          string     `json:"id"`
+	WallID      string     `json:"wall_id"`
+	Status      string     `json:"status"`
+	ArchiveURL  string     `json:"archive_url,omitempty"`
+	ImageURL    string     `json:"image_url,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func newWallExportResponse(wallExport db.WallExport) wallExportResponse {
+	resp := wallExportResponse{
+		ID:         wallExport.ID.String(),
+		WallID:     wallExport.WallID.String(),
+		Status:     wallExport.Status,
+		ArchiveURL: wallExport.ArchiveUrl.String,
+		ImageURL:   wallExport.ImageUrl.String,
+		Error:      wallExport.Error.String,
+		CreatedAt:  wallExport.CreatedAt.Time,
+	}
+	if wallExport.CompletedAt.Valid {
+		resp.CompletedAt = &wallExport.CompletedAt.Time
+	}
+	return resp
+}
+
+// exportWall starts an asynchronous export of a wall's posts
+func (s *Server) exportWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received export wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to export wall", errors.New("user not authorized to export this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to export this wall")))
+		return
+	}
+
+	// Exports are heavy, so each user gets one at a time. A unique index on the
+	// exports being built enforces it, once any that died with the server are
+	// out of the way.
+	err = s.hub.ExpireWallExports(ctx, db.ExpireWallExportsParams{
+		UserID:    currentUser.ID,
+		CreatedAt: pgtype.Timestamp{Time: time.Now().Add(-exportTimeout), Valid: true},
+	})
+	if err != nil {
+		log.Error("Failed to expire stale exports", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	wallExport, err := s.hub.CreateWallExport(ctx, db.CreateWallExportParams{
+		WallID: wall.ID,
+		UserID: currentUser.ID,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			log.Error("Export already in progress", errExportInProgress)
+			ctx.JSON(http.StatusConflict, errorResponse(errExportInProgress))
+			return
+		}
+		log.Error("Failed to create wall export", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Use a background context so the export won't get canceled when request is done
+	go s.processWallExport(context.Background(), wallExport, wall)
+
+	log.Info("Wall export queued successfully")
+	ctx.JSON(http.StatusAccepted, newWallExportResponse(wallExport))
+}
+
+// getWallExport returns the status of an export requested by the current user
+func (s *Server) getWallExport(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received get wall export request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wallExport, err := s.hub.GetWallExport(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall export", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wallExport.UserID != currentUser.ID {
+		log.Error("Unauthorized to view wall export", errors.New("user not authorized to view this export"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to view this export")))
+		return
+	}
+
+	log.Info("Wall export retrieved successfully")
+	ctx.JSON(http.StatusOK, newWallExportResponse(wallExport))
+}
+
+// processWallExport builds the zip archive and collage for a wall, stores both
+// and notifies the wall owner. Failures are recorded on the export row.
+// Media is written to the archive, kept in a temporary file, as it's downloaded
+// and only a thumbnail of each image is kept for the collage.
+func (s *Server) processWallExport(ctx context.Context, wallExport db.WallExport, wall db.Wall) {
+	log := logger.Global()
+	log.Info("Starting wall export %s for wall %s", wallExport.ID.String(), wall.ID.String())
+
+	if err := s.hub.MarkWallExportProcessing(ctx, wallExport.ID); err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	// Without an author, only the approved posts everyone can see are listed,
+	// none of the owner's own pending, scheduled or hidden ones
+	posts, err := s.hub.ListPostsByWallWithAuthorsDetails(ctx, db.ListPostsByWallWithAuthorsDetailsParams{
+		WallID: wall.ID,
+	})
+	if err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	manifest := export.Manifest{
+		Wall: export.WallInfo{
+			ID:          wall.ID.String(),
+			Title:       wall.Title,
+			Description: wall.Description.String,
+		},
+		ExportedAt: time.Now(),
+		Posts:      make([]export.PostEntry, 0, len(posts)),
+	}
+
+	tmp, err := os.CreateTemp("", "wall-export-*.zip")
+	if err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	albums, err := s.exportAlbumMedia(ctx, posts)
+	if err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	archive := export.NewArchive(tmp)
+	var collage export.Collage
+
+	// addMedia downloads a media object into the archive, returning its name
+	// there or "" when it couldn't be downloaded
+	addMedia := func(name, mediaURL string) (string, error) {
+		key := util.ExtractKeyFromMediaURL(mediaURL)
+		data, _, err := s.downloadFile(ctx, key)
+		if err != nil {
+			// A single missing object shouldn't sink the whole export
+			log.Error("Failed to download media for export", err)
+			return "", nil
+		}
+
+		file := "images/" + name + path.Ext(key)
+		if err := archive.AddFile(export.File{Name: file, Data: data}); err != nil {
+			return "", err
+		}
+		if err := collage.Add(data); err != nil {
+			log.Error("Failed to add media to export collage", err)
+		}
+		return file, nil
+	}
+
+	for _, post := range posts {
+		post = hideAnonymousAuthor(post, wall.UserID)
+		entry := export.PostEntry{
+			ID:             post.ID.String(),
+			AuthorID:       optionalUUID(post.Author),
+			AuthorUsername: post.Username,
+			AuthorFullname: post.Fullname.String,
+			PostType:       string(post.PostType.PostType),
+			MediaURL:       post.MediaUrl.String,
+			Caption:        post.Caption.String,
+			LikesCount:     post.LikesCount.Int32,
+			CreatedAt:      post.CreatedAt.Time,
+		}
+
+		switch post.PostType.PostType {
+		case db.PostTypeMedia, db.PostTypeDrawing:
+			// Drawings are exported as their rendered preview
+			if post.MediaUrl.Valid {
+				if entry.File, err = addMedia(post.ID.String(), post.MediaUrl.String); err != nil {
+					s.failWallExport(ctx, wallExport, wall, err)
+					return
+				}
+			}
+		case db.PostTypeAlbum:
+			// Every item goes in, the first one also stands for the album as a whole
+			for _, medium := range albums[post.ID] {
+				item := export.MediaEntry{
+					MediaURL: medium.MediaUrl,
+					AltText:  medium.AltText,
+				}
+				name := fmt.Sprintf("%s-%d", post.ID.String(), medium.Position)
+				if item.File, err = addMedia(name, medium.MediaUrl); err != nil {
+					s.failWallExport(ctx, wallExport, wall, err)
+					return
+				}
+				if len(entry.Media) == 0 {
+					entry.File = item.File
+				}
+				entry.Media = append(entry.Media, item)
+			}
+		}
+
+		manifest.Posts = append(manifest.Posts, entry)
+	}
+
+	if err := archive.Close(manifest); err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	archiveKey := fmt.Sprintf("exports/%s.zip", wallExport.ID.String())
+	if err := s.uploadReader(ctx, archiveKey, "application/zip", tmp, size); err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	arg := db.CompleteWallExportParams{
+		ID:         wallExport.ID,
+		ArchiveUrl: pgtype.Text{String: s.publicURL(archiveKey), Valid: true},
+	}
+
+	rendered, err := collage.Render()
+	switch {
+	case errors.Is(err, export.ErrNoImages):
+		log.Info("No images to render for wall export %s", wallExport.ID.String())
+	case err != nil:
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	default:
+		imageKey := fmt.Sprintf("exports/%s.png", wallExport.ID.String())
+		if err := s.uploadFile(ctx, imageKey, "image/png", rendered); err != nil {
+			s.failWallExport(ctx, wallExport, wall, err)
+			return
+		}
+		arg.ImageUrl = pgtype.Text{String: s.publicURL(imageKey), Valid: true}
+	}
+
+	if _, err := s.hub.CompleteWallExport(ctx, arg); err != nil {
+		s.failWallExport(ctx, wallExport, wall, err)
+		return
+	}
+
+	err = s.SendNotification(
+		ctx,
+		wall.UserID.String(),   // recipient (wall owner)
+		wall.UserID.String(),   // sender (system, on behalf of the owner)
+		"wall_export",          // notification type
+		wallExport.ID.String(), // entity ID (export ID)
+		fmt.Sprintf("Your export of \"%s\" is ready: %s", wall.Title, arg.ArchiveUrl.String), // message
+	)
+	if err != nil {
+		log.Error("Failed to send wall export notification", err)
+	}
+
+	log.Info("Wall export %s completed successfully", wallExport.ID.String())
+}
+
+// exportAlbumMedia loads the items of the album posts being exported, keyed by
+// post and in order
+func (s *Server) exportAlbumMedia(ctx context.Context, posts []db.ListPostsByWallWithAuthorsDetailsRow) (map[pgtype.UUID][]db.PostMedium, error) {
+	var ids []pgtype.UUID
+	for _, post := range posts {
+		if post.PostType.PostType == db.PostTypeAlbum {
+			ids = append(ids, post.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	media, err := s.hub.ListPostMediaByPosts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byPost := make(map[pgtype.UUID][]db.PostMedium, len(ids))
+	for _, medium := range media {
+		byPost[medium.PostID] = append(byPost[medium.PostID], medium)
+	}
+	return byPost, nil
+}
+
+// failWallExport records an export failure and lets the owner know
+func (s *Server) failWallExport(ctx context.Context, wallExport db.WallExport, wall db.Wall, cause error) {
+	log := logger.Global()
+	log.Error("Wall export failed", cause)
+
+	err := s.hub.FailWallExport(ctx, db.FailWallExportParams{
+		ID:    wallExport.ID,
+		Error: pgtype.Text{String: cause.Error(), Valid: true},
+	})
+	if err != nil {
+		log.Error("Failed to mark wall export as failed", err)
+	}
+
+	err = s.SendNotification(
+		ctx,
+		wall.UserID.String(),
+		wall.UserID.String(),
+		"wall_export_failed",
+		wallExport.ID.String(),
+		fmt.Sprintf("Your export of \"%s\" could not be completed", wall.Title),
+	)
+	if err != nil {
+		log.Error("Failed to send wall export failure notification", err)
+	}
+}
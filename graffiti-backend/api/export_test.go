@@ -0,0 +1,305 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestExportWallAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	testCases := []struct {
+		name          string
+		wallID        string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "Unauthorized_NotOwner",
+			wallID:      wall.ID.String(),
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					CreateWallExport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_InvalidID",
+			wallID:      "invalid-uuid",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Conflict_ExportInProgress",
+			wallID:      wall.ID.String(),
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ExpireWallExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ExpireWallExportsParams) error {
+						require.Equal(t, user.ID, arg.UserID)
+						require.WithinDuration(t, time.Now().Add(-exportTimeout), arg.CreatedAt.Time, time.Minute)
+						return nil
+					})
+				mockHub.EXPECT().
+					CreateWallExport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WallExport{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			wallID:      wall.ID.String(),
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ExpireWallExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				mockHub.EXPECT().
+					CreateWallExport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WallExport{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/walls/:id/export", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.exportWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/export", tc.wallID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetWallExportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	wallExport := randomWallExport(t, wall.ID, user.ID)
+
+	testCases := []struct {
+		name          string
+		exportID      string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			exportID:    wallExport.ID.String(),
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallExport(gomock.Any(), wallExport.ID).
+					Times(1).
+					Return(wallExport, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got wallExportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, wallExport.ID.String(), got.ID)
+				require.Equal(t, wallExport.WallID.String(), got.WallID)
+				require.Equal(t, wallExport.Status, got.Status)
+			},
+		},
+		{
+			name:        "Unauthorized_NotRequester",
+			exportID:    wallExport.ID.String(),
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallExport(gomock.Any(), wallExport.ID).
+					Times(1).
+					Return(wallExport, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			exportID:    uuid.New().String(),
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallExport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WallExport{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/exports/:id", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.getWallExport(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/exports/%s", tc.exportID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestProcessWallExport(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	wallExport := randomWallExport(t, wall.ID, user.ID)
+
+	server := newTestServer(t)
+	server.config.Env = "unit-test"
+	server.config.CloudfrontDomain = "cdn.example.com"
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	post := randomPost(t, wall.ID, user.ID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeEmbedLink, Valid: true}
+	album := randomPost(t, wall.ID, user.ID)
+	album.PostType = db.NullPostType{PostType: db.PostTypeAlbum, Valid: true}
+
+	mockHub.EXPECT().
+		MarkWallExportProcessing(gomock.Any(), wallExport.ID).
+		Times(1).
+		Return(nil)
+	mockHub.EXPECT().
+		ListPostsByWallWithAuthorsDetails(gomock.Any(), db.ListPostsByWallWithAuthorsDetailsParams{
+			WallID: wall.ID,
+		}).
+		Times(1).
+		Return([]db.ListPostsByWallWithAuthorsDetailsRow{{
+			ID:       post.ID,
+			WallID:   post.WallID,
+			Author:   post.Author,
+			MediaUrl: post.MediaUrl,
+			PostType: post.PostType,
+			Username: user.Username,
+		}, {
+			ID:       album.ID,
+			WallID:   album.WallID,
+			Author:   album.Author,
+			MediaUrl: album.MediaUrl,
+			PostType: album.PostType,
+			Username: user.Username,
+		}}, nil)
+	// Every item of an album is exported, not just its cover
+	mockHub.EXPECT().
+		ListPostMediaByPosts(gomock.Any(), []pgtype.UUID{album.ID}).
+		Times(1).
+		Return([]db.PostMedium{
+			{PostID: album.ID, Position: 0, MediaUrl: album.MediaUrl.String},
+			{PostID: album.ID, Position: 1, MediaUrl: randomUploadURL()},
+		}, nil)
+	mockHub.EXPECT().
+		CompleteWallExport(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.CompleteWallExportParams) (db.WallExport, error) {
+			require.Equal(t, wallExport.ID, arg.ID)
+			require.True(t, strings.HasPrefix(arg.ArchiveUrl.String, "https://cdn.example.com/exports/"))
+			// Nothing could be downloaded, so there is nothing to render
+			require.False(t, arg.ImageUrl.Valid)
+			return wallExport, nil
+		})
+	mockHub.EXPECT().
+		FailWallExport(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server.processWallExport(context.Background(), wallExport, wall)
+}
+
+func randomWallExport(t *testing.T, wallID, userID pgtype.UUID) db.WallExport {
+	id := pgtype.UUID{}
+	require.NoError(t, id.Scan(uuid.New().String()))
+
+	return db.WallExport{
+		ID:     id,
+		WallID: wallID,
+		UserID: userID,
+		Status: "pending",
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// Layout request types
+type postLayoutRequest struct {
+	PosX          float64 `json:"pos_x"`
+	PosY          float64 `json:"pos_y"`
+	Rotation      float64 `json:"rotation" binding:"gte=-360,lte=360"`
+	Scale         float64 `json:"scale" binding:"gt=0,lte=10"`
+	ZIndex        int32   `json:"z_index"`
+	LayoutVersion int32   `json:"layout_version" binding:"required,min=1"`
+}
+
+type wallLayoutItem struct {
+	ID string `json:"id" binding:"required,uuid"`
+	postLayoutRequest
+}
+
+type updateWallLayoutRequest struct {
+	Posts []wallLayoutItem `json:"posts" binding:"required,min=1,dive"`
+}
+
+// UpdatePostLayout handler
+func (s *Server) updatePostLayout(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received update post layout request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req postLayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, post.WallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Both the wall owner and the post author may move a post around
+	if wall.UserID != currentUser.ID && post.Author != currentUser.ID {
+		log.Error("Unauthorized to update post layout", errors.New("user not authorized to move this post"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to move this post")))
+		return
+	}
+
+	updated, err := s.hub.UpdatePostLayout(ctx, db.UpdatePostLayoutParams{
+		ID:            id,
+		PosX:          req.PosX,
+		PosY:          req.PosY,
+		Rotation:      req.Rotation,
+		Scale:         req.Scale,
+		ZIndex:        req.ZIndex,
+		LayoutVersion: req.LayoutVersion,
+	})
+	if err != nil {
+		// No row matched the version we were given, so someone else got there first
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post layout version conflict", db.ErrLayoutConflict)
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrLayoutConflict))
+			return
+		}
+		log.Error("Failed to update post layout", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Post layout updated successfully")
+	ctx.JSON(http.StatusOK, newPostResponse(updated))
+}
+
+// UpdateWallLayout handler applies a drag-and-drop rearrangement in one go
+func (s *Server) updateWallLayout(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received update wall layout request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateWallLayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var wallID pgtype.UUID
+	if err := wallID.Scan(uri.ID); err != nil {
+		log.Error("Invalid wall_id", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, wallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to update wall layout", errors.New("user not authorized to rearrange this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to rearrange this wall")))
+		return
+	}
+
+	layouts := make([]db.UpdatePostLayoutParams, 0, len(req.Posts))
+	for _, item := range req.Posts {
+		var id pgtype.UUID
+		if err := id.Scan(item.ID); err != nil {
+			log.Error("Invalid post ID", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		layouts = append(layouts, db.UpdatePostLayoutParams{
+			ID:            id,
+			PosX:          item.PosX,
+			PosY:          item.PosY,
+			Rotation:      item.Rotation,
+			Scale:         item.Scale,
+			ZIndex:        item.ZIndex,
+			LayoutVersion: item.LayoutVersion,
+		})
+	}
+
+	posts, err := s.hub.UpdateWallLayoutTx(ctx, wallID, layouts)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrLayoutConflict):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrPostNotInWall):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrRecordNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		log.Error("Failed to update wall layout", err)
+		return
+	}
+
+	log.Info("Wall layout updated successfully")
+	responses := make([]postResponse, 0, len(posts))
+	for _, post := range posts {
+		responses = append(responses, newPostResponse(post))
+	}
+
+	ctx.JSON(http.StatusOK, responses)
+}
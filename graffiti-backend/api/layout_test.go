@@ -0,0 +1,339 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestUpdatePostLayoutAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	author, _ := randomUser(t)
+	post := randomPost(t, wall.ID, author.ID)
+	otherUser, _ := randomUser(t)
+
+	body := gin.H{
+		"pos_x":          200,
+		"pos_y":          150,
+		"rotation":       -10,
+		"scale":          1.25,
+		"z_index":        4,
+		"layout_version": post.LayoutVersion,
+	}
+
+	moved := post
+	moved.PosX = 200
+	moved.PosY = 150
+	moved.Rotation = -10
+	moved.Scale = 1.25
+	moved.ZIndex = 4
+	moved.LayoutVersion = post.LayoutVersion + 1
+
+	testCases := []struct {
+		name          string
+		postID        string
+		currentUser   db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_WallOwner",
+			postID:      post.ID.String(),
+			currentUser: user,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdatePostLayout(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdatePostLayoutParams) (db.Post, error) {
+						require.Equal(t, post.ID, arg.ID)
+						require.Equal(t, float64(200), arg.PosX)
+						require.Equal(t, 1.25, arg.Scale)
+						require.Equal(t, int32(4), arg.ZIndex)
+						require.Equal(t, post.LayoutVersion, arg.LayoutVersion)
+						return moved, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchPostResponse(t, recorder.Body, moved)
+			},
+		},
+		{
+			name:        "OK_PostAuthor",
+			postID:      post.ID.String(),
+			currentUser: author,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdatePostLayout(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(moved, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "Conflict_StaleVersion",
+			postID:      post.ID.String(),
+			currentUser: user,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdatePostLayout(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Post{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwnerOrAuthor",
+			postID:      post.ID.String(),
+			currentUser: otherUser,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdatePostLayout(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_MissingVersion",
+			postID:      post.ID.String(),
+			currentUser: user,
+			body: gin.H{
+				"pos_x": 10,
+				"scale": 1,
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/posts/:id/layout", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.updatePostLayout(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/posts/%s/layout", tc.postID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateWallLayoutAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	post1 := randomPost(t, wall.ID, user.ID)
+	post2 := randomPost(t, wall.ID, user.ID)
+	otherUser, _ := randomUser(t)
+
+	body := gin.H{
+		"posts": []gin.H{
+			{"id": post1.ID.String(), "pos_x": 10, "pos_y": 20, "scale": 1, "z_index": 1, "layout_version": 1},
+			{"id": post2.ID.String(), "pos_x": 30, "pos_y": 40, "scale": 2, "z_index": 0, "layout_version": 1},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: user,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdateWallLayoutTx(gomock.Any(), wall.ID, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, _ interface{}, layouts []db.UpdatePostLayoutParams) ([]db.Post, error) {
+						require.Len(t, layouts, 2)
+						require.Equal(t, post1.ID, layouts[0].ID)
+						require.Equal(t, float64(2), layouts[1].Scale)
+						return []db.Post{post1, post2}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchPostsResponse(t, recorder.Body, []db.Post{post1, post2})
+			},
+		},
+		{
+			name:        "Conflict",
+			currentUser: user,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdateWallLayoutTx(gomock.Any(), wall.ID, gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("%w: post %s", db.ErrLayoutConflict, post2.ID.String()))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_PostNotInWall",
+			currentUser: user,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdateWallLayoutTx(gomock.Any(), wall.ID, gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrPostNotInWall)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			body:        body,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdateWallLayoutTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_Empty",
+			currentUser: user,
+			body:        gin.H{"posts": []gin.H{}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/layout", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.updateWallLayout(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/layout", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
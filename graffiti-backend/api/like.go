@@ -43,6 +43,11 @@ func (s *Server) updateLike(ctx *gin.Context) {
 		return
 	}
 
+	post, ok := s.getVisiblePost(ctx, postID, currentUser)
+	if !ok {
+		return
+	}
+
 	liked, err := s.hub.CreateOrDeleteLikeTx(ctx, postID, currentUser.ID)
 	if err != nil {
 		log.Error("Failed to toggle like", err)
@@ -53,28 +58,7 @@ func (s *Server) updateLike(ctx *gin.Context) {
 	action := "unliked"
 	if liked {
 		action = "liked"
-
-		post, err := s.hub.GetPost(ctx, postID)
-		if err != nil {
-			log.Error("Failed to get post details for notification", err)
-		} else {
-			if post.Author.Bytes != currentUser.ID.Bytes {
-				err = s.SendNotification(
-					ctx,
-					post.Author.String(),           // recipient (post owner)
-					currentUser.ID.String(),        // sender (user who liked)
-					"post_like",                    // notification type
-					post.WallID.String(),           // entity ID (wall ID)
-					fmt.Sprintf("%s liked your post", currentUser.Username), // message
-				)
-
-				if err != nil {
-					log.Error("Failed to send like notification", err)
-				} else {
-					log.Info("Like notification sent to user %s", post.Author.String())
-				}
-			}
-		}
+		s.notifyReaction(ctx, post, currentUser, db.LikeReaction)
 	}
 
 	log.Info("Post %s successfully", action)
@@ -117,10 +101,10 @@ func (s *Server) getLike(ctx *gin.Context) {
 		UserID: currentUser.ID,
 	}
 
-	_, err := s.hub.GetLike(ctx, arg)
+	like, err := s.hub.GetLike(ctx, arg)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			ctx.JSON(http.StatusOK, gin.H{"liked": false})
+			ctx.JSON(http.StatusOK, gin.H{"liked": false, "reaction": nil})
 			return
 		}
 		log.Error("Failed to get like", err)
@@ -129,7 +113,8 @@ func (s *Server) getLike(ctx *gin.Context) {
 	}
 
 	log.Info("Like retrieved successfully")
-	ctx.JSON(http.StatusOK, gin.H{"liked": true})
+	// A like is a thumbs-up, so other reactions don't count as one
+	ctx.JSON(http.StatusOK, gin.H{"liked": like.Reaction == db.LikeReaction, "reaction": like.Reaction})
 }
 
 type deleteLikeRequest struct {
@@ -161,12 +146,9 @@ func (s *Server) deleteLike(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.DeleteLikeParams{
-		PostID: postID,
-		UserID: userID,
-	}
-
-	if err := s.hub.DeleteLike(ctx, arg); err != nil {
+	// Go through the reaction so the post's counts stay in step
+	_, err := s.hub.RemoveReactionTx(ctx, postID, userID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
 		log.Error("Failed to delete like", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -21,8 +21,25 @@ import (
 
 // TestUpdateLikeAPI tests the updateLike handler
 func TestUpdateLikeAPI(t *testing.T) {
+	owner, _ := randomUser(t)
 	user, _ := randomUser(t)
-	post := randomPost(t, pgtype.UUID{}, pgtype.UUID{})
+	wall := randomWall(t, owner.ID)
+	wall.IsPublic = pgtype.Bool{Bool: true, Valid: true}
+	post := randomPost(t, wall.ID, owner.ID)
+
+	pending := post
+	pending.Status = db.PostStatusPending
+	deleted := post
+	deleted.IsDeleted = pgtype.Bool{Bool: true, Valid: true}
+
+	visible := func(mockHub *mockdb.MockHub) {
+		mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+		mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+		mockHub.EXPECT().
+			ListFriendshipByUserPairs(gomock.Any(), db.ListFriendshipByUserPairsParams{FromUser: user.ID, ToUser: owner.ID}).
+			Times(1).
+			Return(db.Friendship{}, db.ErrRecordNotFound)
+	}
 
 	testCases := []struct {
 		name          string
@@ -36,9 +53,7 @@ func TestUpdateLikeAPI(t *testing.T) {
 				"post_id": post.ID.String(),
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
-				mockHub.EXPECT().
-					GetPost(gomock.Any(), post.ID).
-					Return(post, nil)
+				visible(mockHub)
 
 				mockHub.EXPECT().
 					CreateOrDeleteLikeTx(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -59,6 +74,8 @@ func TestUpdateLikeAPI(t *testing.T) {
 				"post_id": post.ID.String(),
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
+				visible(mockHub)
+
 				mockHub.EXPECT().
 					CreateOrDeleteLikeTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
@@ -104,6 +121,8 @@ func TestUpdateLikeAPI(t *testing.T) {
 				"post_id": post.ID.String(),
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
+				visible(mockHub)
+
 				mockHub.EXPECT().
 					CreateOrDeleteLikeTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
@@ -113,6 +132,44 @@ func TestUpdateLikeAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "NotFound_Pending",
+			body: gin.H{
+				"post_id": post.ID.String(),
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(pending, nil)
+
+				mockHub.EXPECT().
+					CreateOrDeleteLikeTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound_Deleted",
+			body: gin.H{
+				"post_id": post.ID.String(),
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(deleted, nil)
+
+				mockHub.EXPECT().
+					CreateOrDeleteLikeTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -144,6 +201,12 @@ func TestUpdateLikeAPI(t *testing.T) {
 	}
 }
 
+// getLikeResponse is the body returned by the getLike handler
+type getLikeResponse struct {
+	Liked    bool   `json:"liked"`
+	Reaction string `json:"reaction"`
+}
+
 // TestGetLikeAPI tests the getLike handler
 func TestGetLikeAPI(t *testing.T) {
 	user, _ := randomUser(t)
@@ -162,14 +225,33 @@ func TestGetLikeAPI(t *testing.T) {
 				mockHub.EXPECT().
 					GetLike(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.Like{}, nil)
+					Return(db.Like{Reaction: db.LikeReaction}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response getLikeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, response.Liked)
+				require.Equal(t, db.LikeReaction, response.Reaction)
+			},
+		},
+		{
+			name:   "OK_OtherReaction",
+			postID: post.ID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetLike(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Like{Reaction: "🎉"}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				var response map[string]bool
+				var response getLikeResponse
 				err := json.Unmarshal(recorder.Body.Bytes(), &response)
 				require.NoError(t, err)
-				require.True(t, response["liked"])
+				require.False(t, response.Liked)
+				require.Equal(t, "🎉", response.Reaction)
 			},
 		},
 		{
@@ -183,10 +265,11 @@ func TestGetLikeAPI(t *testing.T) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				var response map[string]bool
+				var response getLikeResponse
 				err := json.Unmarshal(recorder.Body.Bytes(), &response)
 				require.NoError(t, err)
-				require.False(t, response["liked"])
+				require.False(t, response.Liked)
+				require.Empty(t, response.Reaction)
 			},
 		},
 		{
@@ -260,9 +343,23 @@ func TestDeleteLikeAPI(t *testing.T) {
 			userID: user.ID.String(),
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					DeleteLike(gomock.Any(), gomock.Any()).
+					RemoveReactionTx(gomock.Any(), post.ID, user.ID).
+					Times(1).
+					Return(db.ReactionTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "OK_NotLiked",
+			postID: post.ID.String(),
+			userID: user.ID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					RemoveReactionTx(gomock.Any(), post.ID, user.ID).
 					Times(1).
-					Return(nil)
+					Return(db.ReactionTxResult{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -274,7 +371,7 @@ func TestDeleteLikeAPI(t *testing.T) {
 			userID: user.ID.String(),
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					DeleteLike(gomock.Any(), gomock.Any()).
+					RemoveReactionTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -287,7 +384,7 @@ func TestDeleteLikeAPI(t *testing.T) {
 			userID: "invalid-uuid",
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					DeleteLike(gomock.Any(), gomock.Any()).
+					RemoveReactionTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -300,9 +397,9 @@ func TestDeleteLikeAPI(t *testing.T) {
 			userID: user.ID.String(),
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					DeleteLike(gomock.Any(), gomock.Any()).
+					RemoveReactionTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.ReactionTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -584,10 +681,11 @@ func randomLike(t *testing.T) db.Like {
 	require.NoError(t, err)
 
 	return db.Like{
-		ID:      id,
-		PostID:  postID,
-		UserID:  userID,
-		LikedAt: likedAt,
+		ID:       id,
+		PostID:   postID,
+		UserID:   userID,
+		LikedAt:  likedAt,
+		Reaction: db.LikeReaction,
 	}
 }
 
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/unfurl"
+)
+
+// linkPreviewTTL is how long a fetched preview is reused before the link is fetched again
+const linkPreviewTTL = 7 * 24 * time.Hour
+
+// linkUnfurler fetches the preview for a link, see unfurl.Fetcher
+type linkUnfurler interface {
+	Fetch(ctx context.Context, rawURL string) (unfurl.Preview, error)
+}
+
+type linkPreviewResponse struct {
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Provider     string `json:"provider,omitempty"`
+	EmbedHTML    string `json:"embed_html,omitempty"`
+}
+
+// newLinkPreviewResponse returns nil for previews without anything worth showing
+func newLinkPreviewResponse(preview db.LinkPreview) *linkPreviewResponse {
+	if preview.Title == "" && preview.ThumbnailUrl == "" && preview.EmbedHtml == "" {
+		return nil
+	}
+	return &linkPreviewResponse{
+		Title:        preview.Title,
+		Description:  preview.Description,
+		ThumbnailURL: preview.ThumbnailUrl,
+		Provider:     preview.Provider,
+		EmbedHTML:    preview.EmbedHtml,
+	}
+}
+
+// embedLinkURL is the link a post's preview is stored under, empty for other post types
+func embedLinkURL(postType db.NullPostType, mediaURL pgtype.Text) string {
+	if postType.PostType != db.PostTypeEmbedLink {
+		return ""
+	}
+	return mediaURL.String
+}
+
+// unfurlLink returns the preview for an embed link post, fetching it unless a
+// fresh one is cached. A link that can't be fetched only costs the post its
+// preview, so errors are logged rather than returned.
+func (s *Server) unfurlLink(ctx context.Context, post db.Post) *linkPreviewResponse {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	url := embedLinkURL(post.PostType, post.MediaUrl)
+	if url == "" || s.unfurler == nil {
+		return nil
+	}
+
+	cached, err := s.hub.GetLinkPreview(ctx, url)
+	if err == nil && time.Since(cached.FetchedAt.Time) < linkPreviewTTL {
+		return newLinkPreviewResponse(cached)
+	}
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		log.Error("Failed to get cached link preview", err)
+		return nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, unfurl.DefaultTimeout)
+	defer cancel()
+
+	preview, fetchErr := s.unfurler.Fetch(fetchCtx, url)
+	if fetchErr != nil {
+		log.Error("Failed to unfurl link", fetchErr)
+		// A stale preview is still better than none
+		if err == nil {
+			return newLinkPreviewResponse(cached)
+		}
+		return nil
+	}
+
+	saved, err := s.hub.UpsertLinkPreview(ctx, db.UpsertLinkPreviewParams{
+		Url:          url,
+		Title:        preview.Title,
+		Description:  preview.Description,
+		ThumbnailUrl: preview.ThumbnailURL,
+		Provider:     preview.Provider,
+		EmbedHtml:    preview.EmbedHTML,
+	})
+	if err != nil {
+		log.Error("Failed to cache link preview", err)
+		return nil
+	}
+
+	return newLinkPreviewResponse(saved)
+}
+
+// linkPreviews loads the cached previews for a set of links, keyed by URL
+func (s *Server) linkPreviews(ctx context.Context, urls []string) map[string]*linkPreviewResponse {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	previews, err := s.hub.ListLinkPreviewsByURL(ctx, urls)
+	if err != nil {
+		log.Error("Failed to list link previews", err)
+		return nil
+	}
+
+	byURL := make(map[string]*linkPreviewResponse, len(previews))
+	for _, preview := range previews {
+		byURL[preview.Url] = newLinkPreviewResponse(preview)
+	}
+	return byURL
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/unfurl"
+)
+
+// stubUnfurler stands in for unfurl.Fetcher so tests don't go over the network
+type stubUnfurler struct {
+	preview unfurl.Preview
+	err     error
+	calls   int
+}
+
+func (u *stubUnfurler) Fetch(_ context.Context, _ string) (unfurl.Preview, error) {
+	u.calls++
+	return u.preview, u.err
+}
+
+func TestCreatePostLinkPreview(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	post := randomPost(t, wall.ID, user.ID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeEmbedLink, Valid: true}
+	post.MediaUrl = pgtype.Text{String: "https://example.com/article", Valid: true}
+
+	fetched := unfurl.Preview{
+		Title:        "Street art week",
+		ThumbnailURL: "https://example.com/cover.jpg",
+		Provider:     "example.com",
+	}
+	cached := db.LinkPreview{
+		Url:       post.MediaUrl.String,
+		Title:     "Cached title",
+		Provider:  "example.com",
+		FetchedAt: pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+	stale := cached
+	stale.FetchedAt = pgtype.Timestamp{Time: time.Now().Add(-2 * linkPreviewTTL), Valid: true}
+
+	testCases := []struct {
+		name          string
+		unfurler      *stubUnfurler
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(t *testing.T, rsp postResponse, unfurler *stubUnfurler)
+	}{
+		{
+			name:     "OK_Fetched",
+			unfurler: &stubUnfurler{preview: fetched},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetLinkPreview(gomock.Any(), post.MediaUrl.String).
+					Times(1).
+					Return(db.LinkPreview{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					UpsertLinkPreview(gomock.Any(), db.UpsertLinkPreviewParams{
+						Url:          post.MediaUrl.String,
+						Title:        fetched.Title,
+						ThumbnailUrl: fetched.ThumbnailURL,
+						Provider:     fetched.Provider,
+					}).
+					Times(1).
+					Return(db.LinkPreview{Url: post.MediaUrl.String, Title: fetched.Title, ThumbnailUrl: fetched.ThumbnailURL, Provider: fetched.Provider}, nil)
+			},
+			checkResponse: func(t *testing.T, rsp postResponse, unfurler *stubUnfurler) {
+				require.Equal(t, 1, unfurler.calls)
+				require.NotNil(t, rsp.LinkPreview)
+				require.Equal(t, fetched.Title, rsp.LinkPreview.Title)
+				require.Equal(t, fetched.ThumbnailURL, rsp.LinkPreview.ThumbnailURL)
+			},
+		},
+		{
+			name:     "OK_Cached",
+			unfurler: &stubUnfurler{preview: fetched},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetLinkPreview(gomock.Any(), post.MediaUrl.String).
+					Times(1).
+					Return(cached, nil)
+				mockHub.EXPECT().
+					UpsertLinkPreview(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp postResponse, unfurler *stubUnfurler) {
+				require.Zero(t, unfurler.calls)
+				require.NotNil(t, rsp.LinkPreview)
+				require.Equal(t, cached.Title, rsp.LinkPreview.Title)
+			},
+		},
+		{
+			name:     "OK_StaleKeptOnFailure",
+			unfurler: &stubUnfurler{err: unfurl.ErrBlockedAddress},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetLinkPreview(gomock.Any(), post.MediaUrl.String).
+					Times(1).
+					Return(stale, nil)
+				mockHub.EXPECT().
+					UpsertLinkPreview(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp postResponse, unfurler *stubUnfurler) {
+				require.Equal(t, 1, unfurler.calls)
+				require.NotNil(t, rsp.LinkPreview)
+				require.Equal(t, stale.Title, rsp.LinkPreview.Title)
+			},
+		},
+		{
+			name:     "OK_FetchFailed",
+			unfurler: &stubUnfurler{err: errors.New("timeout")},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetLinkPreview(gomock.Any(), post.MediaUrl.String).
+					Times(1).
+					Return(db.LinkPreview{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					UpsertLinkPreview(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp postResponse, unfurler *stubUnfurler) {
+				require.Equal(t, 1, unfurler.calls)
+				require.Nil(t, rsp.LinkPreview)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.unfurler = tc.unfurler
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+			mockHub.EXPECT().
+				GetDefaultWallSection(gomock.Any(), wall.ID).
+				Return(db.WallSection{}, db.ErrRecordNotFound)
+			mockHub.EXPECT().CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(post, nil)
+			mockHub.EXPECT().
+				ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+				Return([]pgtype.UUID{}, nil)
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createPost(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": "embed_link",
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusCreated, recorder.Code)
+
+			body, err := io.ReadAll(recorder.Body)
+			require.NoError(t, err)
+
+			var rsp postResponse
+			require.NoError(t, json.Unmarshal(body, &rsp))
+			tc.checkResponse(t, rsp, tc.unfurler)
+		})
+	}
+}
+
+func TestListPostsLinkPreviews(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	media := db.ListPostsByWallWithAuthorsDetailsRow{
+		ID:       randomWall(t, user.ID).ID,
+		WallID:   wall.ID,
+		PostType: db.NullPostType{PostType: db.PostTypeMedia, Valid: true},
+		MediaUrl: pgtype.Text{String: "https://cdn.example.com/a.jpg", Valid: true},
+		Status:   db.PostStatusApproved,
+	}
+	link := media
+	link.ID = randomWall(t, user.ID).ID
+	link.PostType = db.NullPostType{PostType: db.PostTypeEmbedLink, Valid: true}
+	link.MediaUrl = pgtype.Text{String: "https://example.com/article", Valid: true}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListPostsByWallWithAuthorsDetails(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListPostsByWallWithAuthorsDetailsRow{media, link}, nil)
+	// Only the embed link is looked up, in a single query
+	mockHub.EXPECT().
+		ListLinkPreviewsByURL(gomock.Any(), []string{link.MediaUrl.String}).
+		Times(1).
+		Return([]db.LinkPreview{{Url: link.MediaUrl.String, Title: "Street art week"}}, nil)
+
+	server.router.GET("/test/walls/:id/posts", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.listPostsByWallWithAuthorsDetails(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/test/walls/"+wall.ID.String()+"/posts", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp []PostResponseWithAuthor
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp, 2)
+	require.Nil(t, rsp[0].LinkPreview)
+	require.NotNil(t, rsp[1].LinkPreview)
+	require.Equal(t, "Street art week", rsp[1].LinkPreview.Title)
+}
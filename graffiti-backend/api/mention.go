@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// mentionResponse is a resolved @username in a caption or comment. Start and
+// End are UTF-16 offsets into the text, see util.MentionSpan.
+type mentionResponse struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Start    int32  `json:"start"`
+	End      int32  `json:"end"`
+}
+
+// mentionTarget is the post caption or comment that mentions belong to
+type mentionTarget struct {
+	PostID    pgtype.UUID
+	CommentID pgtype.UUID
+}
+
+// saveMentions resolves the mentions in a caption or comment that went from
+// oldText to newText and stores them in place of the old ones. It returns the
+// mentions to show along with the users who weren't mentioned before.
+// Usernames that don't exist or belong to someone who blocked the author are
+// dropped without an error.
+func (s *Server) saveMentions(ctx context.Context, author pgtype.UUID, target mentionTarget, oldText, newText string) ([]mentionResponse, []pgtype.UUID, error) {
+	spans := util.ParseMentions(newText)
+	if len(spans) == 0 && len(util.ParseMentions(oldText)) == 0 {
+		return nil, nil, nil
+	}
+
+	var mentions []db.CreateMentionParams
+	usernames := map[pgtype.UUID]string{}
+	if len(spans) > 0 {
+		var names []string
+		seen := map[string]bool{}
+		for _, span := range spans {
+			if !seen[span.Username] {
+				seen[span.Username] = true
+				names = append(names, span.Username)
+			}
+		}
+
+		users, err := s.hub.ListMentionableUsers(ctx, db.ListMentionableUsersParams{
+			Usernames: names,
+			AuthorID:  author,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		byName := make(map[string]pgtype.UUID, len(users))
+		for _, user := range users {
+			byName[user.Username] = user.ID
+			usernames[user.ID] = user.Username
+		}
+
+		for _, span := range spans {
+			userID, ok := byName[span.Username]
+			if !ok {
+				continue
+			}
+			mentions = append(mentions, db.CreateMentionParams{
+				UserID:      userID,
+				StartOffset: int32(span.Start),
+				EndOffset:   int32(span.End),
+			})
+		}
+	}
+
+	result, err := s.hub.ReplaceMentionsTx(ctx, db.ReplaceMentionsTxParams{
+		MentionedBy: author,
+		PostID:      target.PostID,
+		CommentID:   target.CommentID,
+		Mentions:    mentions,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]mentionResponse, 0, len(result.Mentions))
+	for _, mention := range result.Mentions {
+		responses = append(responses, newMentionResponse(mention, usernames[mention.UserID]))
+	}
+	return responses, result.Added, nil
+}
+
+func newMentionResponse(mention db.Mention, username string) mentionResponse {
+	return mentionResponse{
+		UserID:   mention.UserID.String(),
+		Username: username,
+		Start:    mention.StartOffset,
+		End:      mention.EndOffset,
+	}
+}
+
+// notifyMentions tells mentioned users about a post caption or comment on
+// postID that mentions them. Authors aren't notified about mentioning themselves,
+// and the author of an anonymous post isn't named.
+func (s *Server) notifyMentions(ctx context.Context, postID pgtype.UUID, author db.User, userIDs []pgtype.UUID, inComment, anonymous bool) {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	where := "a post"
+	if inComment {
+		where = "a comment"
+	}
+	name := author.Username
+	if anonymous {
+		name = "Someone"
+	}
+
+	for _, userID := range userIDs {
+		if userID == author.ID {
+			continue
+		}
+		// Anonymous mentions come from the mentioned user, like other
+		// notifications about anonymous posts
+		sender := author.ID.String()
+		if anonymous {
+			sender = userID.String()
+		}
+		err := s.SendNotification(
+			ctx,
+			userID.String(),
+			sender,
+			"mention",
+			postID.String(),
+			fmt.Sprintf("%s mentioned you in %s", name, where),
+		)
+		if err != nil {
+			log.Error("Failed to send mention notification", err)
+		}
+	}
+}
+
+// notifyPostMentions notifies everyone mentioned in an approved post's caption.
+// Posts waiting for review hold their mentions back until they're approved.
+func (s *Server) notifyPostMentions(ctx context.Context, post db.Post) error {
+	if post.Status != db.PostStatusApproved || len(util.ParseMentions(post.Caption.String)) == 0 {
+		return nil
+	}
+
+	mentions, err := s.hub.ListMentionsByPosts(ctx, []pgtype.UUID{post.ID})
+	if err != nil {
+		return err
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	author, err := s.hub.GetUser(ctx, post.Author)
+	if err != nil {
+		return err
+	}
+
+	var userIDs []pgtype.UUID
+	seen := map[pgtype.UUID]bool{}
+	for _, mention := range mentions {
+		if !seen[mention.UserID] {
+			seen[mention.UserID] = true
+			userIDs = append(userIDs, mention.UserID)
+		}
+	}
+
+	s.notifyMentions(ctx, post.ID, author, userIDs, false, post.IsAnonymous)
+	return nil
+}
+
+// postMentions loads the mentions in the given posts' captions, keyed by post.
+// Only posts whose caption has something that looks like a mention are looked up.
+func (s *Server) postMentions(ctx context.Context, posts map[pgtype.UUID]string) map[pgtype.UUID][]mentionResponse {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	var ids []pgtype.UUID
+	for id, caption := range posts {
+		if len(util.ParseMentions(caption)) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mentions, err := s.hub.ListMentionsByPosts(ctx, ids)
+	if err != nil {
+		log.Error("Failed to list post mentions", err)
+		return nil
+	}
+
+	byPost := make(map[pgtype.UUID][]mentionResponse, len(ids))
+	for _, mention := range mentions {
+		byPost[mention.PostID] = append(byPost[mention.PostID], mentionResponse{
+			UserID:   mention.UserID.String(),
+			Username: mention.Username,
+			Start:    mention.StartOffset,
+			End:      mention.EndOffset,
+		})
+	}
+	return byPost
+}
+
+// commentMentions loads the mentions in the given comments, keyed by comment.
+// Deleted comments hide their body, so their mentions are left out too.
+func (s *Server) commentMentions(ctx context.Context, comments []db.Comment) map[pgtype.UUID][]mentionResponse {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	var ids []pgtype.UUID
+	for _, comment := range comments {
+		if !comment.IsDeleted && len(util.ParseMentions(comment.Body)) > 0 {
+			ids = append(ids, comment.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mentions, err := s.hub.ListMentionsByComments(ctx, ids)
+	if err != nil {
+		log.Error("Failed to list comment mentions", err)
+		return nil
+	}
+
+	byComment := make(map[pgtype.UUID][]mentionResponse, len(ids))
+	for _, mention := range mentions {
+		byComment[mention.CommentID] = append(byComment[mention.CommentID], mentionResponse{
+			UserID:   mention.UserID.String(),
+			Username: mention.Username,
+			Start:    mention.StartOffset,
+			End:      mention.EndOffset,
+		})
+	}
+	return byComment
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestCreateCommentMentions(t *testing.T) {
+	owner, _ := randomUser(t)
+	user, _ := randomUser(t)
+	mentioned, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	post := randomPost(t, wall.ID, owner.ID)
+
+	body := fmt.Sprintf("hey @%s and @ghost", mentioned.Username)
+	comment := randomComment(t, post.ID, user.ID)
+	comment.Body = body
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				// Unknown users and users who blocked the author are left out by the query
+				mockHub.EXPECT().
+					ListMentionableUsers(gomock.Any(), db.ListMentionableUsersParams{
+						Usernames: []string{mentioned.Username, "ghost"},
+						AuthorID:  user.ID,
+					}).
+					Times(1).
+					Return([]db.ListMentionableUsersRow{{ID: mentioned.ID, Username: mentioned.Username}}, nil)
+				mockHub.EXPECT().
+					ReplaceMentionsTx(gomock.Any(), db.ReplaceMentionsTxParams{
+						MentionedBy: user.ID,
+						CommentID:   comment.ID,
+						Mentions: []db.CreateMentionParams{{
+							UserID:      mentioned.ID,
+							StartOffset: 4,
+							EndOffset:   int32(5 + len(mentioned.Username)),
+						}},
+					}).
+					Times(1).
+					Return(db.ReplaceMentionsTxResult{
+						Mentions: []db.Mention{{UserID: mentioned.ID, CommentID: comment.ID, StartOffset: 4, EndOffset: int32(5 + len(mentioned.Username))}},
+						Added:    []pgtype.UUID{mentioned.ID},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var rsp commentResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, []mentionResponse{{
+					UserID:   mentioned.ID.String(),
+					Username: mentioned.Username,
+					Start:    4,
+					End:      int32(5 + len(mentioned.Username)),
+				}}, rsp.Mentions)
+			},
+		},
+		{
+			name: "OK_SaveFailed",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListMentionableUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("connection lost"))
+				mockHub.EXPECT().
+					ReplaceMentionsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// The comment is already saved, it just goes out without mentions
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var rsp commentResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Empty(t, rsp.Mentions)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(post, nil)
+			mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+			mockHub.EXPECT().
+				ListFriendshipByUserPairs(gomock.Any(), gomock.Any()).
+				Return(db.Friendship{Status: db.NullStatus{Status: db.StatusFriends, Valid: true}}, nil)
+			mockHub.EXPECT().IsUserBlockedTx(gomock.Any(), owner.ID, user.ID).Return(false, nil)
+			mockHub.EXPECT().CreateCommentTx(gomock.Any(), gomock.Any()).Return(comment, nil)
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/posts/:id/comments", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createComment(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"body": body})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/posts/%s/comments", post.ID.String())
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCommentRemovesMentions(t *testing.T) {
+	user, _ := randomUser(t)
+	post := randomPost(t, randomWall(t, user.ID).ID, user.ID)
+	comment := randomComment(t, post.ID, user.ID)
+	comment.Body = "hi @ann"
+
+	edited := comment
+	edited.Body = "hi everyone"
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().GetComment(gomock.Any(), comment.ID).Return(comment, nil)
+	mockHub.EXPECT().UpdateCommentBody(gomock.Any(), gomock.Any()).Return(edited, nil)
+	// Nobody is left to look up, but the old mention still has to go
+	mockHub.EXPECT().ListMentionableUsers(gomock.Any(), gomock.Any()).Times(0)
+	mockHub.EXPECT().
+		ReplaceMentionsTx(gomock.Any(), db.ReplaceMentionsTxParams{MentionedBy: user.ID, CommentID: comment.ID}).
+		Times(1).
+		Return(db.ReplaceMentionsTxResult{}, nil)
+
+	server.router.PUT("/test/comments/:id", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.updateComment(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	data, err := json.Marshal(gin.H{"body": edited.Body})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPut, "/test/comments/"+comment.ID.String(), bytes.NewReader(data))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestCreatePostMentions(t *testing.T) {
+	user, _ := randomUser(t)
+	mentioned, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	post := randomPost(t, wall.ID, user.ID)
+	post.Caption = pgtype.Text{String: "with @" + mentioned.Username, Valid: true}
+	post.MediaUrl = pgtype.Text{String: randomUploadURL(), Valid: true}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+	expectPostUpload(server, mockHub, user.ID)
+
+	mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+	mockHub.EXPECT().GetDefaultWallSection(gomock.Any(), wall.ID).Return(db.WallSection{}, db.ErrRecordNotFound)
+	mockHub.EXPECT().CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(post, nil)
+	mockHub.EXPECT().ListWallFollowersToNotify(gomock.Any(), gomock.Any()).Return([]pgtype.UUID{}, nil)
+	mockHub.EXPECT().
+		ListMentionableUsers(gomock.Any(), db.ListMentionableUsersParams{
+			Usernames: []string{mentioned.Username},
+			AuthorID:  user.ID,
+		}).
+		Times(1).
+		Return([]db.ListMentionableUsersRow{{ID: mentioned.ID, Username: mentioned.Username}}, nil)
+	mockHub.EXPECT().
+		ReplaceMentionsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.ReplaceMentionsTxParams) (db.ReplaceMentionsTxResult, error) {
+			require.Equal(t, post.ID, arg.PostID)
+			require.False(t, arg.CommentID.Valid)
+			require.Len(t, arg.Mentions, 1)
+			mention := db.Mention{UserID: mentioned.ID, PostID: post.ID, StartOffset: arg.Mentions[0].StartOffset, EndOffset: arg.Mentions[0].EndOffset}
+			return db.ReplaceMentionsTxResult{Mentions: []db.Mention{mention}, Added: []pgtype.UUID{mentioned.ID}}, nil
+		})
+
+	server.router.POST("/test/posts", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.createPost(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	data, err := json.Marshal(gin.H{
+		"wall_id":   wall.ID,
+		"media_url": post.MediaUrl.String,
+		"post_type": "media",
+		"caption":   post.Caption.String,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+
+	var rsp postResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp.Mentions, 1)
+	require.Equal(t, mentioned.Username, rsp.Mentions[0].Username)
+	require.Equal(t, int32(5), rsp.Mentions[0].Start)
+}
+
+func TestListPostsMentions(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	plain := db.ListPostsByWallWithAuthorsDetailsRow{
+		ID:      randomWall(t, user.ID).ID,
+		WallID:  wall.ID,
+		Caption: pgtype.Text{String: "no mentions here", Valid: true},
+		Status:  db.PostStatusApproved,
+	}
+	tagged := plain
+	tagged.ID = randomWall(t, user.ID).ID
+	tagged.Caption = pgtype.Text{String: "@ann", Valid: true}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListPostsByWallWithAuthorsDetails(gomock.Any(), gomock.Any()).
+		Return([]db.ListPostsByWallWithAuthorsDetailsRow{plain, tagged}, nil)
+	// Only the caption that looks like it has mentions is looked up
+	mockHub.EXPECT().
+		ListMentionsByPosts(gomock.Any(), []pgtype.UUID{tagged.ID}).
+		Times(1).
+		Return([]db.ListMentionsByPostsRow{{PostID: tagged.ID, UserID: user.ID, Username: "ann", StartOffset: 0, EndOffset: 4}}, nil)
+
+	server.router.GET("/test/walls/:id/posts", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.listPostsByWallWithAuthorsDetails(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/test/walls/"+wall.ID.String()+"/posts", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp []PostResponseWithAuthor
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp, 2)
+	require.Empty(t, rsp[0].Mentions)
+	require.Equal(t, []mentionResponse{{UserID: user.ID.String(), Username: "ann", Start: 0, End: 4}}, rsp[1].Mentions)
+}
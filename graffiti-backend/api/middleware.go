@@ -26,6 +26,14 @@ func (s *Server) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if isSuspended(user) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":           "Account suspended",
+				"suspended_until": user.SuspendedUntil.Time,
+			})
+			return
+		}
+
 		ctx.Set("currentUser", user)
 		ctx.Next()
 	}
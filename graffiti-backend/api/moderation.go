@@ -0,0 +1,647 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// Moderation request/response types
+type setWallModerationRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+type moderatePostRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+}
+
+type moderatePostsRequest struct {
+	PostIDs []string `json:"post_ids" binding:"required,min=1,max=100,dive,uuid"`
+	Action  string   `json:"action" binding:"required,oneof=approve reject"`
+}
+
+type addWallModeratorRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+type wallModeratorResponse struct {
+	WallID         string    `json:"wall_id"`
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	Fullname       string    `json:"fullname"`
+	ProfilePicture string    `json:"profile_picture"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func newWallModeratorResponse(moderator db.ListWallModeratorsRow) wallModeratorResponse {
+	return wallModeratorResponse{
+		WallID:         moderator.WallID.String(),
+		UserID:         moderator.UserID.String(),
+		Username:       moderator.Username,
+		Fullname:       moderator.Fullname.String,
+		ProfilePicture: moderator.ProfilePicture.String,
+		CreatedAt:      moderator.CreatedAt.Time,
+	}
+}
+
+// moderationStatus maps a moderation action to the post status it results in
+func moderationStatus(action string) db.PostStatus {
+	if action == "approve" {
+		return db.PostStatusApproved
+	}
+	return db.PostStatusRejected
+}
+
+// canModerateWall reports whether the user is the wall owner or one of its moderators
+func (s *Server) canModerateWall(ctx context.Context, wall db.Wall, user db.User) (bool, error) {
+	if wall.UserID == user.ID {
+		return true, nil
+	}
+
+	return s.hub.IsWallModerator(ctx, db.IsWallModeratorParams{
+		WallID: wall.ID,
+		UserID: user.ID,
+	})
+}
+
+// newPostStatus is the status a new post by user starts out with. Posts on a
+// moderated wall wait for review unless they come from the owner or a moderator.
+func (s *Server) newPostStatus(ctx context.Context, wall db.Wall, user db.User) (db.PostStatus, error) {
+	if !wall.ModerationEnabled.Bool {
+		return db.PostStatusApproved, nil
+	}
+
+	canModerate, err := s.canModerateWall(ctx, wall, user)
+	if err != nil {
+		return "", err
+	}
+	if !canModerate {
+		return db.PostStatusPending, nil
+	}
+	return db.PostStatusApproved, nil
+}
+
+// notifyModerationDecision tells a post's author whether it made it onto the wall
+func (s *Server) notifyModerationDecision(ctx context.Context, post db.Post, wall db.Wall, moderator db.User) error {
+	notificationType := "post_approved"
+	message := fmt.Sprintf("Your post on \"%s\" was approved", wall.Title)
+	if post.Status == db.PostStatusRejected {
+		notificationType = "post_rejected"
+		message = fmt.Sprintf("Your post on \"%s\" was rejected", wall.Title)
+	}
+
+	return s.SendNotification(
+		ctx,
+		post.Author.String(),  // recipient (post author)
+		moderator.ID.String(), // sender (reviewer)
+		notificationType,      // notification type
+		post.ID.String(),      // entity ID (post ID)
+		message,               // message
+	)
+}
+
+// SetWallModeration handler turns pre-moderation on or off for a wall
+func (s *Server) setWallModeration(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set wall moderation request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setWallModerationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to change wall moderation", errors.New("user not authorized to change moderation for this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to change moderation for this wall")))
+		return
+	}
+
+	wall, err = s.hub.SetWallModeration(ctx, db.SetWallModerationParams{
+		ID:                id,
+		ModerationEnabled: pgtype.Bool{Bool: *req.Enabled, Valid: true},
+	})
+	if err != nil {
+		log.Error("Failed to set wall moderation", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall moderation updated successfully")
+	ctx.JSON(http.StatusOK, newWallResponse(wall))
+}
+
+// ListModerationQueue handler returns the posts waiting for review on a wall
+func (s *Server) listModerationQueue(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list moderation queue request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	canModerate, err := s.canModerateWall(ctx, wall, currentUser)
+	if err != nil {
+		log.Error("Failed to check wall moderators", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !canModerate {
+		log.Error("Unauthorized to view moderation queue", errors.New("user not authorized to moderate this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to moderate this wall")))
+		return
+	}
+
+	posts, err := s.hub.ListPendingPostsByWall(ctx, id)
+	if err != nil {
+		log.Error("Failed to list pending posts", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Moderation queue listed successfully")
+	responses := make([]PostResponseWithAuthor, 0, len(posts))
+	for _, post := range posts {
+		responses = append(responses, newPostResponseWithAuthor(hideAnonymousAuthor(db.ListPostsByWallWithAuthorsDetailsRow(post), currentUser.ID)))
+	}
+
+	ctx.JSON(http.StatusOK, responses)
+}
+
+// ModeratePost handler approves or rejects a single pending post
+func (s *Server) moderatePost(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received moderate post request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req moderatePostRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, post.WallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	canModerate, err := s.canModerateWall(ctx, wall, currentUser)
+	if err != nil {
+		log.Error("Failed to check wall moderators", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !canModerate {
+		log.Error("Unauthorized to moderate post", errors.New("user not authorized to moderate this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to moderate this wall")))
+		return
+	}
+
+	post, err = s.hub.ModeratePost(ctx, db.ModeratePostParams{
+		ID:     id,
+		Status: moderationStatus(req.Action),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrPostNotPending))
+			return
+		}
+		log.Error("Failed to moderate post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := s.notifyModerationDecision(ctx, post, wall, currentUser); err != nil {
+		log.Error("Failed to send moderation notification", err)
+	}
+	if err := s.notifyWallFollowers(ctx, wall, post); err != nil {
+		log.Error("Failed to notify wall followers", err)
+	}
+	if err := s.notifyPostMentions(ctx, post); err != nil {
+		log.Error("Failed to notify mentioned users", err)
+	}
+
+	log.Info("Post moderated successfully")
+	ctx.JSON(http.StatusOK, newPostResponse(post))
+}
+
+// ModeratePosts handler approves or rejects several pending posts on a wall at once
+func (s *Server) moderatePosts(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received bulk moderate posts request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req moderatePostsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var wallID pgtype.UUID
+	if err := wallID.Scan(uri.ID); err != nil {
+		log.Error("Invalid wall_id", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	postIDs := make([]pgtype.UUID, 0, len(req.PostIDs))
+	for _, rawID := range req.PostIDs {
+		var postID pgtype.UUID
+		if err := postID.Scan(rawID); err != nil {
+			log.Error("Invalid post ID", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	wall, err := s.hub.GetWall(ctx, wallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	canModerate, err := s.canModerateWall(ctx, wall, currentUser)
+	if err != nil {
+		log.Error("Failed to check wall moderators", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !canModerate {
+		log.Error("Unauthorized to moderate posts", errors.New("user not authorized to moderate this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to moderate this wall")))
+		return
+	}
+
+	posts, err := s.hub.ModeratePostsTx(ctx, wallID, postIDs, moderationStatus(req.Action))
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrPostNotPending):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrPostNotInWall):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrRecordNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		log.Error("Failed to moderate posts", err)
+		return
+	}
+
+	responses := make([]postResponse, 0, len(posts))
+	for _, post := range posts {
+		if err := s.notifyModerationDecision(ctx, post, wall, currentUser); err != nil {
+			log.Error("Failed to send moderation notification", err)
+		}
+		if err := s.notifyWallFollowers(ctx, wall, post); err != nil {
+			log.Error("Failed to notify wall followers", err)
+		}
+		if err := s.notifyPostMentions(ctx, post); err != nil {
+			log.Error("Failed to notify mentioned users", err)
+		}
+		responses = append(responses, newPostResponse(post))
+	}
+
+	log.Info("Posts moderated successfully")
+	ctx.JSON(http.StatusOK, responses)
+}
+
+// ListWallModerators handler
+func (s *Server) listWallModerators(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list wall moderators request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	canModerate, err := s.canModerateWall(ctx, wall, currentUser)
+	if err != nil {
+		log.Error("Failed to check wall moderators", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !canModerate {
+		log.Error("Unauthorized to list wall moderators", errors.New("user not authorized to moderate this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to moderate this wall")))
+		return
+	}
+
+	moderators, err := s.hub.ListWallModerators(ctx, id)
+	if err != nil {
+		log.Error("Failed to list wall moderators", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall moderators listed successfully")
+	responses := make([]wallModeratorResponse, 0, len(moderators))
+	for _, moderator := range moderators {
+		responses = append(responses, newWallModeratorResponse(moderator))
+	}
+
+	ctx.JSON(http.StatusOK, responses)
+}
+
+// AddWallModerator handler lets a wall owner appoint another user as moderator
+func (s *Server) addWallModerator(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received add wall moderator request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req addWallModeratorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var wallID pgtype.UUID
+	if err := wallID.Scan(uri.ID); err != nil {
+		log.Error("Invalid wall_id", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var userID pgtype.UUID
+	if err := userID.Scan(req.UserID); err != nil {
+		log.Error("Invalid user_id", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, wallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to add wall moderator", errors.New("user not authorized to manage moderators for this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to manage moderators for this wall")))
+		return
+	}
+
+	if userID == wall.UserID {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("wall owner is already a moderator")))
+		return
+	}
+
+	_, err = s.hub.AddWallModerator(ctx, db.AddWallModeratorParams{
+		WallID: wallID,
+		UserID: userID,
+	})
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, errorResponse(errors.New("user is already a moderator of this wall")))
+		case db.ForeignKeyViolation:
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("user not found")))
+		default:
+			log.Error("Failed to add wall moderator", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	log.Info("Wall moderator added successfully")
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Moderator added successfully"})
+}
+
+// RemoveWallModerator handler
+func (s *Server) removeWallModerator(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received remove wall moderator request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID     string `uri:"id" binding:"required,uuid"`
+		UserID string `uri:"user_id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var wallID pgtype.UUID
+	if err := wallID.Scan(uri.ID); err != nil {
+		log.Error("Invalid wall_id", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var userID pgtype.UUID
+	if err := userID.Scan(uri.UserID); err != nil {
+		log.Error("Invalid user_id", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, wallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Moderators may step down themselves, otherwise only the owner can remove them
+	if wall.UserID != currentUser.ID && userID != currentUser.ID {
+		log.Error("Unauthorized to remove wall moderator", errors.New("user not authorized to manage moderators for this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to manage moderators for this wall")))
+		return
+	}
+
+	err = s.hub.RemoveWallModerator(ctx, db.RemoveWallModeratorParams{
+		WallID: wallID,
+		UserID: userID,
+	})
+	if err != nil {
+		log.Error("Failed to remove wall moderator", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall moderator removed successfully")
+	ctx.JSON(http.StatusOK, gin.H{"message": "Moderator removed successfully"})
+}
@@ -0,0 +1,552 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestCreatePostOnModeratedWallAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	moderator, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	wall.ModerationEnabled = pgtype.Bool{Bool: true, Valid: true}
+
+	testCases := []struct {
+		name           string
+		currentUser    db.User
+		expectedStatus db.PostStatus
+		setupMock      func(mockHub *mockdb.MockHub)
+	}{
+		{
+			name:           "Pending_Author",
+			currentUser:    author,
+			expectedStatus: db.PostStatusPending,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					IsWallModerator(gomock.Any(), db.IsWallModeratorParams{WallID: wall.ID, UserID: author.ID}).
+					Times(1).
+					Return(false, nil)
+			},
+		},
+		{
+			name:           "Approved_Moderator",
+			currentUser:    moderator,
+			expectedStatus: db.PostStatusApproved,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					IsWallModerator(gomock.Any(), db.IsWallModeratorParams{WallID: wall.ID, UserID: moderator.ID}).
+					Times(1).
+					Return(true, nil)
+			},
+		},
+		{
+			name:           "Approved_Owner",
+			currentUser:    owner,
+			expectedStatus: db.PostStatusApproved,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					IsWallModerator(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			post := randomPost(t, wall.ID, tc.currentUser.ID)
+			post.Status = tc.expectedStatus
+			post.MediaUrl = pgtype.Text{String: randomUploadURL(), Valid: true}
+			expectPostUpload(server, mockHub, tc.currentUser.ID)
+
+			mockHub.EXPECT().
+				GetWall(gomock.Any(), wall.ID).
+				Times(1).
+				Return(wall, nil)
+			tc.setupMock(mockHub)
+			mockHub.EXPECT().
+				GetDefaultWallSection(gomock.Any(), wall.ID).
+				Times(1).
+				Return(db.WallSection{}, db.ErrRecordNotFound)
+			mockHub.EXPECT().
+				CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ interface{}, arg db.CreatePostParams, _ string) (db.Post, error) {
+					require.Equal(t, tc.expectedStatus, arg.Status)
+					return post, nil
+				})
+
+			// Followers only hear about posts that went straight onto the wall
+			followerCalls := 0
+			if tc.expectedStatus == db.PostStatusApproved {
+				followerCalls = 1
+			}
+			mockHub.EXPECT().
+				ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+				Times(followerCalls).
+				Return([]pgtype.UUID{}, nil)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.createPost(ctx)
+			})
+
+			data, err := json.Marshal(gin.H{
+				"wall_id":   wall.ID.String(),
+				"media_url": post.MediaUrl.String,
+				"post_type": "media",
+			})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusCreated, recorder.Code)
+			requireBodyMatchPostResponse(t, recorder.Body, post)
+		})
+	}
+}
+
+func TestListModerationQueueAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	post := randomPost(t, wall.ID, author.ID)
+	post.Status = db.PostStatusPending
+	pending := []db.ListPendingPostsByWallRow{{
+		ID:       post.ID,
+		WallID:   post.WallID,
+		Author:   post.Author,
+		MediaUrl: post.MediaUrl,
+		PostType: post.PostType,
+		Status:   post.Status,
+		Username: author.Username,
+	}}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_Owner",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListPendingPostsByWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(pending, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var got []PostResponseWithAuthor
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.Len(t, got, 1)
+				require.Equal(t, post.ID.String(), got[0].ID)
+				require.Equal(t, string(db.PostStatusPending), got[0].Status)
+				require.Equal(t, author.Username, got[0].Username)
+			},
+		},
+		{
+			name:        "Unauthorized_NotModerator",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					IsWallModerator(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				mockHub.EXPECT().
+					ListPendingPostsByWall(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/walls/:id/moderation/queue", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.listModerationQueue(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/moderation/queue", wall.ID.String())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestModeratePostAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	post := randomPost(t, wall.ID, author.ID)
+	post.Status = db.PostStatusPending
+
+	approved := post
+	approved.Status = db.PostStatusApproved
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_Approve",
+			currentUser: owner,
+			body:        gin.H{"action": "approve"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ModeratePost(gomock.Any(), db.ModeratePostParams{ID: post.ID, Status: db.PostStatusApproved}).
+					Times(1).
+					Return(approved, nil)
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), db.ListWallFollowersToNotifyParams{WallID: wall.ID, UserID: author.ID}).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchPostResponse(t, recorder.Body, approved)
+			},
+		},
+		{
+			name:        "Conflict_AlreadyReviewed",
+			currentUser: owner,
+			body:        gin.H{"action": "reject"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(approved, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ModeratePost(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Post{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized_Author",
+			currentUser: author,
+			body:        gin.H{"action": "approve"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					IsWallModerator(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				mockHub.EXPECT().
+					ModeratePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_InvalidAction",
+			currentUser: owner,
+			body:        gin.H{"action": "maybe"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/posts/:id/moderation", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.moderatePost(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/posts/%s/moderation", post.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestModeratePostsAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	post1 := randomPost(t, wall.ID, author.ID)
+	post2 := randomPost(t, wall.ID, author.ID)
+	post1.Status = db.PostStatusRejected
+	post2.Status = db.PostStatusRejected
+
+	body := gin.H{
+		"post_ids": []string{post1.ID.String(), post2.ID.String()},
+		"action":   "reject",
+	}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ModeratePostsTx(gomock.Any(), wall.ID, []pgtype.UUID{post1.ID, post2.ID}, db.PostStatusRejected).
+					Times(1).
+					Return([]db.Post{post1, post2}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchPostsResponse(t, recorder.Body, []db.Post{post1, post2})
+			},
+		},
+		{
+			name: "Conflict_NotPending",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ModeratePostsTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("%w: post %s", db.ErrPostNotPending, post2.ID.String()))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ModeratePostsTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/moderation/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", owner)
+				server.moderatePosts(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/moderation/posts", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetWallModerationAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	moderated := wall
+	moderated.ModerationEnabled = pgtype.Bool{Bool: true, Valid: true}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					SetWallModeration(gomock.Any(), db.SetWallModerationParams{
+						ID:                wall.ID,
+						ModerationEnabled: pgtype.Bool{Bool: true, Valid: true},
+					}).
+					Times(1).
+					Return(moderated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got wallResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.ModerationEnabled)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					SetWallModeration(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/moderation", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.setWallModeration(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"enabled": true})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/moderation", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package api
+
+const defaultPageSize = 20
+
+// paginationRequest is bound from ?page=&page_size= query params.
+// Pages start at 1.
+type paginationRequest struct {
+	Page     int32 `form:"page" binding:"omitempty,min=1"`
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=50"`
+}
+
+func (p *paginationRequest) setDefaults() {
+	if p.Page == 0 {
+		p.Page = 1
+	}
+	if p.PageSize == 0 {
+		p.PageSize = defaultPageSize
+	}
+}
+
+func (p paginationRequest) offset() int32 {
+	return (p.Page - 1) * p.PageSize
+}
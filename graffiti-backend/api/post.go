@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"time"
@@ -17,55 +18,151 @@ import (
 
 // Post request/response types
 type createPostRequest struct {
-	WallID   string `json:"wall_id" binding:"required,uuid"`
-	MediaURL string `json:"media_url" binding:"required"`
-	PostType string `json:"post_type" binding:"required,oneof=media embed_link"`
+	WallID string `json:"wall_id" binding:"required,uuid"`
+	// Text posts carry no media, only a caption, albums list theirs in Media
+	// and drawings get a preview rendered from their strokes
+	MediaURL string  `json:"media_url" binding:"required_if=PostType media,required_if=PostType embed_link"`
+	PostType string  `json:"post_type" binding:"required,oneof=media embed_link text album drawing"`
+	Caption  *string `json:"caption"`
+	// Album items in display order, each one the author's own upload
+	Media []albumMediaRequest `json:"media" binding:"omitempty,max=10,dive"`
+	// A drawing's canvas and strokes, validated by util/drawing
+	Drawing json.RawMessage `json:"drawing"`
+	// Optional canvas placement, defaults to the origin at normal scale
+	PosX     *float64 `json:"pos_x"`
+	PosY     *float64 `json:"pos_y"`
+	Rotation *float64 `json:"rotation" binding:"omitempty,gte=-360,lte=360"`
+	Scale    *float64 `json:"scale" binding:"omitempty,gt=0,lte=10"`
+	ZIndex   *int32   `json:"z_index"`
+	// Optional section, defaults to the wall's default section when it has sections
+	SectionID string `json:"section_id" binding:"omitempty,uuid"`
+	// Optional time to publish the post at, until then only its author sees it
+	PublishAt *time.Time `json:"publish_at"`
+	// Hides the author from everyone but admins, on walls that allow it
+	Anonymous bool `json:"anonymous"`
 }
 
 type postResponse struct {
-	ID            string    `json:"id"`
-	WallID        string    `json:"wall_id"`
-	Author        string    `json:"author"`
-	MediaURL      string    `json:"media_url"`
-	PostType      string    `json:"post_type"`
-	IsHighlighted bool      `json:"is_highlighted"`
-	LikesCount    int32     `json:"likes_count"`
-	IsDeleted     bool      `json:"is_deleted"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID             string           `json:"id"`
+	WallID         string           `json:"wall_id"`
+	Author         string           `json:"author"`
+	MediaURL       string           `json:"media_url"`
+	Caption        string           `json:"caption"`
+	PostType       string           `json:"post_type"`
+	IsHighlighted  bool             `json:"is_highlighted"`
+	LikesCount     int32            `json:"likes_count"`
+	CommentsCount  int32            `json:"comments_count"`
+	ReactionCounts map[string]int32 `json:"reaction_counts"`
+	IsDeleted      bool             `json:"is_deleted"`
+	IsHidden       bool             `json:"is_hidden"`
+	IsAnonymous    bool             `json:"is_anonymous"`
+	CreatedAt      time.Time        `json:"created_at"`
+	PosX           float64          `json:"pos_x"`
+	PosY           float64          `json:"pos_y"`
+	Rotation       float64          `json:"rotation"`
+	Scale          float64          `json:"scale"`
+	ZIndex         int32            `json:"z_index"`
+	LayoutVersion  int32            `json:"layout_version"`
+	Status         string           `json:"status"`
+	SectionID      string           `json:"section_id,omitempty"`
+	EditedAt       *time.Time       `json:"edited_at,omitempty"`
+	PublishAt      *time.Time       `json:"publish_at,omitempty"`
+	// LinkPreview is only set on embed links whose metadata could be fetched
+	LinkPreview *linkPreviewResponse `json:"link_preview,omitempty"`
+	Mentions    []mentionResponse    `json:"mentions,omitempty"`
+	// Media lists an album's items in order, the first one is also its media_url
+	Media []postMediaResponse `json:"media,omitempty"`
 }
 
 type updatePostRequest struct {
 	MediaURL *string `json:"media_url"`
-	PostType *string `json:"post_type" binding:"omitempty,oneof=media embed_link"`
+	PostType *string `json:"post_type" binding:"omitempty,oneof=media embed_link text"`
+	Caption  *string `json:"caption"`
+}
+
+var (
+	errTextPostMedia   = errors.New("text posts cannot have media")
+	errTextPostCaption = errors.New("text posts need a caption")
+	errTextPostType    = errors.New("post type cannot be changed to or from text")
+)
+
+// postCaption sanitizes a caption from a request, leaving it NULL when none was sent
+func postCaption(caption *string) (pgtype.Text, error) {
+	if caption == nil {
+		return pgtype.Text{}, nil
+	}
+	sanitized, err := util.SanitizeCaption(*caption)
+	if err != nil {
+		return pgtype.Text{}, err
+	}
+	return pgtype.Text{String: sanitized, Valid: true}, nil
 }
 
 // Convert DB post to API response
 func newPostResponse(post db.Post) postResponse {
-	return postResponse{
-		ID:            post.ID.String(),
-		WallID:        post.WallID.String(),
-		Author:        post.Author.String(),
-		MediaURL:      post.MediaUrl.String,
-		PostType:      string(post.PostType.PostType),
-		IsHighlighted: post.IsHighlighted.Bool,
-		LikesCount:    post.LikesCount.Int32,
-		IsDeleted:     post.IsDeleted.Bool,
-		CreatedAt:     post.CreatedAt.Time,
+	response := postResponse{
+		ID:             post.ID.String(),
+		WallID:         post.WallID.String(),
+		Author:         post.Author.String(),
+		MediaURL:       post.MediaUrl.String,
+		Caption:        post.Caption.String,
+		PostType:       string(post.PostType.PostType),
+		IsHighlighted:  post.IsHighlighted.Bool,
+		LikesCount:     post.LikesCount.Int32,
+		CommentsCount:  post.CommentsCount,
+		ReactionCounts: reactionCounts(post.ReactionCounts),
+		IsDeleted:      post.IsDeleted.Bool,
+		IsHidden:       post.IsHidden,
+		IsAnonymous:    post.IsAnonymous,
+		CreatedAt:      post.CreatedAt.Time,
+		PosX:           post.PosX,
+		PosY:           post.PosY,
+		Rotation:       post.Rotation,
+		Scale:          post.Scale,
+		ZIndex:         post.ZIndex,
+		LayoutVersion:  post.LayoutVersion,
+		Status:         string(post.Status),
+		SectionID:      optionalUUID(post.SectionID),
+		EditedAt:       optionalTime(post.EditedAt),
+		PublishAt:      optionalTime(post.PublishAt),
+	}
+	// Only the author and admins know who wrote an anonymous post
+	if post.IsAnonymous {
+		response.Author = ""
 	}
+	return response
 }
 
 type PostResponseWithAuthor struct {
-	ID             string      `json:"id"`
-	WallID         string      `json:"wall_id"`
-	MediaURL       string      `json:"media_url"`
-	PostType       string      `json:"post_type"`
-	IsHighlighted  bool        `json:"is_highlighted"`
-	LikesCount     int32       `json:"likes_count"`
-	IsDeleted      bool        `json:"is_deleted"`
-	CreatedAt      time.Time   `json:"created_at"`
-	Username       string      `json:"username"`
-	ProfilePicture pgtype.Text `json:"profile_picture"`
-	Fullname       pgtype.Text `json:"fullname"`
+	ID             string               `json:"id"`
+	WallID         string               `json:"wall_id"`
+	MediaURL       string               `json:"media_url"`
+	Caption        string               `json:"caption"`
+	PostType       string               `json:"post_type"`
+	IsHighlighted  bool                 `json:"is_highlighted"`
+	LikesCount     int32                `json:"likes_count"`
+	CommentsCount  int32                `json:"comments_count"`
+	ReactionCounts map[string]int32     `json:"reaction_counts"`
+	IsDeleted      bool                 `json:"is_deleted"`
+	IsHidden       bool                 `json:"is_hidden"`
+	IsAnonymous    bool                 `json:"is_anonymous"`
+	CreatedAt      time.Time            `json:"created_at"`
+	PosX           float64              `json:"pos_x"`
+	PosY           float64              `json:"pos_y"`
+	Rotation       float64              `json:"rotation"`
+	Scale          float64              `json:"scale"`
+	ZIndex         int32                `json:"z_index"`
+	LayoutVersion  int32                `json:"layout_version"`
+	Status         string               `json:"status"`
+	SectionID      string               `json:"section_id,omitempty"`
+	EditedAt       *time.Time           `json:"edited_at,omitempty"`
+	PublishAt      *time.Time           `json:"publish_at,omitempty"`
+	Username       string               `json:"username"`
+	ProfilePicture pgtype.Text          `json:"profile_picture"`
+	Fullname       pgtype.Text          `json:"fullname"`
+	LinkPreview    *linkPreviewResponse `json:"link_preview,omitempty"`
+	Mentions       []mentionResponse    `json:"mentions,omitempty"`
+	Media          []postMediaResponse  `json:"media,omitempty"`
 }
 
 func newPostResponseWithAuthor(post db.ListPostsByWallWithAuthorsDetailsRow) PostResponseWithAuthor {
@@ -73,11 +170,26 @@ func newPostResponseWithAuthor(post db.ListPostsByWallWithAuthorsDetailsRow) Pos
 		ID:             post.ID.String(),
 		WallID:         post.WallID.String(),
 		MediaURL:       post.MediaUrl.String,
+		Caption:        post.Caption.String,
 		PostType:       string(post.PostType.PostType),
 		IsHighlighted:  post.IsHighlighted.Bool,
 		LikesCount:     post.LikesCount.Int32,
+		CommentsCount:  post.CommentsCount,
+		ReactionCounts: reactionCounts(post.ReactionCounts),
 		IsDeleted:      post.IsDeleted.Bool,
+		IsHidden:       post.IsHidden,
+		IsAnonymous:    post.IsAnonymous,
 		CreatedAt:      post.CreatedAt.Time,
+		PosX:           post.PosX,
+		PosY:           post.PosY,
+		Rotation:       post.Rotation,
+		Scale:          post.Scale,
+		ZIndex:         post.ZIndex,
+		LayoutVersion:  post.LayoutVersion,
+		Status:         string(post.Status),
+		SectionID:      optionalUUID(post.SectionID),
+		EditedAt:       optionalTime(post.EditedAt),
+		PublishAt:      optionalTime(post.PublishAt),
 		Username:       post.Username,
 		ProfilePicture: post.ProfilePicture,
 		Fullname:       post.Fullname,
@@ -104,6 +216,64 @@ func (s *Server) createPost(ctx *gin.Context) {
 		return
 	}
 
+	postType := db.PostType(req.PostType)
+	caption, err := postCaption(req.Caption)
+	if err != nil {
+		log.Error("Invalid caption", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	mediaURL := pgtype.Text{String: req.MediaURL, Valid: true}
+	if postType == db.PostTypeText {
+		if req.MediaURL != "" {
+			log.Error("Invalid text post", errTextPostMedia)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errTextPostMedia))
+			return
+		}
+		if caption.String == "" {
+			log.Error("Invalid text post", errTextPostCaption)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errTextPostCaption))
+			return
+		}
+		mediaURL = pgtype.Text{}
+	}
+
+	// An album's first item doubles as its media_url, so it has a cover wherever
+	// only a single media is shown
+	if postType == db.PostTypeAlbum {
+		if req.MediaURL != "" || len(req.Media) < minAlbumItems {
+			log.Error("Invalid album", errAlbumItems)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errAlbumItems))
+			return
+		}
+		mediaURL = pgtype.Text{String: req.Media[0].MediaURL, Valid: true}
+	} else if len(req.Media) > 0 {
+		log.Error("Invalid post media", errAlbumOnlyMedia)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errAlbumOnlyMedia))
+		return
+	}
+
+	if postType == db.PostTypeDrawing {
+		if req.MediaURL != "" || len(req.Drawing) == 0 {
+			log.Error("Invalid drawing", errDrawingRequired)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errDrawingRequired))
+			return
+		}
+	} else if len(req.Drawing) > 0 {
+		log.Error("Invalid post media", errDrawingOnly)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errDrawingOnly))
+		return
+	}
+
+	if req.PublishAt != nil {
+		if err := checkPublishAt(*req.PublishAt, time.Now()); err != nil {
+			log.Error("Invalid publish_at", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
 	var wallID pgtype.UUID
 	if err := wallID.Scan(req.WallID); err != nil {
 		log.Error("Invalid wall_id", err)
@@ -118,32 +288,175 @@ func (s *Server) createPost(ctx *gin.Context) {
 		return
 	}
 
-	postType := db.PostType(req.PostType)
+	if req.Anonymous && !wall.AllowAnonymous {
+		log.Error("Invalid anonymous post", errAnonymousNotAllowed)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errAnonymousNotAllowed))
+		return
+	}
+
+	// Scheduled posts only go through moderation once they're published
+	status := db.PostStatusScheduled
+	if req.PublishAt == nil {
+		status, err = s.newPostStatus(ctx, wall, currentUser)
+		if err != nil {
+			log.Error("Failed to check wall moderators", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	sectionID, err := s.postSection(ctx, wallID, req.SectionID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Section not in wall", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("section does not belong to this wall")))
+			return
+		}
+		log.Error("Failed to get wall section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Media has to be the author's own upload, which the post then claims.
+	// Drawings bring their own media, the preview rendered from their strokes.
+	var uploadKey, previewKey string
+	var albumKeys []string
+	var albumMedia []db.CreatePostMediaParams
+	var drawingArg db.CreateDrawingPostTxParams
+	switch postType {
+	case db.PostTypeMedia:
+		if uploadKey, ok = s.checkPostUpload(ctx, currentUser, req.MediaURL, pgtype.UUID{}); !ok {
+			return
+		}
+	case db.PostTypeAlbum:
+		if albumMedia, albumKeys, ok = s.albumMediaParams(ctx, currentUser, req.Media); !ok {
+			return
+		}
+	case db.PostTypeDrawing:
+		if drawingArg, previewKey, ok = s.drawingParams(ctx, req.Drawing); !ok {
+			return
+		}
+		mediaURL = pgtype.Text{String: s.publicURL(previewKey), Valid: true}
+	}
+
 	arg := db.CreatePostParams{
-		WallID:   wallID,
-		Author:   currentUser.ID,
-		MediaUrl: pgtype.Text{String: req.MediaURL, Valid: true},
-		PostType: db.NullPostType{PostType: postType, Valid: true},
+		WallID:      wallID,
+		Author:      currentUser.ID,
+		MediaUrl:    mediaURL,
+		PostType:    db.NullPostType{PostType: postType, Valid: true},
+		Scale:       1,
+		Status:      status,
+		SectionID:   sectionID,
+		Caption:     caption,
+		IsAnonymous: req.Anonymous,
 	}
 
-	post, err := s.hub.CreatePost(ctx, arg)
+	if req.PosX != nil {
+		arg.PosX = *req.PosX
+	}
+	if req.PosY != nil {
+		arg.PosY = *req.PosY
+	}
+	if req.Rotation != nil {
+		arg.Rotation = *req.Rotation
+	}
+	if req.Scale != nil {
+		arg.Scale = *req.Scale
+	}
+	if req.ZIndex != nil {
+		arg.ZIndex = *req.ZIndex
+	}
+	if req.PublishAt != nil {
+		arg.PublishAt = pgtype.Timestamp{Time: req.PublishAt.Local(), Valid: true}
+	}
+
+	var post db.Post
+	var media []postMediaResponse
+	switch postType {
+	case db.PostTypeAlbum:
+		var result db.CreateAlbumPostTxResult
+		result, err = s.hub.CreateAlbumPostTx(ctx, db.CreateAlbumPostTxParams{
+			Post:       arg,
+			Media:      albumMedia,
+			UploadKeys: albumKeys,
+		})
+		post, media = result.Post, newPostMediaResponses(result.Media)
+	case db.PostTypeDrawing:
+		drawingArg.Post = arg
+		post, err = s.hub.CreateDrawingPostTx(ctx, drawingArg)
+	default:
+		post, err = s.hub.CreatePostTx(ctx, arg, uploadKey)
+	}
 	if err != nil {
+		// Nothing else will ever point at the preview of a drawing that wasn't posted
+		if previewKey != "" {
+			if err := s.DeleteFile(ctx, previewKey); err != nil {
+				log.Error("Failed to delete drawing preview", err)
+			}
+		}
+		if errors.Is(err, db.ErrUploadClaimed) {
+			log.Error("Upload already used", err)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		log.Error("Failed to create post", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Send notification if someone posts on another user's wall
-	if wall.UserID.Bytes != currentUser.ID.Bytes {
-		err = s.SendNotification(
+	s.notifyNewPost(ctx, wall, currentUser, post)
+
+	response := newPostResponseFor(post, currentUser.ID)
+	response.LinkPreview = s.unfurlLink(ctx, post)
+	response.Media = media
+
+	mentions, added, err := s.saveMentions(ctx, currentUser.ID, mentionTarget{PostID: post.ID}, "", post.Caption.String)
+	if err != nil {
+		log.Error("Failed to save post mentions", err)
+	} else {
+		response.Mentions = mentions
+		// Mentions on a pending or scheduled post are sent once it goes live
+		if post.Status == db.PostStatusApproved {
+			s.notifyMentions(ctx, post.ID, currentUser, added, false, post.IsAnonymous)
+		}
+	}
+
+	log.Info("Post created successfully")
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// notifyNewPost tells the wall owner about a new post, or one waiting in the
+// moderation queue, and lets the wall's followers know. Scheduled posts are
+// announced once they're published instead.
+func (s *Server) notifyNewPost(ctx context.Context, wall db.Wall, author db.User, post db.Post) {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	switch {
+	case post.Status == db.PostStatusScheduled:
+		return
+	case post.Status == db.PostStatusPending:
+		// Let the owner know there is something waiting in the moderation queue
+		err := s.SendNotification(
 			ctx,
-			wall.UserID.String(),           // recipient (wall owner)
-			currentUser.ID.String(),        // sender (post author)
-			"wall_post",                    // notification type
-			wallID.String(),               // entity ID (post ID)
-			fmt.Sprintf("%s posted on your wall", currentUser.Username), // message
+			wall.UserID.String(),
+			postNotificationSender(post, wall.UserID.String()),
+			"wall_post_pending",
+			wall.ID.String(),
+			fmt.Sprintf("%s submitted a post for review on your wall", postAuthorName(post, author)),
+		)
+		if err != nil {
+			log.Error("Failed to send pending post notification", err)
+		}
+	case wall.UserID != author.ID:
+		// Send notification if someone posts on another user's wall
+		err := s.SendNotification(
+			ctx,
+			wall.UserID.String(), // recipient (wall owner)
+			postNotificationSender(post, wall.UserID.String()), // sender (post author, unless anonymous)
+			"wall_post",      // notification type
+			wall.ID.String(), // entity ID (wall ID)
+			fmt.Sprintf("%s posted on your wall", postAuthorName(post, author)), // message
 		)
-
 		if err != nil {
 			log.Error("Failed to send wall post notification", err)
 		} else {
@@ -151,9 +464,9 @@ func (s *Server) createPost(ctx *gin.Context) {
 		}
 	}
 
-	log.Info("Post created successfully")
-	response := newPostResponse(post)
-	ctx.JSON(http.StatusCreated, response)
+	if err := s.notifyWallFollowers(ctx, wall, post); err != nil {
+		log.Error("Failed to notify wall followers", err)
+	}
 }
 
 // GetPost handler
@@ -186,8 +499,20 @@ func (s *Server) getPost(ctx *gin.Context) {
 		return
 	}
 
+	// Posts that haven't been approved are only visible through the moderation
+	// queue, and hidden ones only to moderators reviewing reports
+	if post.Status != db.PostStatusApproved || post.IsHidden {
+		ctx.JSON(http.StatusNotFound, errorResponse(db.ErrRecordNotFound))
+		return
+	}
+
 	log.Info("Post retrieved successfully")
 	response := newPostResponse(post)
+	if url := embedLinkURL(post.PostType, post.MediaUrl); url != "" {
+		response.LinkPreview = s.linkPreviews(ctx, []string{url})[url]
+	}
+	response.Mentions = s.postMentions(ctx, map[pgtype.UUID]string{post.ID: post.Caption.String})[post.ID]
+	response.Media = s.albumMedia(ctx, map[pgtype.UUID]db.NullPostType{post.ID: post.PostType})[post.ID]
 	ctx.JSON(http.StatusOK, response)
 }
 
@@ -244,9 +569,32 @@ func (s *Server) listPostsByWall(ctx *gin.Context) {
 	}
 
 	log.Info("Posts by wall listed successfully")
+	var urls []string
+	captions := make(map[pgtype.UUID]string, len(posts))
+	postTypes := make(map[pgtype.UUID]db.NullPostType, len(posts))
+	for _, post := range posts {
+		if url := embedLinkURL(post.PostType, post.MediaUrl); url != "" {
+			urls = append(urls, url)
+		}
+		if post.Status == db.PostStatusApproved {
+			captions[post.ID] = post.Caption.String
+			postTypes[post.ID] = post.PostType
+		}
+	}
+	previews := s.linkPreviews(ctx, urls)
+	mentions := s.postMentions(ctx, captions)
+	media := s.albumMedia(ctx, postTypes)
+
 	responses := make([]postResponse, 0, len(posts))
 	for _, post := range posts {
-		responses = append(responses, newPostResponse(post))
+		if post.Status != db.PostStatusApproved {
+			continue
+		}
+		response := newPostResponse(post)
+		response.LinkPreview = previews[embedLinkURL(post.PostType, post.MediaUrl)]
+		response.Mentions = mentions[post.ID]
+		response.Media = media[post.ID]
+		responses = append(responses, response)
 	}
 
 	ctx.JSON(http.StatusOK, responses)
@@ -262,13 +610,22 @@ func (s *Server) listPostsByWallWithAuthorsDetails(ctx *gin.Context) {
 		return
 	}
 
-	_, ok := ctx.MustGet("currentUser").(db.User)
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
 	if !ok {
 		log.Error("Failed to get current user from context")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
 		return
 	}
 
+	var req struct {
+		SectionID string `form:"section_id" binding:"omitempty,uuid"`
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	var wallID pgtype.UUID
 	err := wallID.Scan(uri.WallID)
 	if err != nil {
@@ -276,15 +633,49 @@ func (s *Server) listPostsByWallWithAuthorsDetails(ctx *gin.Context) {
 		return
 	}
 
-	posts, err := s.hub.ListPostsByWallWithAuthorsDetails(ctx, wallID)
+	// An empty section ID lists the whole wall
+	var sectionID pgtype.UUID
+	if req.SectionID != "" {
+		if err := sectionID.Scan(req.SectionID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	// Authors still see their own posts while they wait for review
+	posts, err := s.hub.ListPostsByWallWithAuthorsDetails(ctx, db.ListPostsByWallWithAuthorsDetailsParams{
+		WallID:    wallID,
+		Author:    currentUser.ID,
+		SectionID: sectionID,
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	s.views.Record(wallID, currentUser.ID)
+
+	var urls []string
+	captions := make(map[pgtype.UUID]string, len(posts))
+	postTypes := make(map[pgtype.UUID]db.NullPostType, len(posts))
+	for _, post := range posts {
+		if url := embedLinkURL(post.PostType, post.MediaUrl); url != "" {
+			urls = append(urls, url)
+		}
+		captions[post.ID] = post.Caption.String
+		postTypes[post.ID] = post.PostType
+	}
+	previews := s.linkPreviews(ctx, urls)
+	mentions := s.postMentions(ctx, captions)
+	media := s.albumMedia(ctx, postTypes)
+
 	responses := make([]PostResponseWithAuthor, 0, len(posts))
 	for _, post := range posts {
-		responses = append(responses, newPostResponseWithAuthor(post))
+		response := newPostResponseWithAuthor(hideAnonymousAuthor(post, currentUser.ID))
+		response.LinkPreview = previews[embedLinkURL(post.PostType, post.MediaUrl)]
+		response.Mentions = mentions[post.ID]
+		response.Media = media[post.ID]
+		responses = append(responses, response)
 	}
 	ctx.JSON(http.StatusOK, responses)
 }
@@ -356,6 +747,13 @@ func (s *Server) updatePost(ctx *gin.Context) {
 	log := meta.GetLogger()
 	log.Info("Received update post request")
 
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
 	var uri struct {
 		ID string `uri:"id" binding:"required,uuid"`
 	}
@@ -381,15 +779,40 @@ func (s *Server) updatePost(ctx *gin.Context) {
 
 	currentPost, err := s.hub.GetPost(ctx, id)
 	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		log.Error("Failed to get current post", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	if currentPost.Author != currentUser.ID {
+		log.Error("Unauthorized to update post", errors.New("user not authorized to update this post"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to update this post")))
+		return
+	}
+
+	if currentPost.IsDeleted.Bool {
+		log.Error("Cannot update deleted post", errors.New("restore the post before editing it"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("restore the post before editing it")))
+		return
+	}
+
+	caption, err := postCaption(req.Caption)
+	if err != nil {
+		log.Error("Invalid caption", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.UpdatePostParams{
 		ID:       id,
 		MediaUrl: currentPost.MediaUrl,
 		PostType: currentPost.PostType,
+		Caption:  caption,
 	}
 
 	if req.MediaURL != nil {
@@ -400,15 +823,81 @@ func (s *Server) updatePost(ctx *gin.Context) {
 		arg.PostType = db.NullPostType{PostType: postType, Valid: true}
 	}
 
-	post, err := s.hub.UpdatePost(ctx, arg)
+	// Only an album's caption can be edited, its items stay as they were posted
+	isAlbum := currentPost.PostType.PostType == db.PostTypeAlbum
+	if isAlbum && (req.MediaURL != nil || req.PostType != nil) {
+		log.Error("Invalid album edit", errAlbumEdit)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errAlbumEdit))
+		return
+	}
+
+	// The same goes for drawings, whose preview has to match their strokes
+	isDrawing := currentPost.PostType.PostType == db.PostTypeDrawing
+	if isDrawing && (req.MediaURL != nil || req.PostType != nil) {
+		log.Error("Invalid drawing edit", errDrawingEdit)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errDrawingEdit))
+		return
+	}
+
+	// A text post stays a text post, and always keeps a caption
+	isText := currentPost.PostType.PostType == db.PostTypeText
+	if (arg.PostType.PostType == db.PostTypeText) != isText {
+		log.Error("Invalid post type change", errTextPostType)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTextPostType))
+		return
+	}
+	if isText && req.MediaURL != nil {
+		log.Error("Invalid text post", errTextPostMedia)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTextPostMedia))
+		return
+	}
+	if isText && caption.Valid && caption.String == "" {
+		log.Error("Invalid text post", errTextPostCaption)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTextPostCaption))
+		return
+	}
+
+	// New media has to be the author's own upload, just like when posting
+	var uploadKey string
+	if arg.PostType.PostType == db.PostTypeMedia && (arg.MediaUrl != currentPost.MediaUrl || currentPost.PostType.PostType != db.PostTypeMedia) {
+		if uploadKey, ok = s.checkPostUpload(ctx, currentUser, arg.MediaUrl.String, currentPost.ID); !ok {
+			return
+		}
+	}
+
+	// The previous version is kept as a revision, along with its media
+	post, err := s.hub.UpdatePostTx(ctx, arg, currentUser.ID, uploadKey)
 	if err != nil {
+		if errors.Is(err, db.ErrUploadClaimed) {
+			log.Error("Upload already used", err)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		log.Error("Failed to update post", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	log.Info("Post updated successfully")
-	response := newPostResponse(post)
+	response := newPostResponseFor(post, currentUser.ID)
+	response.LinkPreview = s.unfurlLink(ctx, post)
+	response.Media = s.albumMedia(ctx, map[pgtype.UUID]db.NullPostType{post.ID: post.PostType})[post.ID]
+
+	if caption.Valid {
+		mentions, added, err := s.saveMentions(ctx, currentUser.ID, mentionTarget{PostID: post.ID}, currentPost.Caption.String, post.Caption.String)
+		if err != nil {
+			log.Error("Failed to save post mentions", err)
+		} else {
+			response.Mentions = mentions
+			// Posts waiting for review notify everyone mentioned once approved
+			if post.Status == db.PostStatusApproved {
+				s.notifyMentions(ctx, post.ID, currentUser, added, false, post.IsAnonymous)
+			}
+		}
+	} else {
+		response.Mentions = s.postMentions(ctx, map[pgtype.UUID]string{post.ID: post.Caption.String})[post.ID]
+	}
+
 	ctx.JSON(http.StatusOK, response)
 }
 
@@ -531,25 +1020,13 @@ func (s *Server) deletePost(ctx *gin.Context) {
 		return
 	}
 
+	// Media stays in S3 until the purge job runs, so the post can still be restored
 	if err := s.hub.DeletePost(ctx, id); err != nil {
 		log.Error("Failed to delete post", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	if post.PostType.Valid && post.PostType.PostType == db.PostTypeMedia && post.MediaUrl.Valid {
-		// Delete media from S3
-		key := util.ExtractKeyFromMediaURL(post.MediaUrl.String)
-		go func(key string) {
-			bgCtx := context.Background()
-
-			if err := s.DeleteFile(bgCtx, key); err != nil {
-				log.Error("Failed to delete media from S3", err)
-			}
-		}(key)
-
-	}
-
 	log.Info("Post deleted successfully")
 	ctx.JSON(http.StatusOK, gin.H{"message": "Post deleted successfully"})
 }
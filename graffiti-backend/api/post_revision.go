@@ -0,0 +1,159 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// postRevisionResponse is how a post looked before the edit made by the editor
+type postRevisionResponse struct {
+	ID                   string    `json:"id"`
+	PostID               string    `json:"post_id"`
+	EditorID             string    `json:"editor_id,omitempty"`
+	EditorUsername       string    `json:"editor_username,omitempty"`
+	EditorFullname       string    `json:"editor_fullname,omitempty"`
+	EditorProfilePicture string    `json:"editor_profile_picture,omitempty"`
+	MediaURL             string    `json:"media_url"`
+	PostType             string    `json:"post_type"`
+	Caption              string    `json:"caption"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+type postRevisionsResponse struct {
+	Page      int32                  `json:"page"`
+	PageSize  int32                  `json:"page_size"`
+	HasMore   bool                   `json:"has_more"`
+	Revisions []postRevisionResponse `json:"revisions"`
+}
+
+func newPostRevisionResponse(revision db.ListPostRevisionsRow) postRevisionResponse {
+	return postRevisionResponse{
+		ID:                   revision.ID.String(),
+		PostID:               revision.PostID.String(),
+		EditorID:             optionalUUID(revision.EditorID),
+		EditorUsername:       revision.EditorUsername.String,
+		EditorFullname:       revision.EditorFullname.String,
+		EditorProfilePicture: revision.EditorProfilePicture.String,
+		MediaURL:             revision.MediaUrl.String,
+		PostType:             string(revision.PostType.PostType),
+		Caption:              revision.Caption.String,
+		CreatedAt:            revision.CreatedAt.Time,
+	}
+}
+
+// optionalTime returns nil for an unset timestamp
+func optionalTime(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Time
+}
+
+// ListPostRevisions handler returns a post's edit history, newest first.
+// Only the wall owner and the post's author can see previous versions.
+func (s *Server) listPostRevisions(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list post revisions request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req paginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if post.Author != currentUser.ID {
+		wall, err := s.hub.GetWall(ctx, post.WallID)
+		if err != nil {
+			log.Error("Failed to get wall", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if wall.UserID != currentUser.ID {
+			log.Error("Unauthorized to view post history", errors.New("user not authorized to view the history of this post"))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to view the history of this post")))
+			return
+		}
+	}
+
+	// Fetch one extra revision to know whether there is another page
+	revisions, err := s.hub.ListPostRevisions(ctx, db.ListPostRevisionsParams{
+		PostID: id,
+		Limit:  req.PageSize + 1,
+		Offset: req.offset(),
+	})
+	if err != nil {
+		log.Error("Failed to list post revisions", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := postRevisionsResponse{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		HasMore:   len(revisions) > int(req.PageSize),
+		Revisions: make([]postRevisionResponse, 0, len(revisions)),
+	}
+	if rsp.HasMore {
+		revisions = revisions[:req.PageSize]
+	}
+	for _, revision := range revisions {
+		response := newPostRevisionResponse(revision)
+		// Edits to an anonymous post don't give its author away to the wall owner
+		if post.IsAnonymous && revision.EditorID == post.Author && post.Author != currentUser.ID {
+			response.EditorID = ""
+			response.EditorUsername = anonymousUsername
+			response.EditorFullname = ""
+			response.EditorProfilePicture = ""
+		}
+		rsp.Revisions = append(rsp.Revisions, response)
+	}
+
+	log.Info("Post revisions listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
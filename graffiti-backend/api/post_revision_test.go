@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestListPostRevisionsAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	post := randomPost(t, wall.ID, author.ID)
+
+	revision := db.ListPostRevisionsRow{
+		ID:             randomWall(t, owner.ID).ID,
+		PostID:         post.ID,
+		EditorID:       author.ID,
+		EditorUsername: pgtype.Text{String: author.Username, Valid: true},
+		MediaUrl:       pgtype.Text{String: "https://cdn.example.com/uploads/old.jpg", Valid: true},
+		PostType:       db.NullPostType{PostType: db.PostTypeMedia, Valid: true},
+		Caption:        pgtype.Text{String: "Old caption", Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_WallOwner",
+			currentUser: owner,
+			query:       "?page_size=1",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListPostRevisions(gomock.Any(), db.ListPostRevisionsParams{
+						PostID: post.ID,
+						Limit:  2,
+						Offset: 0,
+					}).
+					Times(1).
+					Return([]db.ListPostRevisionsRow{revision, revision}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp postRevisionsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.True(t, rsp.HasMore)
+				require.Len(t, rsp.Revisions, 1)
+				require.Equal(t, "Old caption", rsp.Revisions[0].Caption)
+				require.Equal(t, revision.MediaUrl.String, rsp.Revisions[0].MediaURL)
+				require.Equal(t, author.ID.String(), rsp.Revisions[0].EditorID)
+				require.Equal(t, author.Username, rsp.Revisions[0].EditorUsername)
+			},
+		},
+		{
+			name:        "OK_Author",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					ListPostRevisions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPostRevisionsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp postRevisionsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.False(t, rsp.HasMore)
+				require.Empty(t, rsp.Revisions)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListPostRevisions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(db.Post{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/posts/:id/revisions", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.listPostRevisions(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/posts/%s/revisions%s", post.ID.String(), tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
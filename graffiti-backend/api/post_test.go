@@ -26,6 +26,8 @@ func TestCreatePostAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	wall := randomWall(t, user.ID)
 	post := randomPost(t, wall.ID, user.ID)
+	post.MediaUrl = pgtype.Text{String: randomUploadURL(), Valid: true}
+	section := db.WallSection{ID: randomWall(t, user.ID).ID, WallID: wall.ID, Name: "Wishes"}
 
 	const validPostType = "media"
 
@@ -46,22 +48,90 @@ func TestCreatePostAPI(t *testing.T) {
 				mockHub.EXPECT().
 					GetWall(gomock.Any(), wall.ID).
 					Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
 
 				mockHub.EXPECT().
-					CreatePost(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(_ interface{}, params db.CreatePostParams) (db.Post, error) {
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, params db.CreatePostParams, _ string) (db.Post, error) {
 						require.Equal(t, wall.ID.String(), params.WallID.String())
 						require.Equal(t, user.ID.String(), params.Author.String())
 						require.Equal(t, post.MediaUrl.String, params.MediaUrl.String)
 						require.Equal(t, db.PostType(validPostType), params.PostType.PostType)
+						require.Equal(t, float64(1), params.Scale)
 						return post, nil
 					})
+
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), db.ListWallFollowersToNotifyParams{WallID: wall.ID, UserID: user.ID}).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
 				requireBodyMatchPostResponse(t, recorder.Body, post)
 			},
 		},
+		{
+			name: "OK_WithLayout",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": validPostType,
+				"pos_x":     120.5,
+				"pos_y":     -40,
+				"rotation":  15,
+				"scale":     1.5,
+				"z_index":   3,
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, params db.CreatePostParams, _ string) (db.Post, error) {
+						require.Equal(t, 120.5, params.PosX)
+						require.Equal(t, float64(-40), params.PosY)
+						require.Equal(t, float64(15), params.Rotation)
+						require.Equal(t, 1.5, params.Scale)
+						require.Equal(t, int32(3), params.ZIndex)
+						return post, nil
+					})
+
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_InvalidScale",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": validPostType,
+				"scale":     -1,
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "BadRequest_MissingRequired",
 			body: gin.H{
@@ -69,7 +139,7 @@ func TestCreatePostAPI(t *testing.T) {
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					CreatePost(gomock.Any(), gomock.Any()).
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -85,7 +155,7 @@ func TestCreatePostAPI(t *testing.T) {
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					CreatePost(gomock.Any(), gomock.Any()).
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -101,7 +171,174 @@ func TestCreatePostAPI(t *testing.T) {
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					CreatePost(gomock.Any(), gomock.Any()).
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OK_Caption",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": validPostType,
+				"caption":   "  <b>Happy</b> birthday <3 ",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, params db.CreatePostParams, _ string) (db.Post, error) {
+						require.Equal(t, pgtype.Text{String: "Happy birthday <3", Valid: true}, params.Caption)
+						return post, nil
+					})
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "OK_TextPost",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"post_type": "text",
+				"caption":   "Congrats on the new job!",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, params db.CreatePostParams, _ string) (db.Post, error) {
+						require.Equal(t, db.PostTypeText, params.PostType.PostType)
+						require.False(t, params.MediaUrl.Valid)
+						require.Equal(t, "Congrats on the new job!", params.Caption.String)
+						return post, nil
+					})
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_TextPostWithMedia",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": "text",
+				"caption":   "Congrats!",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_TextPostWithoutCaption",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"post_type": "text",
+				"caption":   "<p> </p>",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_CaptionTooLong",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": validPostType,
+				"caption":   util.RandomString(util.MaxCaptionLength + 1),
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OK_DefaultSection",
+			body: gin.H{
+				"wall_id":   wall.ID,
+				"media_url": post.MediaUrl.String,
+				"post_type": validPostType,
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Times(1).
+					Return(section, nil)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, params db.CreatePostParams, _ string) (db.Post, error) {
+						require.Equal(t, section.ID, params.SectionID)
+						return post, nil
+					})
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_SectionNotInWall",
+			body: gin.H{
+				"wall_id":    wall.ID,
+				"media_url":  post.MediaUrl.String,
+				"post_type":  validPostType,
+				"section_id": section.ID.String(),
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					GetWallSection(gomock.Any(), db.GetWallSectionParams{ID: section.ID, WallID: wall.ID}).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -120,7 +357,11 @@ func TestCreatePostAPI(t *testing.T) {
 					GetWall(gomock.Any(), wall.ID).
 					Return(wall, nil)
 				mockHub.EXPECT().
-					CreatePost(gomock.Any(), gomock.Any()).
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.Post{}, sql.ErrConnDone)
 			},
@@ -139,6 +380,7 @@ func TestCreatePostAPI(t *testing.T) {
 			require.True(t, ok)
 
 			tc.setupMock(mockHub)
+			expectPostUpload(server, mockHub, user.ID)
 
 			server.router.POST("/test/posts", func(ctx *gin.Context) {
 				ctx.Set("currentUser", user)
@@ -422,9 +664,12 @@ func TestListPostsByWallWithAuthorsDetailsAPI(t *testing.T) {
 		}
 	}
 
+	sectionID := randomWall(t, user.ID).ID
+
 	testCases := []struct {
 		name          string
 		wallID        string
+		query         string
 		setupMock     func(mockHub *mockdb.MockHub)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
@@ -435,8 +680,13 @@ func TestListPostsByWallWithAuthorsDetailsAPI(t *testing.T) {
 				var id pgtype.UUID
 				id.Scan(wall.ID.String())
 
+				arg := db.ListPostsByWallWithAuthorsDetailsParams{
+					WallID: id,
+					Author: user.ID,
+				}
+
 				mockHub.EXPECT().
-					ListPostsByWallWithAuthorsDetails(gomock.Any(), gomock.Eq(id)).
+					ListPostsByWallWithAuthorsDetails(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(postsWithAuthors, nil)
 			},
@@ -452,6 +702,37 @@ func TestListPostsByWallWithAuthorsDetailsAPI(t *testing.T) {
 				require.Len(t, gotResponse, n)
 			},
 		},
+		{
+			name:   "OK_BySection",
+			wallID: wall.ID.String(),
+			query:  "?section_id=" + sectionID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPostsByWallWithAuthorsDetails(gomock.Any(), db.ListPostsByWallWithAuthorsDetailsParams{
+						WallID:    wall.ID,
+						Author:    user.ID,
+						SectionID: sectionID,
+					}).
+					Times(1).
+					Return(postsWithAuthors[:1], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "BadRequest_InvalidSectionID",
+			wallID: wall.ID.String(),
+			query:  "?section_id=invalid",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPostsByWallWithAuthorsDetails(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:   "InvalidID",
 			wallID: "invalid-id",
@@ -496,7 +777,7 @@ func TestListPostsByWallWithAuthorsDetailsAPI(t *testing.T) {
 
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/test/walls/%s/posts", tc.wallID)
+			url := fmt.Sprintf("/test/walls/%s/posts%s", tc.wallID, tc.query)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -660,23 +941,32 @@ func TestGetHighlightedPostsByWallAPI(t *testing.T) {
 // TestUpdatePostAPI tests the updatePost handler
 func TestUpdatePostAPI(t *testing.T) {
 	user, _ := randomUser(t)
+	other, _ := randomUser(t)
 	wall := randomWall(t, user.ID)
 	post := randomPost(t, wall.ID, user.ID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeMedia, Valid: true}
 
 	updatedPost := post
-	newMediaURL := "https://updated-url.com/image.jpg"
+	newMediaURL := randomUploadURL()
+	newMediaKey := util.ExtractKeyFromMediaURL(newMediaURL)
 	updatedPost.MediaUrl.String = newMediaURL
+	updatedPost.EditedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	deletedPost := post
+	deletedPost.IsDeleted = pgtype.Bool{Bool: true, Valid: true}
 
 	testCases := []struct {
 		name          string
 		postID        string
+		currentUser   db.User
 		body          gin.H
 		setupMock     func(mockHub *mockdb.MockHub)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:   "OK",
-			postID: post.ID.String(),
+			name:        "OK",
+			postID:      post.ID.String(),
+			currentUser: user,
 			body: gin.H{
 				"media_url": newMediaURL,
 			},
@@ -687,8 +977,8 @@ func TestUpdatePostAPI(t *testing.T) {
 					Return(post, nil)
 
 				mockHub.EXPECT().
-					UpdatePost(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(_ interface{}, params db.UpdatePostParams) (db.Post, error) {
+					UpdatePostTx(gomock.Any(), gomock.Any(), user.ID, newMediaKey).
+					DoAndReturn(func(_ interface{}, params db.UpdatePostParams, _ pgtype.UUID, _ string) (db.Post, error) {
 						require.Equal(t, post.ID.String(), params.ID.String())
 						require.Equal(t, newMediaURL, params.MediaUrl.String)
 						return updatedPost, nil
@@ -700,17 +990,39 @@ func TestUpdatePostAPI(t *testing.T) {
 			},
 		},
 		{
-			name:   "InvalidID",
-			postID: "invalid-id",
+			name:        "Unauthorized_NotAuthor",
+			postID:      post.ID.String(),
+			currentUser: other,
 			body: gin.H{
 				"media_url": newMediaURL,
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
 					GetPost(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_Deleted",
+			postID:      post.ID.String(),
+			currentUser: user,
+			body: gin.H{
+				"media_url": newMediaURL,
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					UpdatePost(gomock.Any(), gomock.Any()).
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(deletedPost, nil)
+				mockHub.EXPECT().
+					UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -718,8 +1030,73 @@ func TestUpdatePostAPI(t *testing.T) {
 			},
 		},
 		{
-			name:   "PostNotFound",
-			postID: uuid.New().String(),
+			name:        "OK_Caption",
+			postID:      post.ID.String(),
+			currentUser: user,
+			body: gin.H{
+				"caption": "<i>New</i> caption",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(post, nil)
+
+				mockHub.EXPECT().
+					UpdatePostTx(gomock.Any(), gomock.Any(), user.ID, "").
+					DoAndReturn(func(_ interface{}, params db.UpdatePostParams, _ pgtype.UUID, _ string) (db.Post, error) {
+						require.Equal(t, pgtype.Text{String: "New caption", Valid: true}, params.Caption)
+						require.Equal(t, post.MediaUrl, params.MediaUrl)
+						return post, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_ChangeToText",
+			postID:      post.ID.String(),
+			currentUser: user,
+			body: gin.H{
+				"post_type": "text",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidID",
+			postID:      "invalid-id",
+			currentUser: user,
+			body: gin.H{
+				"media_url": newMediaURL,
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "PostNotFound",
+			postID:      uuid.New().String(),
+			currentUser: user,
 			body: gin.H{
 				"media_url": newMediaURL,
 			},
@@ -730,16 +1107,17 @@ func TestUpdatePostAPI(t *testing.T) {
 					Return(db.Post{}, db.ErrRecordNotFound)
 
 				mockHub.EXPECT().
-					UpdatePost(gomock.Any(), gomock.Any()).
+					UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name:   "InternalError",
-			postID: post.ID.String(),
+			name:        "InternalError",
+			postID:      post.ID.String(),
+			currentUser: user,
 			body: gin.H{
 				"media_url": newMediaURL,
 			},
@@ -750,7 +1128,7 @@ func TestUpdatePostAPI(t *testing.T) {
 					Return(post, nil)
 
 				mockHub.EXPECT().
-					UpdatePost(gomock.Any(), gomock.Any()).
+					UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.Post{}, sql.ErrConnDone)
 			},
@@ -769,13 +1147,19 @@ func TestUpdatePostAPI(t *testing.T) {
 			require.True(t, ok)
 
 			tc.setupMock(mockHub)
+			expectPostUpload(server, mockHub, user.ID)
+
+			server.router.PUT("/test/posts/:id", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.updatePost(ctx)
+			})
 
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
 			require.NoError(t, err)
 
-			url := fmt.Sprintf("/api/v1/posts/%s", tc.postID)
+			url := fmt.Sprintf("/test/posts/%s", tc.postID)
 			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
 			request.Header.Set("Content-Type", "application/json")
@@ -1198,6 +1582,10 @@ func randomPost(t *testing.T, wallID pgtype.UUID, authorID pgtype.UUID) db.Post
 		LikesCount:    likesCount,
 		IsDeleted:     isDeleted,
 		CreatedAt:     createdAt,
+		Scale:         1,
+		LayoutVersion: 1,
+		Status:        db.PostStatusApproved,
+		Caption:       pgtype.Text{String: util.RandomString(20), Valid: true},
 	}
 }
 
@@ -1213,10 +1601,20 @@ func requireBodyMatchPostResponse(t *testing.T, body *bytes.Buffer, post db.Post
 	require.Equal(t, post.WallID.String(), gotResponse.WallID)
 	require.Equal(t, post.Author.String(), gotResponse.Author)
 	require.Equal(t, post.MediaUrl.String, gotResponse.MediaURL)
+	require.Equal(t, post.Caption.String, gotResponse.Caption)
 	require.Equal(t, string(post.PostType.PostType), gotResponse.PostType)
 	require.Equal(t, post.IsHighlighted.Bool, gotResponse.IsHighlighted)
 	require.Equal(t, post.LikesCount.Int32, gotResponse.LikesCount)
+	require.Equal(t, post.CommentsCount, gotResponse.CommentsCount)
 	require.Equal(t, post.IsDeleted.Bool, gotResponse.IsDeleted)
+	require.Equal(t, post.PosX, gotResponse.PosX)
+	require.Equal(t, post.PosY, gotResponse.PosY)
+	require.Equal(t, post.Rotation, gotResponse.Rotation)
+	require.Equal(t, post.Scale, gotResponse.Scale)
+	require.Equal(t, post.ZIndex, gotResponse.ZIndex)
+	require.Equal(t, post.LayoutVersion, gotResponse.LayoutVersion)
+	require.Equal(t, string(post.Status), gotResponse.Status)
+	require.Equal(t, post.EditedAt.Valid, gotResponse.EditedAt != nil)
 }
 
 func requireBodyMatchPostsResponse(t *testing.T, body *bytes.Buffer, posts []db.Post) {
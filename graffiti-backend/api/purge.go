@@ -0,0 +1,324 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	// defaultPurgeRetentionDays is used when PURGE_RETENTION_DAYS isn't set
+	defaultPurgeRetentionDays = 30
+	// defaultRevisionRetentionDays is used when POST_REVISION_RETENTION_DAYS isn't set
+	defaultRevisionRetentionDays = 90
+	// defaultExportRetentionDays is used when EXPORT_RETENTION_DAYS isn't set
+	defaultExportRetentionDays = 7
+	// purgeBatchSize bounds how many rows a single purge pass loads at once
+	purgeBatchSize = 100
+)
+
+// purgeResult counts what a purge run removed
+type purgeResult struct {
+	Walls int64
+	Posts int64
+	Likes int64
+	Files int
+	// Revisions counts post revisions pruned for their age, not those removed
+	// along with a purged post
+	Revisions int64
+	Exports   int64
+}
+
+// purgeRetention is how long soft-deleted content is kept before it is purged
+func (s *Server) purgeRetention() time.Duration {
+	days := s.config.PurgeRetentionDays
+	if days <= 0 {
+		days = defaultPurgeRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// revisionRetention is how long a post revision is kept before it is pruned
+func (s *Server) revisionRetention() time.Duration {
+	days := s.config.RevisionRetentionDays
+	if days <= 0 {
+		days = defaultRevisionRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// exportRetention is how long a wall export is kept before it is pruned
+func (s *Server) exportRetention() time.Duration {
+	days := s.config.ExportRetentionDays
+	if days <= 0 {
+		days = defaultExportRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// purgeableKey reports whether a media key belongs to a single post or wall.
+// Profile and background keys are per user and may still be in use elsewhere.
+func purgeableKey(key string) bool {
+	return strings.HasPrefix(key, "uploads/")
+}
+
+// purgeDeletedContent permanently removes walls and posts that were soft-deleted
+// longer ago than the retention window. Their likes, revisions and media go
+// with them, including album items and drawing previews. Old wall exports and
+// post revisions are pruned as well. Media is removed before the rows, so a
+// pass that fails halfway is simply picked up again by the next run.
+func (s *Server) purgeDeletedContent(ctx context.Context) (purgeResult, error) {
+	log := logger.Global()
+	cutoff := pgtype.Timestamp{Time: time.Now().Add(-s.purgeRetention()), Valid: true}
+
+	var total purgeResult
+
+	// Exports go first, purging a wall would drop its exports but not their files
+	exports, files, err := s.pruneWallExports(ctx, cutoff)
+	total.Exports += exports
+	total.Files += files
+	if err != nil {
+		return total, err
+	}
+
+	for {
+		posts, err := s.hub.ListPurgeablePosts(ctx, db.ListPurgeablePostsParams{
+			DeletedAt: cutoff,
+			Limit:     purgeBatchSize,
+		})
+		if err != nil {
+			return total, err
+		}
+
+		// Walls are only removed once every post on them fits in this batch
+		var walls []db.Wall
+		if len(posts) < purgeBatchSize {
+			walls, err = s.hub.ListPurgeableWalls(ctx, db.ListPurgeableWallsParams{
+				DeletedAt: cutoff,
+				Limit:     purgeBatchSize,
+			})
+			if err != nil {
+				return total, err
+			}
+		}
+
+		if len(posts) == 0 && len(walls) == 0 {
+			break
+		}
+
+		var keys []string
+		postIDs := make([]pgtype.UUID, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.ID)
+			// A drawing's preview is stored like any other upload
+			isMedia := post.PostType.PostType == db.PostTypeMedia || post.PostType.PostType == db.PostTypeDrawing
+			if isMedia && post.MediaUrl.Valid {
+				if key := util.ExtractKeyFromMediaURL(post.MediaUrl.String); purgeableKey(key) {
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		// Revisions go with their post, so the media they kept goes too
+		if len(postIDs) > 0 {
+			revisionMedia, err := s.hub.ListPostRevisionMediaByPosts(ctx, postIDs)
+			if err != nil {
+				return total, err
+			}
+			keys = appendPurgeableKeys(keys, revisionMedia)
+
+			// Every item of an album is removed, not just the cover
+			albumMedia, err := s.hub.ListPostMediaByPosts(ctx, postIDs)
+			if err != nil {
+				return total, err
+			}
+			albumURLs := make([]string, 0, len(albumMedia))
+			for _, medium := range albumMedia {
+				albumURLs = append(albumURLs, medium.MediaUrl)
+			}
+			keys = appendPurgeableKeys(keys, albumURLs)
+		}
+
+		wallIDs := make([]pgtype.UUID, 0, len(walls))
+		for _, wall := range walls {
+			wallIDs = append(wallIDs, wall.ID)
+			if wall.BackgroundImage.Valid {
+				if key := util.ExtractKeyFromMediaURL(wall.BackgroundImage.String); purgeableKey(key) {
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		if err := s.DeleteFiles(ctx, keys); err != nil {
+			return total, err
+		}
+		total.Files += len(keys)
+
+		result, err := s.hub.PurgeDeletedTx(ctx, wallIDs, postIDs)
+		if err != nil {
+			return total, err
+		}
+		total.Walls += result.Walls
+		total.Posts += result.Posts
+		total.Likes += result.Likes
+
+		if len(posts) < purgeBatchSize && len(walls) < purgeBatchSize {
+			break
+		}
+	}
+
+	revisions, files, err := s.prunePostRevisions(ctx)
+	total.Revisions += revisions
+	total.Files += files
+	if err != nil {
+		return total, err
+	}
+
+	log.Info("Purge removed %d walls, %d posts, %d likes, %d post revisions, %d wall exports and %d media files", total.Walls, total.Posts, total.Likes, total.Revisions, total.Exports, total.Files)
+	return total, nil
+}
+
+// prunePostRevisions removes post revisions older than the revision retention
+// window. The media a revision kept is deleted with it, unless the post or a
+// revision that isn't being pruned still points to it.
+func (s *Server) prunePostRevisions(ctx context.Context) (int64, int, error) {
+	cutoff := pgtype.Timestamp{Time: time.Now().Add(-s.revisionRetention()), Valid: true}
+
+	var pruned int64
+	var files int
+	for {
+		revisions, err := s.hub.ListPrunablePostRevisions(ctx, db.ListPrunablePostRevisionsParams{
+			CreatedAt: cutoff,
+			Limit:     purgeBatchSize,
+		})
+		if err != nil {
+			return pruned, files, err
+		}
+		if len(revisions) == 0 {
+			break
+		}
+
+		ids := make([]pgtype.UUID, 0, len(revisions))
+		var mediaURLs []string
+		for _, revision := range revisions {
+			ids = append(ids, revision.ID)
+			if revision.PostType.PostType == db.PostTypeMedia && revision.MediaUrl.Valid {
+				mediaURLs = append(mediaURLs, revision.MediaUrl.String)
+			}
+		}
+
+		var keys []string
+		if len(mediaURLs) > 0 {
+			inUse, err := s.hub.ListMediaURLsInUse(ctx, db.ListMediaURLsInUseParams{
+				MediaUrls:   mediaURLs,
+				RevisionIds: ids,
+			})
+			if err != nil {
+				return pruned, files, err
+			}
+
+			used := make(map[string]bool, len(inUse))
+			for _, url := range inUse {
+				used[url] = true
+			}
+
+			var unused []string
+			for _, url := range mediaURLs {
+				if !used[url] {
+					unused = append(unused, url)
+				}
+			}
+			keys = appendPurgeableKeys(nil, unused)
+		}
+
+		if err := s.DeleteFiles(ctx, keys); err != nil {
+			return pruned, files, err
+		}
+		files += len(keys)
+
+		n, err := s.hub.DeletePostRevisions(ctx, ids)
+		if err != nil {
+			return pruned, files, err
+		}
+		pruned += n
+
+		if len(revisions) < purgeBatchSize {
+			break
+		}
+	}
+
+	return pruned, files, nil
+}
+
+// pruneWallExports removes wall exports older than the export retention window,
+// and those of walls soft-deleted before wallCutoff, along with their archive
+// and collage files
+func (s *Server) pruneWallExports(ctx context.Context, wallCutoff pgtype.Timestamp) (int64, int, error) {
+	cutoff := pgtype.Timestamp{Time: time.Now().Add(-s.exportRetention()), Valid: true}
+
+	var pruned int64
+	var files int
+	for {
+		exports, err := s.hub.ListPrunableWallExports(ctx, db.ListPrunableWallExportsParams{
+			CreatedAt: cutoff,
+			DeletedAt: wallCutoff,
+			Limit:     purgeBatchSize,
+		})
+		if err != nil {
+			return pruned, files, err
+		}
+		if len(exports) == 0 {
+			break
+		}
+
+		ids := make([]pgtype.UUID, 0, len(exports))
+		var keys []string
+		for _, wallExport := range exports {
+			ids = append(ids, wallExport.ID)
+			for _, url := range []pgtype.Text{wallExport.ArchiveUrl, wallExport.ImageUrl} {
+				if key := util.ExtractKeyFromMediaURL(url.String); url.Valid && strings.HasPrefix(key, "exports/") {
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		if err := s.DeleteFiles(ctx, keys); err != nil {
+			return pruned, files, err
+		}
+		files += len(keys)
+
+		n, err := s.hub.DeleteWallExports(ctx, ids)
+		if err != nil {
+			return pruned, files, err
+		}
+		pruned += n
+
+		if len(exports) < purgeBatchSize {
+			break
+		}
+	}
+
+	return pruned, files, nil
+}
+
+// appendPurgeableKeys adds the storage keys of mediaURLs that are safe to
+// delete to keys, skipping any already there
+func appendPurgeableKeys(keys []string, mediaURLs []string) []string {
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+	for _, url := range mediaURLs {
+		key := util.ExtractKeyFromMediaURL(url)
+		if purgeableKey(key) && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestPurgeDeletedContent(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	wall.BackgroundImage = pgtype.Text{String: "https://cdn.example.com/bg/" + user.ID.String(), Valid: true}
+
+	post1 := randomPost(t, wall.ID, user.ID)
+	post1.PostType = db.NullPostType{PostType: db.PostTypeMedia, Valid: true}
+	post1.MediaUrl = pgtype.Text{String: "https://cdn.example.com/uploads/post1.jpg", Valid: true}
+	post2 := randomPost(t, wall.ID, user.ID)
+	post2.PostType = db.NullPostType{PostType: db.PostTypeEmbedLink, Valid: true}
+	album := randomPost(t, wall.ID, user.ID)
+	album.PostType = db.NullPostType{PostType: db.PostTypeAlbum, Valid: true}
+	album.MediaUrl = pgtype.Text{String: "https://cdn.example.com/uploads/album1.jpg", Valid: true}
+	sketch := randomPost(t, wall.ID, user.ID)
+	sketch.PostType = db.NullPostType{PostType: db.PostTypeDrawing, Valid: true}
+	sketch.MediaUrl = pgtype.Text{String: "https://cdn.example.com/uploads/drawings/sketch.png", Valid: true}
+
+	testCases := []struct {
+		name      string
+		setupMock func(mockHub *mockdb.MockHub)
+		check     func(result purgeResult, err error)
+	}{
+		{
+			name: "OK",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPrunableWallExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.WallExport{}, nil)
+				mockHub.EXPECT().
+					ListPurgeablePosts(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ListPurgeablePostsParams) ([]db.Post, error) {
+						require.True(t, arg.DeletedAt.Valid)
+						require.Equal(t, int32(purgeBatchSize), arg.Limit)
+						return []db.Post{post1, post2, album, sketch}, nil
+					})
+				mockHub.EXPECT().
+					ListPurgeableWalls(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Wall{wall}, nil)
+				// The first revision kept the post's current media, which is only deleted once
+				mockHub.EXPECT().
+					ListPostRevisionMediaByPosts(gomock.Any(), []pgtype.UUID{post1.ID, post2.ID, album.ID, sketch.ID}).
+					Times(1).
+					Return([]string{post1.MediaUrl.String, "https://cdn.example.com/uploads/post1-old.jpg"}, nil)
+				// The album's cover is its first item, so it only shows up once
+				mockHub.EXPECT().
+					ListPostMediaByPosts(gomock.Any(), []pgtype.UUID{post1.ID, post2.ID, album.ID, sketch.ID}).
+					Times(1).
+					Return([]db.PostMedium{
+						{PostID: album.ID, Position: 0, MediaUrl: album.MediaUrl.String},
+						{PostID: album.ID, Position: 1, MediaUrl: "https://cdn.example.com/uploads/album2.jpg"},
+					}, nil)
+				mockHub.EXPECT().
+					PurgeDeletedTx(gomock.Any(), []pgtype.UUID{wall.ID}, []pgtype.UUID{post1.ID, post2.ID, album.ID, sketch.ID}).
+					Times(1).
+					Return(db.PurgeTxResult{Walls: 1, Posts: 4, Likes: 5}, nil)
+				mockHub.EXPECT().
+					ListPrunablePostRevisions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.PostRevision{}, nil)
+			},
+			check: func(result purgeResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(1), result.Walls)
+				require.Equal(t, int64(4), result.Posts)
+				require.Equal(t, int64(5), result.Likes)
+				// The shared background key is left alone
+				require.Equal(t, 5, result.Files)
+			},
+		},
+		{
+			name: "NothingToPurge",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPrunableWallExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.WallExport{}, nil)
+				mockHub.EXPECT().
+					ListPurgeablePosts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Post{}, nil)
+				mockHub.EXPECT().
+					ListPurgeableWalls(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Wall{}, nil)
+				mockHub.EXPECT().
+					PurgeDeletedTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					ListPrunablePostRevisions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.PostRevision{}, nil)
+			},
+			check: func(result purgeResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, purgeResult{}, result)
+			},
+		},
+		{
+			name: "TxError",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPrunableWallExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.WallExport{}, nil)
+				mockHub.EXPECT().
+					ListPurgeablePosts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Post{post2}, nil)
+				mockHub.EXPECT().
+					ListPurgeableWalls(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Wall{}, nil)
+				mockHub.EXPECT().
+					ListPostRevisionMediaByPosts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]string{}, nil)
+				mockHub.EXPECT().
+					ListPostMediaByPosts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.PostMedium{}, nil)
+				mockHub.EXPECT().
+					PurgeDeletedTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PurgeTxResult{}, errors.New("tx failed"))
+			},
+			check: func(result purgeResult, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.config.Env = "unit-test"
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			result, err := server.purgeDeletedContent(context.Background())
+			tc.check(result, err)
+		})
+	}
+}
+
+func TestPruneWallExports(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	completed := db.WallExport{
+		ID:         randomWall(t, user.ID).ID,
+		WallID:     wall.ID,
+		UserID:     user.ID,
+		Status:     "completed",
+		ArchiveUrl: pgtype.Text{String: "https://cdn.example.com/exports/old.zip", Valid: true},
+		ImageUrl:   pgtype.Text{String: "https://cdn.example.com/exports/old.png", Valid: true},
+	}
+	failed := db.WallExport{
+		ID:     randomWall(t, user.ID).ID,
+		WallID: wall.ID,
+		UserID: user.ID,
+		Status: "failed",
+	}
+	ids := []pgtype.UUID{completed.ID, failed.ID}
+	wallCutoff := pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	server := newTestServer(t)
+	server.config.Env = "unit-test"
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListPrunableWallExports(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ListPrunableWallExportsParams) ([]db.WallExport, error) {
+			require.WithinDuration(t, time.Now().Add(-server.exportRetention()), arg.CreatedAt.Time, time.Minute)
+			require.Equal(t, wallCutoff, arg.DeletedAt)
+			return []db.WallExport{completed, failed}, nil
+		})
+	mockHub.EXPECT().
+		DeleteWallExports(gomock.Any(), ids).
+		Times(1).
+		Return(int64(2), nil)
+
+	exports, files, err := server.pruneWallExports(context.Background(), wallCutoff)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), exports)
+	// Only the completed export had an archive and a collage
+	require.Equal(t, 2, files)
+}
+
+func TestPrunePostRevisions(t *testing.T) {
+	user, _ := randomUser(t)
+	post := randomPost(t, randomWall(t, user.ID).ID, user.ID)
+
+	media := db.NullPostType{PostType: db.PostTypeMedia, Valid: true}
+	oldMedia := db.PostRevision{
+		ID:       randomWall(t, post.ID).ID,
+		PostID:   post.ID,
+		PostType: media,
+		MediaUrl: pgtype.Text{String: "https://cdn.example.com/uploads/old.jpg", Valid: true},
+	}
+	stillUsed := db.PostRevision{
+		ID:       randomWall(t, post.ID).ID,
+		PostID:   post.ID,
+		PostType: media,
+		MediaUrl: pgtype.Text{String: "https://cdn.example.com/uploads/current.jpg", Valid: true},
+	}
+	embed := db.PostRevision{
+		ID:       randomWall(t, post.ID).ID,
+		PostID:   post.ID,
+		PostType: db.NullPostType{PostType: db.PostTypeEmbedLink, Valid: true},
+		MediaUrl: pgtype.Text{String: "https://example.com/uploads/article", Valid: true},
+	}
+	ids := []pgtype.UUID{oldMedia.ID, stillUsed.ID, embed.ID}
+
+	server := newTestServer(t)
+	server.config.Env = "unit-test"
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListPrunablePostRevisions(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ListPrunablePostRevisionsParams) ([]db.PostRevision, error) {
+			require.WithinDuration(t, time.Now().Add(-server.revisionRetention()), arg.CreatedAt.Time, time.Minute)
+			return []db.PostRevision{oldMedia, stillUsed, embed}, nil
+		})
+	// Embed links aren't stored by us, so only uploaded media is checked
+	mockHub.EXPECT().
+		ListMediaURLsInUse(gomock.Any(), db.ListMediaURLsInUseParams{
+			MediaUrls:   []string{oldMedia.MediaUrl.String, stillUsed.MediaUrl.String},
+			RevisionIds: ids,
+		}).
+		Times(1).
+		Return([]string{stillUsed.MediaUrl.String}, nil)
+	mockHub.EXPECT().
+		DeletePostRevisions(gomock.Any(), ids).
+		Times(1).
+		Return(int64(3), nil)
+
+	revisions, files, err := server.prunePostRevisions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revisions)
+	require.Equal(t, 1, files)
+}
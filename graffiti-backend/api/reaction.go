@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// defaultReactionEmojis is used when REACTION_EMOJIS isn't set
+const defaultReactionEmojis = "👍,❤️,😂,😮,😢,🎉"
+
+var errInvalidReaction = errors.New("reaction is not one of the allowed emojis")
+
+// reactionSet is the list of emojis users may react with. The thumbs-up that
+// likes stand for is always first, even if the config leaves it out.
+func (s *Server) reactionSet() []string {
+	raw := s.config.ReactionEmojis
+	if strings.TrimSpace(raw) == "" {
+		raw = defaultReactionEmojis
+	}
+
+	set := []string{db.LikeReaction}
+	seen := map[string]bool{db.LikeReaction: true}
+	for _, emoji := range strings.Split(raw, ",") {
+		emoji = strings.TrimSpace(emoji)
+		if emoji == "" || seen[emoji] {
+			continue
+		}
+		seen[emoji] = true
+		set = append(set, emoji)
+	}
+	return set
+}
+
+// allowedReaction reports whether an emoji is in the configured reaction set
+func (s *Server) allowedReaction(reaction string) bool {
+	for _, emoji := range s.reactionSet() {
+		if emoji == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// reactionCounts decodes a post's per-emoji counts, which are kept as jsonb
+func reactionCounts(raw []byte) map[string]int32 {
+	counts := map[string]int32{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &counts)
+	}
+	return counts
+}
+
+// notifyReaction tells the post author which emoji someone reacted with
+func (s *Server) notifyReaction(ctx context.Context, post db.Post, user db.User, reaction string) {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	if post.Author.Bytes == user.ID.Bytes {
+		return
+	}
+
+	// Thumbs-up keeps the like notification type older clients know about
+	notificationType := "post_reaction"
+	if reaction == db.LikeReaction {
+		notificationType = "post_like"
+	}
+
+	err := s.SendNotification(
+		ctx,
+		post.Author.String(),
+		user.ID.String(),
+		notificationType,
+		post.WallID.String(),
+		fmt.Sprintf("%s reacted %s to your post", user.Username, reaction),
+	)
+	if err != nil {
+		log.Error("Failed to send reaction notification", err)
+	}
+}
+
+type reactionResponse struct {
+	PostID         string           `json:"post_id"`
+	Reaction       string           `json:"reaction"`
+	LikesCount     int32            `json:"likes_count"`
+	ReactionCounts map[string]int32 `json:"reaction_counts"`
+}
+
+func newReactionResponse(result db.ReactionTxResult) reactionResponse {
+	return reactionResponse{
+		PostID:         result.Post.ID.String(),
+		Reaction:       result.Reaction,
+		LikesCount:     result.Post.LikesCount.Int32,
+		ReactionCounts: reactionCounts(result.Post.ReactionCounts),
+	}
+}
+
+// ListReactions handler returns the emojis users may react with
+func (s *Server) listReactions(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"reactions": s.reactionSet()})
+}
+
+type setReactionRequest struct {
+	Reaction string `json:"reaction" binding:"required"`
+}
+
+// SetReaction handler adds the current user's reaction to a post or changes it
+func (s *Server) setReaction(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set reaction request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setReactionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !s.allowedReaction(req.Reaction) {
+		log.Error("Invalid reaction", errInvalidReaction)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidReaction))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := s.getVisiblePost(ctx, id, currentUser); !ok {
+		return
+	}
+
+	result, err := s.hub.SetReactionTx(ctx, id, currentUser.ID, req.Reaction)
+	if err != nil {
+		log.Error("Failed to set reaction", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if result.Previous != result.Reaction {
+		s.notifyReaction(ctx, result.Post, currentUser, result.Reaction)
+	}
+
+	log.Info("Reaction set successfully")
+	ctx.JSON(http.StatusOK, newReactionResponse(result))
+}
+
+// RemoveReaction handler removes the current user's reaction from a post
+func (s *Server) removeReaction(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received remove reaction request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := s.hub.RemoveReactionTx(ctx, id, currentUser.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Reaction not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to remove reaction", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Reaction removed successfully")
+	ctx.JSON(http.StatusOK, newReactionResponse(result))
+}
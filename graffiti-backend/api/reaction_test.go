@@ -0,0 +1,264 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestReactionSet(t *testing.T) {
+	server := newTestServer(t)
+	require.Equal(t, []string{"👍", "❤️", "😂", "😮", "😢", "🎉"}, server.reactionSet())
+
+	// The thumbs-up is always allowed so likes keep working
+	server.config.ReactionEmojis = " 🔥, 🎉,,🔥 "
+	require.Equal(t, []string{"👍", "🔥", "🎉"}, server.reactionSet())
+	require.True(t, server.allowedReaction("👍"))
+	require.False(t, server.allowedReaction("❤️"))
+}
+
+func TestReactionCounts(t *testing.T) {
+	require.Equal(t, map[string]int32{}, reactionCounts(nil))
+	require.Equal(t, map[string]int32{"👍": 2, "🎉": 1}, reactionCounts([]byte(`{"👍": 2, "🎉": 1}`)))
+}
+
+func TestSetReactionAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	user, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	wall.IsPublic = pgtype.Bool{Bool: true, Valid: true}
+	post := randomPost(t, wall.ID, owner.ID)
+
+	reacted := post
+	reacted.ReactionCounts = []byte(`{"🎉": 1}`)
+
+	visible := func(mockHub *mockdb.MockHub) {
+		mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(post, nil)
+		mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Times(1).Return(wall, nil)
+		mockHub.EXPECT().
+			ListFriendshipByUserPairs(gomock.Any(), db.ListFriendshipByUserPairsParams{FromUser: user.ID, ToUser: owner.ID}).
+			Times(1).
+			Return(db.Friendship{}, db.ErrRecordNotFound)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"reaction": "🎉"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				visible(mockHub)
+				mockHub.EXPECT().
+					SetReactionTx(gomock.Any(), post.ID, user.ID, "🎉").
+					Times(1).
+					Return(db.ReactionTxResult{Post: reacted, Reaction: "🎉"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp reactionResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, "🎉", rsp.Reaction)
+				// A party popper isn't a like
+				require.Zero(t, rsp.LikesCount)
+				require.Equal(t, map[string]int32{"🎉": 1}, rsp.ReactionCounts)
+			},
+		},
+		{
+			name: "OK_Unchanged",
+			body: gin.H{"reaction": "🎉"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				visible(mockHub)
+				mockHub.EXPECT().
+					SetReactionTx(gomock.Any(), post.ID, user.ID, "🎉").
+					Times(1).
+					Return(db.ReactionTxResult{Post: reacted, Reaction: "🎉", Previous: "🎉"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_NotAllowed",
+			body: gin.H{"reaction": "🍕"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetReactionTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_Missing",
+			body: gin.H{},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetReactionTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"reaction": "🎉"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Times(1).Return(db.Post{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					SetReactionTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"reaction": "🎉"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				visible(mockHub)
+				mockHub.EXPECT().
+					SetReactionTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReactionTxResult{}, fmt.Errorf("connection lost"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/posts/:id/reaction", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.setReaction(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/posts/%s/reaction", post.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRemoveReactionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	post := randomPost(t, pgtype.UUID{}, pgtype.UUID{})
+
+	testCases := []struct {
+		name          string
+		postID        string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			postID: post.ID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					RemoveReactionTx(gomock.Any(), post.ID, user.ID).
+					Times(1).
+					Return(db.ReactionTxResult{Post: post, Previous: "🎉"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp reactionResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Empty(t, rsp.Reaction)
+				require.Equal(t, post.ID.String(), rsp.PostID)
+			},
+		},
+		{
+			name:   "NotFound",
+			postID: post.ID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					RemoveReactionTx(gomock.Any(), post.ID, user.ID).
+					Times(1).
+					Return(db.ReactionTxResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "BadRequest_InvalidID",
+			postID: "invalid-uuid",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					RemoveReactionTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.DELETE("/test/posts/:id/reaction", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.removeReaction(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/posts/%s/reaction", tc.postID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,611 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	// defaultReportHideThreshold is used when REPORT_HIDE_THRESHOLD isn't set
+	defaultReportHideThreshold = 5
+	// maxReportDetailLength is the most characters a report's detail can have once sanitized
+	maxReportDetailLength = 1000
+)
+
+const (
+	roleModerator = "moderator"
+	roleAdmin     = "admin"
+)
+
+var (
+	errReportOwnContent    = errors.New("you cannot report your own content")
+	errReportReviewed      = errors.New("report has already been reviewed")
+	errAlreadyReported     = errors.New("you have already reported this")
+	errSuspendModerator    = errors.New("only admins can suspend moderators")
+	errNotModerator        = errors.New("user not authorized to moderate reported content")
+	errReportDetailTooLong = fmt.Errorf("detail is longer than %d characters", maxReportDetailLength)
+)
+
+type createReportRequest struct {
+	TargetType string `json:"target_type" binding:"required,oneof=post wall user"`
+	TargetID   string `json:"target_id" binding:"required,uuid"`
+	Reason     string `json:"reason" binding:"required,oneof=spam harassment hate_speech nudity violence self_harm impersonation other"`
+	Detail     string `json:"detail"`
+}
+
+type listReportsRequest struct {
+	paginationRequest
+	Status     string `form:"status" binding:"omitempty,oneof=open resolved dismissed"`
+	TargetType string `form:"target_type" binding:"omitempty,oneof=post wall user"`
+}
+
+type resolveReportRequest struct {
+	Status string `json:"status" binding:"required,oneof=resolved dismissed"`
+	Note   string `json:"note"`
+}
+
+type suspendUserRequest struct {
+	Days int32 `json:"days" binding:"required,min=1,max=3650"`
+}
+
+type reportResponse struct {
+	ID               string     `json:"id"`
+	ReporterID       string     `json:"reporter_id"`
+	ReporterUsername string     `json:"reporter_username,omitempty"`
+	TargetType       string     `json:"target_type"`
+	TargetID         string     `json:"target_id"`
+	Reason           string     `json:"reason"`
+	Detail           string     `json:"detail"`
+	Status           string     `json:"status"`
+	ResolvedBy       string     `json:"resolved_by,omitempty"`
+	ResolutionNote   string     `json:"resolution_note,omitempty"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	// OpenReports counts the open reports on the same target, only set when listing
+	OpenReports int64 `json:"open_reports,omitempty"`
+}
+
+type reportsResponse struct {
+	Page     int32            `json:"page"`
+	PageSize int32            `json:"page_size"`
+	HasMore  bool             `json:"has_more"`
+	Reports  []reportResponse `json:"reports"`
+}
+
+func newReportResponse(report db.Report) reportResponse {
+	return reportResponse{
+		ID:             report.ID.String(),
+		ReporterID:     report.ReporterID.String(),
+		TargetType:     string(report.TargetType),
+		TargetID:       report.TargetID.String(),
+		Reason:         report.Reason,
+		Detail:         report.Detail,
+		Status:         string(report.Status),
+		ResolvedBy:     optionalUUID(report.ResolvedBy),
+		ResolutionNote: report.ResolutionNote,
+		ResolvedAt:     optionalTime(report.ResolvedAt),
+		CreatedAt:      report.CreatedAt.Time,
+	}
+}
+
+// isModerator reports whether a user can review reports across the whole site,
+// as opposed to the per-wall moderators of moderation.go
+func isModerator(user db.User) bool {
+	return user.Role == roleModerator || user.Role == roleAdmin
+}
+
+// isSuspended reports whether a user is currently suspended
+func isSuspended(user db.User) bool {
+	return user.SuspendedUntil.Valid && time.Now().Before(user.SuspendedUntil.Time)
+}
+
+// reportHideThreshold is how many open reports hide a post or wall until a moderator reviews it
+func (s *Server) reportHideThreshold() int64 {
+	if s.config.ReportHideThreshold <= 0 {
+		return defaultReportHideThreshold
+	}
+	return int64(s.config.ReportHideThreshold)
+}
+
+// getModerator loads the current user and checks they're a moderator.
+// It writes the error response itself and returns false if not.
+func getModerator(ctx *gin.Context) (db.User, bool) {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return currentUser, false
+	}
+
+	if !isModerator(currentUser) {
+		log.Error("Unauthorized to moderate", errNotModerator)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errNotModerator))
+		return currentUser, false
+	}
+
+	return currentUser, true
+}
+
+// reportTargetOwner returns the user responsible for a reported post, wall or user
+func (s *Server) reportTargetOwner(ctx context.Context, targetType db.ReportTargetType, targetID pgtype.UUID) (pgtype.UUID, error) {
+	switch targetType {
+	case db.ReportTargetTypePost:
+		post, err := s.hub.GetPost(ctx, targetID)
+		return post.Author, err
+	case db.ReportTargetTypeWall:
+		wall, err := s.hub.GetWall(ctx, targetID)
+		return wall.UserID, err
+	default:
+		user, err := s.hub.GetUser(ctx, targetID)
+		return user.ID, err
+	}
+}
+
+// notifyReportResolved tells reporters their report was reviewed. The
+// notification comes from the reporters themselves so moderators stay anonymous.
+func (s *Server) notifyReportResolved(ctx context.Context, reports []db.Report) {
+	log := logger.GetMetadata(ctx).GetLogger()
+
+	for _, report := range reports {
+		message := "Your report was reviewed and action was taken"
+		if report.Status == db.ReportStatusDismissed {
+			message = "Your report was reviewed and no action was needed"
+		}
+
+		err := s.SendNotification(
+			ctx,
+			report.ReporterID.String(),
+			report.ReporterID.String(),
+			"report_resolved",
+			report.ID.String(),
+			message,
+		)
+		if err != nil {
+			log.Error("Failed to send report resolved notification", err)
+		}
+	}
+}
+
+// CreateReport handler lets a user flag a post, wall or user for review
+func (s *Server) createReport(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received create report request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var req createReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var targetID pgtype.UUID
+	if err := targetID.Scan(req.TargetID); err != nil {
+		log.Error("Invalid target ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	detail := util.StripMarkup(req.Detail)
+	if len([]rune(detail)) > maxReportDetailLength {
+		log.Error("Invalid report detail", errReportDetailTooLong)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportDetailTooLong))
+		return
+	}
+
+	targetType := db.ReportTargetType(req.TargetType)
+	owner, err := s.reportTargetOwner(ctx, targetType, targetID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Report target not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("%s not found", req.TargetType)))
+			return
+		}
+		log.Error("Failed to get report target", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if owner == currentUser.ID {
+		log.Error("Invalid report", errReportOwnContent)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportOwnContent))
+		return
+	}
+
+	result, err := s.hub.CreateReportTx(ctx, db.CreateReportParams{
+		ReporterID: currentUser.ID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     req.Reason,
+		Detail:     detail,
+	}, s.reportHideThreshold())
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			log.Error("Duplicate report", err)
+			ctx.JSON(http.StatusConflict, errorResponse(errAlreadyReported))
+			return
+		}
+		log.Error("Failed to create report", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if result.Hidden {
+		log.Info("Reported %s %s hidden pending review", req.TargetType, req.TargetID)
+	}
+
+	log.Info("Report created successfully")
+	ctx.JSON(http.StatusCreated, newReportResponse(result.Report))
+}
+
+// ListReports handler returns reports for moderators to triage, oldest first
+func (s *Server) listReports(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list reports request")
+
+	if _, ok := getModerator(ctx); !ok {
+		return
+	}
+
+	var req listReportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	arg := db.ListReportsParams{
+		Limit:  req.PageSize + 1,
+		Offset: req.offset(),
+	}
+	if req.Status != "" {
+		arg.Status = db.NullReportStatus{ReportStatus: db.ReportStatus(req.Status), Valid: true}
+	}
+	if req.TargetType != "" {
+		arg.TargetType = db.NullReportTargetType{ReportTargetType: db.ReportTargetType(req.TargetType), Valid: true}
+	}
+
+	// Fetch one extra report to know whether there is another page
+	reports, err := s.hub.ListReports(ctx, arg)
+	if err != nil {
+		log.Error("Failed to list reports", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := reportsResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		HasMore:  len(reports) > int(req.PageSize),
+		Reports:  make([]reportResponse, 0, len(reports)),
+	}
+	if rsp.HasMore {
+		reports = reports[:req.PageSize]
+	}
+	for _, row := range reports {
+		report := newReportResponse(db.Report{
+			ID:             row.ID,
+			ReporterID:     row.ReporterID,
+			TargetType:     row.TargetType,
+			TargetID:       row.TargetID,
+			Reason:         row.Reason,
+			Detail:         row.Detail,
+			Status:         row.Status,
+			ResolvedBy:     row.ResolvedBy,
+			ResolutionNote: row.ResolutionNote,
+			ResolvedAt:     row.ResolvedAt,
+			CreatedAt:      row.CreatedAt,
+		})
+		report.ReporterUsername = row.ReporterUsername
+		report.OpenReports = row.OpenReports
+		rsp.Reports = append(rsp.Reports, report)
+	}
+
+	log.Info("Reports listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// ResolveReport handler closes a report, along with every other open report on
+// the same target, and lets the reporters know
+func (s *Server) resolveReport(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received resolve report request")
+
+	moderator, ok := getModerator(ctx)
+	if !ok {
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req resolveReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	note := util.StripMarkup(req.Note)
+	if len([]rune(note)) > maxReportDetailLength {
+		log.Error("Invalid resolution note", errReportDetailTooLong)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportDetailTooLong))
+		return
+	}
+
+	report, err := s.hub.GetReport(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Report not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get report", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if report.Status != db.ReportStatusOpen {
+		log.Error("Report already reviewed", errReportReviewed)
+		ctx.JSON(http.StatusConflict, errorResponse(errReportReviewed))
+		return
+	}
+
+	resolved, err := s.hub.ResolveReportsByTarget(ctx, db.ResolveReportsByTargetParams{
+		TargetType:     report.TargetType,
+		TargetID:       report.TargetID,
+		Status:         db.ReportStatus(req.Status),
+		ResolvedBy:     moderator.ID,
+		ResolutionNote: note,
+	})
+	if err != nil {
+		log.Error("Failed to resolve reports", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	s.notifyReportResolved(ctx, resolved)
+
+	rsp := make([]reportResponse, 0, len(resolved))
+	for _, r := range resolved {
+		rsp = append(rsp, newReportResponse(r))
+	}
+
+	log.Info("Reports resolved successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// HidePost handler takes a post off its wall until a moderator unhides it
+func (s *Server) hidePost(ctx *gin.Context) {
+	s.setPostHidden(ctx, true)
+}
+
+// UnhidePost handler puts a hidden post back on its wall
+func (s *Server) unhidePost(ctx *gin.Context) {
+	s.setPostHidden(ctx, false)
+}
+
+func (s *Server) setPostHidden(ctx *gin.Context, hidden bool) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set post hidden request")
+
+	if _, ok := getModerator(ctx); !ok {
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.SetPostHidden(ctx, db.SetPostHiddenParams{
+		ID:       id,
+		IsHidden: hidden,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to set post hidden", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Post hidden set successfully")
+	ctx.JSON(http.StatusOK, newPostResponse(post))
+}
+
+// HideWall handler hides a wall from everyone but its owner until a moderator unhides it
+func (s *Server) hideWall(ctx *gin.Context) {
+	s.setWallHidden(ctx, true)
+}
+
+// UnhideWall handler makes a hidden wall visible again
+func (s *Server) unhideWall(ctx *gin.Context) {
+	s.setWallHidden(ctx, false)
+}
+
+func (s *Server) setWallHidden(ctx *gin.Context, hidden bool) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set wall hidden request")
+
+	if _, ok := getModerator(ctx); !ok {
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.SetWallHidden(ctx, db.SetWallHiddenParams{
+		ID:       id,
+		IsHidden: hidden,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to set wall hidden", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall hidden set successfully")
+	ctx.JSON(http.StatusOK, newWallResponse(wall))
+}
+
+// SuspendUser handler locks a user out for a number of days, at most ten years
+func (s *Server) suspendUser(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received suspend user request")
+
+	var req suspendUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	until := time.Now().Add(time.Duration(req.Days) * 24 * time.Hour)
+	s.setUserSuspension(ctx, pgtype.Timestamp{Time: until, Valid: true})
+}
+
+// UnsuspendUser handler lifts a user's suspension early
+func (s *Server) unsuspendUser(ctx *gin.Context) {
+	s.setUserSuspension(ctx, pgtype.Timestamp{})
+}
+
+func (s *Server) setUserSuspension(ctx *gin.Context, until pgtype.Timestamp) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+
+	moderator, ok := getModerator(ctx)
+	if !ok {
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if id == moderator.ID {
+		log.Error("Invalid suspension", errors.New("you cannot change your own suspension"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("you cannot change your own suspension")))
+		return
+	}
+
+	user, err := s.hub.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("User not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get user", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if isModerator(user) && moderator.Role != roleAdmin {
+		log.Error("Unauthorized to suspend moderator", errSuspendModerator)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errSuspendModerator))
+		return
+	}
+
+	user, err = s.hub.SetUserSuspension(ctx, db.SetUserSuspensionParams{
+		ID:             id,
+		SuspendedUntil: until,
+	})
+	if err != nil {
+		log.Error("Failed to set user suspension", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("User suspension set successfully")
+	ctx.JSON(http.StatusOK, gin.H{
+		"id":              user.ID.String(),
+		"username":        user.Username,
+		"suspended_until": optionalTime(user.SuspendedUntil),
+	})
+}
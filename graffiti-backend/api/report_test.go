@@ -0,0 +1,526 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func randomReport(t *testing.T, reporterID pgtype.UUID, targetType db.ReportTargetType, targetID pgtype.UUID) db.Report {
+	return db.Report{
+		ID:         randomWall(t, reporterID).ID,
+		ReporterID: reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     "spam",
+		Status:     db.ReportStatusOpen,
+	}
+}
+
+func TestCreateReportAPI(t *testing.T) {
+	reporter, _ := randomUser(t)
+	author, _ := randomUser(t)
+	wall := randomWall(t, author.ID)
+	post := randomPost(t, wall.ID, author.ID)
+	report := randomReport(t, reporter.ID, db.ReportTargetTypePost, post.ID)
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: reporter,
+			body: gin.H{
+				"target_type": "post",
+				"target_id":   post.ID.String(),
+				"reason":      "spam",
+				"detail":      "<b>Buy</b> followers",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					CreateReportTx(gomock.Any(), db.CreateReportParams{
+						ReporterID: reporter.ID,
+						TargetType: db.ReportTargetTypePost,
+						TargetID:   post.ID,
+						Reason:     "spam",
+						Detail:     "Buy followers",
+					}, int64(defaultReportHideThreshold)).
+					Times(1).
+					Return(db.CreateReportTxResult{Report: report, Hidden: true}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var rsp reportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, report.ID.String(), rsp.ID)
+				require.Equal(t, "post", rsp.TargetType)
+				require.Equal(t, "open", rsp.Status)
+			},
+		},
+		{
+			name:        "BadRequest_OwnContent",
+			currentUser: author,
+			body: gin.H{
+				"target_type": "post",
+				"target_id":   post.ID.String(),
+				"reason":      "spam",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					CreateReportTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_InvalidReason",
+			currentUser: reporter,
+			body: gin.H{
+				"target_type": "post",
+				"target_id":   post.ID.String(),
+				"reason":      "boring",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: reporter,
+			body: gin.H{
+				"target_type": "wall",
+				"target_id":   wall.ID.String(),
+				"reason":      "harassment",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.Wall{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreateReportTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "Conflict_AlreadyReported",
+			currentUser: reporter,
+			body: gin.H{
+				"target_type": "user",
+				"target_id":   author.ID.String(),
+				"reason":      "impersonation",
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), author.ID).
+					Times(1).
+					Return(author, nil)
+				mockHub.EXPECT().
+					CreateReportTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateReportTxResult{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/reports", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.createReport(ctx)
+			})
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/reports", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListReportsAPI(t *testing.T) {
+	moderator, _ := randomUser(t)
+	moderator.Role = roleModerator
+	user, _ := randomUser(t)
+	report := randomReport(t, user.ID, db.ReportTargetTypeWall, randomWall(t, user.ID).ID)
+
+	row := db.ListReportsRow{
+		ID:               report.ID,
+		ReporterID:       report.ReporterID,
+		TargetType:       report.TargetType,
+		TargetID:         report.TargetID,
+		Reason:           report.Reason,
+		Status:           report.Status,
+		ReporterUsername: user.Username,
+		OpenReports:      3,
+	}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: moderator,
+			query:       "?status=open&target_type=wall&page_size=1",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListReports(gomock.Any(), db.ListReportsParams{
+						Limit:      2,
+						Offset:     0,
+						Status:     db.NullReportStatus{ReportStatus: db.ReportStatusOpen, Valid: true},
+						TargetType: db.NullReportTargetType{ReportTargetType: db.ReportTargetTypeWall, Valid: true},
+					}).
+					Times(1).
+					Return([]db.ListReportsRow{row, row}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp reportsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.HasMore)
+				require.Len(t, rsp.Reports, 1)
+				require.Equal(t, user.Username, rsp.Reports[0].ReporterUsername)
+				require.Equal(t, int64(3), rsp.Reports[0].OpenReports)
+			},
+		},
+		{
+			name:        "Unauthorized_NotModerator",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListReports(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/reports", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.listReports(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/test/reports"+tc.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestResolveReportAPI(t *testing.T) {
+	moderator, _ := randomUser(t)
+	moderator.Role = roleAdmin
+	reporter, _ := randomUser(t)
+	author, _ := randomUser(t)
+	post := randomPost(t, randomWall(t, author.ID).ID, author.ID)
+	report := randomReport(t, reporter.ID, db.ReportTargetTypePost, post.ID)
+
+	resolved := report
+	resolved.Status = db.ReportStatusResolved
+	resolved.ResolvedBy = moderator.ID
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"status": "resolved", "note": "Removed"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetReport(gomock.Any(), report.ID).
+					Times(1).
+					Return(report, nil)
+				mockHub.EXPECT().
+					ResolveReportsByTarget(gomock.Any(), db.ResolveReportsByTargetParams{
+						TargetType:     db.ReportTargetTypePost,
+						TargetID:       post.ID,
+						Status:         db.ReportStatusResolved,
+						ResolvedBy:     moderator.ID,
+						ResolutionNote: "Removed",
+					}).
+					Times(1).
+					Return([]db.Report{resolved}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []reportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp, 1)
+				require.Equal(t, "resolved", rsp[0].Status)
+				require.Equal(t, moderator.ID.String(), rsp[0].ResolvedBy)
+			},
+		},
+		{
+			name: "Conflict_AlreadyReviewed",
+			body: gin.H{"status": "dismissed"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetReport(gomock.Any(), report.ID).
+					Times(1).
+					Return(resolved, nil)
+				mockHub.EXPECT().
+					ResolveReportsByTarget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_InvalidStatus",
+			body: gin.H{"status": "open"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/reports/:id", func(ctx *gin.Context) {
+				ctx.Set("currentUser", moderator)
+				server.resolveReport(ctx)
+			})
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/reports/%s", report.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestHidePostAPI(t *testing.T) {
+	moderator, _ := randomUser(t)
+	moderator.Role = roleModerator
+	author, _ := randomUser(t)
+	post := randomPost(t, randomWall(t, author.ID).ID, author.ID)
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	hidden := post
+	hidden.IsHidden = true
+	mockHub.EXPECT().
+		SetPostHidden(gomock.Any(), db.SetPostHiddenParams{ID: post.ID, IsHidden: true}).
+		Times(1).
+		Return(hidden, nil)
+
+	server.router.PUT("/test/posts/:id/hide", func(ctx *gin.Context) {
+		ctx.Set("currentUser", moderator)
+		server.hidePost(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	url := fmt.Sprintf("/test/posts/%s/hide", post.ID.String())
+	request, err := http.NewRequest(http.MethodPut, url, nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestSuspendUserAPI(t *testing.T) {
+	moderator, _ := randomUser(t)
+	moderator.Role = roleModerator
+	user, _ := randomUser(t)
+	otherModerator, _ := randomUser(t)
+	otherModerator.Role = roleModerator
+
+	testCases := []struct {
+		name          string
+		target        db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			target: user,
+			body:   gin.H{"days": 7},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+				mockHub.EXPECT().
+					SetUserSuspension(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SetUserSuspensionParams) (db.User, error) {
+						require.Equal(t, user.ID, arg.ID)
+						require.True(t, arg.SuspendedUntil.Valid)
+						suspended := user
+						suspended.SuspendedUntil = arg.SuspendedUntil
+						return suspended, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Unauthorized_SuspendModerator",
+			target: otherModerator,
+			body:   gin.H{"days": 7},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), otherModerator.ID).
+					Times(1).
+					Return(otherModerator, nil)
+				mockHub.EXPECT().
+					SetUserSuspension(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "BadRequest_Self",
+			target: moderator,
+			body:   gin.H{"days": 7},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "BadRequest_InvalidDays",
+			target: user,
+			body:   gin.H{"days": 0},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/users/:id/suspend", func(ctx *gin.Context) {
+				ctx.Set("currentUser", moderator)
+				server.suspendUser(ctx)
+			})
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/users/%s/suspend", tc.target.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
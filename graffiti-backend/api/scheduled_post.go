@@ -0,0 +1,314 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	// maxScheduleAhead is how far in the future a post can be scheduled
+	maxScheduleAhead = 365 * 24 * time.Hour
+	// publishBatchSize bounds how many due posts a single publishing pass loads at once
+	publishBatchSize = 100
+)
+
+var (
+	errPublishAtPast    = errors.New("publish_at must be in the future")
+	errPublishAtTooFar  = errors.New("posts can be scheduled at most a year ahead")
+	errPostNotScheduled = errors.New("post is not scheduled")
+)
+
+type reschedulePostRequest struct {
+	PublishAt time.Time `json:"publish_at" binding:"required"`
+}
+
+type scheduledPostsResponse struct {
+	Page     int32          `json:"page"`
+	PageSize int32          `json:"page_size"`
+	HasMore  bool           `json:"has_more"`
+	Posts    []postResponse `json:"posts"`
+}
+
+// checkPublishAt makes sure a post is scheduled for later, but not too much later
+func checkPublishAt(publishAt, now time.Time) error {
+	if !publishAt.After(now) {
+		return errPublishAtPast
+	}
+	if publishAt.Sub(now) > maxScheduleAhead {
+		return errPublishAtTooFar
+	}
+	return nil
+}
+
+// publishScheduledPosts publishes every scheduled post whose time has come. Each
+// goes through the wall's moderation as if it had just been posted, and the
+// notifications held back when it was scheduled are sent now.
+func (s *Server) publishScheduledPosts(ctx context.Context) (int, error) {
+	log := logger.Global()
+
+	published := 0
+	for {
+		posts, err := s.hub.ListDueScheduledPosts(ctx, db.ListDueScheduledPostsParams{
+			PublishAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+			Limit:     publishBatchSize,
+		})
+		if err != nil {
+			return published, err
+		}
+
+		for _, post := range posts {
+			ok, err := s.publishScheduledPost(ctx, post)
+			if err != nil {
+				return published, err
+			}
+			if ok {
+				published++
+			}
+		}
+
+		if len(posts) < publishBatchSize {
+			break
+		}
+	}
+
+	log.Info("Published %d scheduled posts", published)
+	return published, nil
+}
+
+// publishScheduledPost publishes a single due post and reports whether it went
+// out. A post whose wall or author no longer exists never can, so it's
+// cancelled rather than left at the head of every later run.
+func (s *Server) publishScheduledPost(ctx context.Context, post db.Post) (bool, error) {
+	log := logger.Global()
+
+	wall, err := s.hub.GetWall(ctx, post.WallID)
+	if err != nil {
+		return false, s.cancelUnpublishablePost(ctx, post, err)
+	}
+	author, err := s.hub.GetUser(ctx, post.Author)
+	if err != nil {
+		return false, s.cancelUnpublishablePost(ctx, post, err)
+	}
+
+	status, err := s.newPostStatus(ctx, wall, author)
+	if err != nil {
+		return false, err
+	}
+
+	post, err = s.hub.PublishScheduledPost(ctx, db.PublishScheduledPostParams{
+		ID:     post.ID,
+		Status: status,
+	})
+	if err != nil {
+		// Cancelled or published by an overlapping run in the meantime
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	s.notifyNewPost(ctx, wall, author, post)
+	if err := s.notifyPostMentions(ctx, post); err != nil {
+		log.Error("Failed to notify mentions in scheduled post", err)
+	}
+	return true, nil
+}
+
+// cancelUnpublishablePost deletes a scheduled post when err says its wall or
+// author is missing, and passes any other error on
+func (s *Server) cancelUnpublishablePost(ctx context.Context, post db.Post, err error) error {
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return err
+	}
+	logger.Global().Error("Cancelling scheduled post "+post.ID.String()+" whose wall or author is gone", err)
+	return s.hub.DeletePost(ctx, post.ID)
+}
+
+// getOwnScheduledPost loads the post in the URI, making sure it's the current
+// user's and still waiting to be published.
+// It writes the error response itself and returns false if it isn't.
+func (s *Server) getOwnScheduledPost(ctx *gin.Context) (db.Post, bool) {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return db.Post{}, false
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Post{}, false
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Post{}, false
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Post{}, false
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Post{}, false
+	}
+
+	if post.Author != currentUser.ID {
+		log.Error("Unauthorized to schedule post", errors.New("user not authorized to schedule this post"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to schedule this post")))
+		return db.Post{}, false
+	}
+
+	if post.Status != db.PostStatusScheduled || post.IsDeleted.Bool {
+		log.Error("Post not scheduled", errPostNotScheduled)
+		ctx.JSON(http.StatusConflict, errorResponse(errPostNotScheduled))
+		return db.Post{}, false
+	}
+
+	return post, true
+}
+
+// ListScheduledPosts handler lists the current user's posts waiting to be
+// published, soonest first
+func (s *Server) listScheduledPosts(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list scheduled posts request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var req paginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	// Fetch one extra to know whether there is another page
+	posts, err := s.hub.ListScheduledPostsByAuthor(ctx, db.ListScheduledPostsByAuthorParams{
+		Author: currentUser.ID,
+		Limit:  req.PageSize + 1,
+		Offset: req.offset(),
+	})
+	if err != nil {
+		log.Error("Failed to list scheduled posts", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := scheduledPostsResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		HasMore:  len(posts) > int(req.PageSize),
+		Posts:    make([]postResponse, 0, len(posts)),
+	}
+	if rsp.HasMore {
+		posts = posts[:req.PageSize]
+	}
+
+	postTypes := make(map[pgtype.UUID]db.NullPostType, len(posts))
+	for _, post := range posts {
+		postTypes[post.ID] = post.PostType
+	}
+	media := s.albumMedia(ctx, postTypes)
+
+	for _, post := range posts {
+		response := newPostResponseFor(post, currentUser.ID)
+		response.Media = media[post.ID]
+		rsp.Posts = append(rsp.Posts, response)
+	}
+
+	log.Info("Scheduled posts listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// ReschedulePost handler moves a scheduled post to another time
+func (s *Server) reschedulePost(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received reschedule post request")
+
+	var req reschedulePostRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := checkPublishAt(req.PublishAt, time.Now()); err != nil {
+		log.Error("Invalid publish_at", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, ok := s.getOwnScheduledPost(ctx)
+	if !ok {
+		return
+	}
+
+	post, err := s.hub.ReschedulePost(ctx, db.ReschedulePostParams{
+		ID:        post.ID,
+		PublishAt: pgtype.Timestamp{Time: req.PublishAt.Local(), Valid: true},
+	})
+	if err != nil {
+		// Published while the request was on its way
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not scheduled", errPostNotScheduled)
+			ctx.JSON(http.StatusConflict, errorResponse(errPostNotScheduled))
+			return
+		}
+		log.Error("Failed to reschedule post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Post rescheduled successfully")
+	ctx.JSON(http.StatusOK, newPostResponseFor(post, post.Author))
+}
+
+// CancelScheduledPost handler drops a post before it's published. It's
+// deleted like any other post, so it can still be restored from the trash.
+func (s *Server) cancelScheduledPost(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received cancel scheduled post request")
+
+	post, ok := s.getOwnScheduledPost(ctx)
+	if !ok {
+		return
+	}
+
+	if err := s.hub.DeletePost(ctx, post.ID); err != nil {
+		log.Error("Failed to delete post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Scheduled post cancelled successfully")
+	ctx.JSON(http.StatusOK, gin.H{"message": "Scheduled post cancelled successfully"})
+}
@@ -0,0 +1,428 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func randomScheduledPost(t *testing.T, wallID, authorID pgtype.UUID) db.Post {
+	post := randomPost(t, wallID, authorID)
+	post.PostType = db.NullPostType{PostType: db.PostTypeText, Valid: true}
+	post.MediaUrl = pgtype.Text{}
+	post.Caption = pgtype.Text{String: "Happy birthday!", Valid: true}
+	post.Status = db.PostStatusScheduled
+	post.PublishAt = pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true}
+	return post
+}
+
+func TestCheckPublishAt(t *testing.T) {
+	now := time.Now()
+
+	require.NoError(t, checkPublishAt(now.Add(time.Minute), now))
+	require.NoError(t, checkPublishAt(now.Add(maxScheduleAhead), now))
+	require.ErrorIs(t, checkPublishAt(now, now), errPublishAtPast)
+	require.ErrorIs(t, checkPublishAt(now.Add(-time.Hour), now), errPublishAtPast)
+	require.ErrorIs(t, checkPublishAt(now.Add(maxScheduleAhead+time.Minute), now), errPublishAtTooFar)
+}
+
+func TestCreateScheduledPostAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	owner, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	// Scheduled posts skip moderation until they're published
+	wall.ModerationEnabled = pgtype.Bool{Bool: true, Valid: true}
+
+	testCases := []struct {
+		name          string
+		publishAt     time.Time
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			publishAt: time.Now().Add(24 * time.Hour),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+				mockHub.EXPECT().
+					GetDefaultWallSection(gomock.Any(), wall.ID).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					IsWallModerator(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), "").
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreatePostParams, _ string) (db.Post, error) {
+						require.Equal(t, db.PostStatusScheduled, arg.Status)
+						require.True(t, arg.PublishAt.Valid)
+
+						post := randomScheduledPost(t, arg.WallID, arg.Author)
+						post.PublishAt = arg.PublishAt
+						return post, nil
+					})
+				// Nobody hears about it until it's published
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var rsp postResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, "scheduled", rsp.Status)
+				require.NotNil(t, rsp.PublishAt)
+			},
+		},
+		{
+			name:      "BadRequest_InThePast",
+			publishAt: time.Now().Add(-time.Minute),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "BadRequest_TooFarAhead",
+			publishAt: time.Now().Add(maxScheduleAhead + time.Hour),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createPost(ctx)
+			})
+
+			data, err := json.Marshal(gin.H{
+				"wall_id":    wall.ID,
+				"post_type":  "text",
+				"caption":    "Happy birthday!",
+				"publish_at": tc.publishAt,
+			})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPublishScheduledPosts(t *testing.T) {
+	author, _ := randomUser(t)
+	owner, _ := randomUser(t)
+	deletedAuthor, _ := randomUser(t)
+
+	openWall := randomWall(t, owner.ID)
+	moderatedWall := randomWall(t, owner.ID)
+	moderatedWall.ModerationEnabled = pgtype.Bool{Bool: true, Valid: true}
+
+	onOpenWall := randomScheduledPost(t, openWall.ID, author.ID)
+	onModeratedWall := randomScheduledPost(t, moderatedWall.ID, author.ID)
+	cancelled := randomScheduledPost(t, openWall.ID, author.ID)
+	// Its author was deleted after scheduling it
+	orphaned := randomScheduledPost(t, openWall.ID, deletedAuthor.ID)
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListDueScheduledPosts(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ListDueScheduledPostsParams) ([]db.Post, error) {
+			require.WithinDuration(t, time.Now(), arg.PublishAt.Time, time.Minute)
+			require.Equal(t, int32(publishBatchSize), arg.Limit)
+			return []db.Post{orphaned, onOpenWall, onModeratedWall, cancelled}, nil
+		})
+	mockHub.EXPECT().GetWall(gomock.Any(), openWall.ID).Times(3).Return(openWall, nil)
+	mockHub.EXPECT().GetWall(gomock.Any(), moderatedWall.ID).Times(1).Return(moderatedWall, nil)
+	mockHub.EXPECT().GetUser(gomock.Any(), author.ID).Times(3).Return(author, nil)
+	mockHub.EXPECT().GetUser(gomock.Any(), deletedAuthor.ID).Times(1).Return(db.User{}, db.ErrRecordNotFound)
+	// An orphaned post is cancelled instead of holding up the posts after it
+	mockHub.EXPECT().DeletePost(gomock.Any(), orphaned.ID).Times(1).Return(nil)
+	mockHub.EXPECT().
+		IsWallModerator(gomock.Any(), db.IsWallModeratorParams{WallID: moderatedWall.ID, UserID: author.ID}).
+		Times(1).
+		Return(false, nil)
+
+	publish := func(post db.Post, status db.PostStatus) {
+		mockHub.EXPECT().
+			PublishScheduledPost(gomock.Any(), db.PublishScheduledPostParams{ID: post.ID, Status: status}).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ db.PublishScheduledPostParams) (db.Post, error) {
+				post.Status = status
+				return post, nil
+			})
+	}
+	publish(onOpenWall, db.PostStatusApproved)
+	// Moderation is decided when the post goes out, not when it was written
+	publish(onModeratedWall, db.PostStatusPending)
+	mockHub.EXPECT().
+		PublishScheduledPost(gomock.Any(), db.PublishScheduledPostParams{ID: cancelled.ID, Status: db.PostStatusApproved}).
+		Times(1).
+		Return(db.Post{}, db.ErrRecordNotFound)
+
+	// Only the approved post reaches the wall's followers
+	mockHub.EXPECT().
+		ListWallFollowersToNotify(gomock.Any(), db.ListWallFollowersToNotifyParams{WallID: openWall.ID, UserID: author.ID}).
+		Times(1).
+		Return([]pgtype.UUID{}, nil)
+
+	published, err := server.publishScheduledPosts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+}
+
+func TestListScheduledPostsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	posts := make([]db.Post, 0, 3)
+	for i := 0; i < 3; i++ {
+		posts = append(posts, randomScheduledPost(t, wall.ID, user.ID))
+	}
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListScheduledPostsByAuthor(gomock.Any(), db.ListScheduledPostsByAuthorParams{
+			Author: user.ID,
+			Limit:  3,
+			Offset: 0,
+		}).
+		Times(1).
+		Return(posts, nil)
+
+	server.router.GET("/test/posts/scheduled", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.listScheduledPosts(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/test/posts/scheduled?page_size=2", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp scheduledPostsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.True(t, rsp.HasMore)
+	require.Len(t, rsp.Posts, 2)
+	require.Equal(t, posts[0].ID.String(), rsp.Posts[0].ID)
+}
+
+func TestReschedulePostAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	post := randomScheduledPost(t, randomWall(t, user.ID).ID, user.ID)
+
+	published := post
+	published.Status = db.PostStatusApproved
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		publishAt     time.Time
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			user:      user,
+			publishAt: time.Now().Add(48 * time.Hour),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(post, nil)
+				mockHub.EXPECT().
+					ReschedulePost(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReschedulePostParams) (db.Post, error) {
+						require.Equal(t, post.ID, arg.ID)
+						require.WithinDuration(t, time.Now().Add(48*time.Hour), arg.PublishAt.Time, time.Minute)
+
+						rescheduled := post
+						rescheduled.PublishAt = arg.PublishAt
+						return rescheduled, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "BadRequest_InThePast",
+			user:      user,
+			publishAt: time.Now().Add(-time.Hour),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ReschedulePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "Unauthorized_NotTheAuthor",
+			user:      otherUser,
+			publishAt: time.Now().Add(time.Hour),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(post, nil)
+				mockHub.EXPECT().
+					ReschedulePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "Conflict_AlreadyPublished",
+			user:      user,
+			publishAt: time.Now().Add(time.Hour),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(published, nil)
+				mockHub.EXPECT().
+					ReschedulePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/posts/:id/schedule", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.user)
+				server.reschedulePost(ctx)
+			})
+
+			data, err := json.Marshal(gin.H{"publish_at": tc.publishAt})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/test/posts/%s/schedule", post.ID.String()), bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCancelScheduledPostAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	post := randomScheduledPost(t, randomWall(t, user.ID).ID, user.ID)
+
+	published := post
+	published.Status = db.PostStatusApproved
+
+	testCases := []struct {
+		name          string
+		post          db.Post
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			post: post,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					DeletePost(gomock.Any(), post.ID).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Conflict_AlreadyPublished",
+			post: published,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					DeletePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().GetPost(gomock.Any(), post.ID).Return(tc.post, nil)
+			tc.setupMock(mockHub)
+
+			server.router.DELETE("/test/posts/:id/schedule", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.cancelScheduledPost(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/test/posts/%s/schedule", post.ID.String()), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
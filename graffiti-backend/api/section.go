@@ -0,0 +1,535 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// maxWallSections includes the default section
+const maxWallSections = 20
+
+type wallSectionRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type reorderWallSectionsRequest struct {
+	SectionIDs []string `json:"section_ids" binding:"required,min=1,max=20,unique,dive,uuid"`
+}
+
+type setPostSectionRequest struct {
+	SectionID string `json:"section_id" binding:"required,uuid"`
+}
+
+type wallSectionResponse struct {
+	ID        string    `json:"id"`
+	WallID    string    `json:"wall_id"`
+	Name      string    `json:"name"`
+	Position  int32     `json:"position"`
+	IsDefault bool      `json:"is_default"`
+	PostCount int64     `json:"post_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type deleteWallSectionResponse struct {
+	DefaultSectionID string `json:"default_section_id"`
+	PostsMoved       int64  `json:"posts_moved"`
+}
+
+func newWallSectionResponse(section db.WallSection) wallSectionResponse {
+	return wallSectionResponse{
+		ID:        section.ID.String(),
+		WallID:    section.WallID.String(),
+		Name:      section.Name,
+		Position:  section.Position,
+		IsDefault: section.IsDefault,
+		CreatedAt: section.CreatedAt.Time,
+	}
+}
+
+// optionalUUID formats a nullable ID, leaving it empty when unset
+func optionalUUID(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.String()
+}
+
+// postSection picks the section a new post goes into. An explicit section must
+// belong to the wall; otherwise the wall's default section is used, if it has sections.
+func (s *Server) postSection(ctx context.Context, wallID pgtype.UUID, requested string) (pgtype.UUID, error) {
+	var sectionID pgtype.UUID
+
+	if requested != "" {
+		if err := sectionID.Scan(requested); err != nil {
+			return sectionID, err
+		}
+		section, err := s.hub.GetWallSection(ctx, db.GetWallSectionParams{ID: sectionID, WallID: wallID})
+		return section.ID, err
+	}
+
+	section, err := s.hub.GetDefaultWallSection(ctx, wallID)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return sectionID, nil
+	}
+	return section.ID, err
+}
+
+// bindWallSectionURI reads the wall and section IDs from the URI
+func bindWallSectionURI(ctx *gin.Context) (wallID, sectionID pgtype.UUID, err error) {
+	var uri struct {
+		ID        string `uri:"id" binding:"required,uuid"`
+		SectionID string `uri:"section_id" binding:"required,uuid"`
+	}
+
+	if err = ctx.ShouldBindUri(&uri); err != nil {
+		return
+	}
+	if err = wallID.Scan(uri.ID); err != nil {
+		return
+	}
+	err = sectionID.Scan(uri.SectionID)
+	return
+}
+
+// getOwnWallForSections loads a wall and checks the current user may change its sections.
+// It writes the error response itself and returns false if not.
+func (s *Server) getOwnWallForSections(ctx *gin.Context, wallID pgtype.UUID, user db.User) bool {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	wall, err := s.hub.GetWall(ctx, wallID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if wall.UserID != user.ID {
+		log.Error("Unauthorized to edit wall sections", errors.New("user not authorized to edit the sections of this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to edit the sections of this wall")))
+		return false
+	}
+
+	if wall.IsDeleted.Bool {
+		log.Error("Wall is deleted", errors.New("wall is deleted"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("wall is deleted")))
+		return false
+	}
+
+	return true
+}
+
+// ListWallSections handler
+func (s *Server) listWallSections(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list wall sections request")
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sections, err := s.hub.ListWallSections(ctx, id)
+	if err != nil {
+		log.Error("Failed to list wall sections", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]wallSectionResponse, 0, len(sections))
+	for _, section := range sections {
+		sectionRsp := newWallSectionResponse(db.WallSection{
+			ID:        section.ID,
+			WallID:    section.WallID,
+			Name:      section.Name,
+			Position:  section.Position,
+			IsDefault: section.IsDefault,
+			CreatedAt: section.CreatedAt,
+		})
+		sectionRsp.PostCount = section.PostCount
+		rsp = append(rsp, sectionRsp)
+	}
+
+	log.Info("Wall sections listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// CreateWallSection handler
+func (s *Server) createWallSection(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received create wall section request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req wallSectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		log.Error("Invalid section name", errors.New("section name is required"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("section name is required")))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !s.getOwnWallForSections(ctx, id, currentUser) {
+		return
+	}
+
+	sections, err := s.hub.ListWallSections(ctx, id)
+	if err != nil {
+		log.Error("Failed to list wall sections", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// The first section also creates the default one
+	count := len(sections)
+	if count == 0 {
+		count = 1
+	}
+	if count >= maxWallSections {
+		err := fmt.Errorf("a wall can have at most %d sections", maxWallSections)
+		log.Error("Section limit reached", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	section, err := s.hub.CreateWallSectionTx(ctx, id, name)
+	if err != nil {
+		log.Error("Failed to create wall section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall section created successfully")
+	ctx.JSON(http.StatusCreated, newWallSectionResponse(section))
+}
+
+// RenameWallSection handler
+func (s *Server) renameWallSection(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received rename wall section request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	wallID, sectionID, err := bindWallSectionURI(ctx)
+	if err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req wallSectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		log.Error("Invalid section name", errors.New("section name is required"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("section name is required")))
+		return
+	}
+
+	if !s.getOwnWallForSections(ctx, wallID, currentUser) {
+		return
+	}
+
+	if _, err := s.hub.GetWallSection(ctx, db.GetWallSectionParams{ID: sectionID, WallID: wallID}); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Section not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("section not found")))
+			return
+		}
+		log.Error("Failed to get wall section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	section, err := s.hub.RenameWallSection(ctx, db.RenameWallSectionParams{
+		ID:   sectionID,
+		Name: name,
+	})
+	if err != nil {
+		log.Error("Failed to rename wall section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall section renamed successfully")
+	ctx.JSON(http.StatusOK, newWallSectionResponse(section))
+}
+
+// ReorderWallSections handler
+func (s *Server) reorderWallSections(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received reorder wall sections request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req reorderWallSectionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sectionIDs := make([]pgtype.UUID, len(req.SectionIDs))
+	for i, sectionID := range req.SectionIDs {
+		if err := sectionIDs[i].Scan(sectionID); err != nil {
+			log.Error("Invalid section ID", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	if !s.getOwnWallForSections(ctx, id, currentUser) {
+		return
+	}
+
+	sections, err := s.hub.ReorderWallSectionsTx(ctx, id, sectionIDs)
+	if err != nil {
+		if errors.Is(err, db.ErrSectionOrderMismatch) {
+			log.Error("Invalid section order", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		log.Error("Failed to reorder wall sections", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]wallSectionResponse, 0, len(sections))
+	for _, section := range sections {
+		rsp = append(rsp, newWallSectionResponse(section))
+	}
+
+	log.Info("Wall sections reordered successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// DeleteWallSection handler moves the section's posts to the default section
+func (s *Server) deleteWallSection(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received delete wall section request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	wallID, sectionID, err := bindWallSectionURI(ctx)
+	if err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !s.getOwnWallForSections(ctx, wallID, currentUser) {
+		return
+	}
+
+	result, err := s.hub.DeleteWallSectionTx(ctx, wallID, sectionID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Section not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("section not found")))
+			return
+		}
+		if errors.Is(err, db.ErrDefaultSection) {
+			log.Error("Cannot delete default section", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		log.Error("Failed to delete wall section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall section deleted successfully")
+	ctx.JSON(http.StatusOK, deleteWallSectionResponse{
+		DefaultSectionID: result.DefaultSection.ID.String(),
+		PostsMoved:       result.PostsMoved,
+	})
+}
+
+// SetPostSection handler moves a post to another section of its wall.
+// The post's author and the wall owner can move it.
+func (s *Server) setPostSection(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set post section request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setPostSectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id, sectionID pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := sectionID.Scan(req.SectionID); err != nil {
+		log.Error("Invalid section ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if post.Author != currentUser.ID {
+		wall, err := s.hub.GetWall(ctx, post.WallID)
+		if err != nil {
+			log.Error("Failed to get wall", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if wall.UserID != currentUser.ID {
+			log.Error("Unauthorized to move post", errors.New("user not authorized to move this post"))
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to move this post")))
+			return
+		}
+	}
+
+	if _, err := s.hub.GetWallSection(ctx, db.GetWallSectionParams{ID: sectionID, WallID: post.WallID}); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Section not in wall", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("section does not belong to this wall")))
+			return
+		}
+		log.Error("Failed to get wall section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	post, err = s.hub.SetPostSection(ctx, db.SetPostSectionParams{
+		ID:        id,
+		SectionID: sectionID,
+	})
+	if err != nil {
+		log.Error("Failed to set post section", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Post section updated successfully")
+	ctx.JSON(http.StatusOK, newPostResponse(post))
+}
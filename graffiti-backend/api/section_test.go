@@ -0,0 +1,464 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestCreateWallSectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	section := db.WallSection{ID: randomWall(t, user.ID).ID, WallID: wall.ID, Name: "Wishes", Position: 1}
+
+	fullWall := make([]db.ListWallSectionsRow, maxWallSections)
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: user,
+			body:        gin.H{"name": "  Wishes "},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListWallSections(gomock.Any(), wall.ID).
+					Times(1).
+					Return([]db.ListWallSectionsRow{}, nil)
+				mockHub.EXPECT().
+					CreateWallSectionTx(gomock.Any(), wall.ID, "Wishes").
+					Times(1).
+					Return(section, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp wallSectionResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, section.ID.String(), rsp.ID)
+				require.Equal(t, "Wishes", rsp.Name)
+				require.False(t, rsp.IsDefault)
+			},
+		},
+		{
+			name:        "BadRequest_TooManySections",
+			currentUser: user,
+			body:        gin.H{"name": "One more"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ListWallSections(gomock.Any(), wall.ID).
+					Times(1).
+					Return(fullWall, nil)
+				mockHub.EXPECT().
+					CreateWallSectionTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_BlankName",
+			currentUser: user,
+			body:        gin.H{"name": "   "},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized_NotOwner",
+			currentUser: otherUser,
+			body:        gin.H{"name": "Wishes"},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					CreateWallSectionTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/walls/:id/sections", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.createWallSection(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/sections", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReorderWallSectionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	first := db.WallSection{ID: randomWall(t, user.ID).ID, WallID: wall.ID, Name: "General", IsDefault: true}
+	second := db.WallSection{ID: randomWall(t, user.ID).ID, WallID: wall.ID, Name: "Wishes"}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"section_ids": []string{second.ID.String(), first.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ReorderWallSectionsTx(gomock.Any(), wall.ID, gomock.Len(2)).
+					Times(1).
+					Return([]db.WallSection{second, first}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp []wallSectionResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Len(t, rsp, 2)
+				require.Equal(t, second.ID.String(), rsp[0].ID)
+			},
+		},
+		{
+			name: "BadRequest_Mismatch",
+			body: gin.H{"section_ids": []string{second.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					ReorderWallSectionsTx(gomock.Any(), wall.ID, gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrSectionOrderMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_Duplicates",
+			body: gin.H{"section_ids": []string{first.ID.String(), first.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ReorderWallSectionsTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/sections/order", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.reorderWallSections(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/sections/order", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteWallSectionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+
+	defaultSection := db.WallSection{ID: randomWall(t, user.ID).ID, WallID: wall.ID, Name: "General", IsDefault: true}
+	sectionID := randomWall(t, user.ID).ID
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					DeleteWallSectionTx(gomock.Any(), wall.ID, sectionID).
+					Times(1).
+					Return(db.DeleteWallSectionTxResult{DefaultSection: defaultSection, PostsMoved: 4}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp deleteWallSectionResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, defaultSection.ID.String(), rsp.DefaultSectionID)
+				require.Equal(t, int64(4), rsp.PostsMoved)
+			},
+		},
+		{
+			name: "BadRequest_DefaultSection",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					DeleteWallSectionTx(gomock.Any(), wall.ID, sectionID).
+					Times(1).
+					Return(db.DeleteWallSectionTxResult{}, db.ErrDefaultSection)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					DeleteWallSectionTx(gomock.Any(), wall.ID, sectionID).
+					Times(1).
+					Return(db.DeleteWallSectionTxResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.DELETE("/test/walls/:id/sections/:section_id", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.deleteWallSection(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/sections/%s", wall.ID.String(), sectionID.String())
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetPostSectionAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	post := randomPost(t, wall.ID, author.ID)
+
+	section := db.WallSection{ID: randomWall(t, owner.ID).ID, WallID: wall.ID, Name: "Wishes"}
+
+	moved := post
+	moved.SectionID = section.ID
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_WallOwner",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					GetWallSection(gomock.Any(), db.GetWallSectionParams{ID: section.ID, WallID: wall.ID}).
+					Times(1).
+					Return(section, nil)
+				mockHub.EXPECT().
+					SetPostSection(gomock.Any(), db.SetPostSectionParams{ID: post.ID, SectionID: section.ID}).
+					Times(1).
+					Return(moved, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp postResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, section.ID.String(), rsp.SectionID)
+			},
+		},
+		{
+			name:        "BadRequest_SectionNotInWall",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWallSection(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WallSection{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					SetPostSection(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					SetPostSection(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/posts/:id/section", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.setPostSection(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"section_id": section.ID.String()})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/posts/%s/section", post.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
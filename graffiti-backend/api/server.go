@@ -20,6 +20,7 @@ import (
 	"github.com/vittotedja/graffiti/graffiti-backend/token"
 	"github.com/vittotedja/graffiti/graffiti-backend/util"
 	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/unfurl"
 )
 
 // Server serves HTTP requests
@@ -30,6 +31,8 @@ type Server struct {
 	router     *gin.Engine
 	tokenMaker token.Maker
 	httpServer *http.Server
+	views      *viewTracker
+	unfurler   linkUnfurler
 }
 
 func NewServer(config util.Config) (*Server, error) {
@@ -37,7 +40,7 @@ func NewServer(config util.Config) (*Server, error) {
 	if err != nil {
 		log.Fatal("cannot create token maker", err)
 	}
-	server := &Server{config: config, router: gin.Default(), tokenMaker: tokenMaker}
+	server := &Server{config: config, router: gin.Default(), tokenMaker: tokenMaker, unfurler: unfurl.NewFetcher()}
 	server.router.Use(logger.Middleware())
 	server.registerRoutes("server")
 
@@ -60,6 +63,8 @@ func (s *Server) Start() error {
 	}
 	s.db = connPool
 	s.hub = db.NewHub(connPool)
+	s.views = newViewTracker(s.hub)
+	s.views.Start()
 
 	// Set up HTTP server
 	s.httpServer = &http.Server{
@@ -68,6 +73,18 @@ func (s *Server) Start() error {
 	}
 
 	cron.ScheduleMaterializedViewRefresh(s.db)
+	cron.SchedulePurge(func(ctx context.Context) error {
+		_, err := s.purgeDeletedContent(ctx)
+		return err
+	})
+	cron.ScheduleAutoArchive(func(ctx context.Context) error {
+		_, err := s.autoArchiveWalls(ctx)
+		return err
+	})
+	cron.SchedulePostPublishing(func(ctx context.Context) error {
+		_, err := s.publishScheduledPosts(ctx)
+		return err
+	})
 
 	logger.Global().Info("Server listening on %s", s.config.ServerAddress)
 	return s.httpServer.ListenAndServe()
@@ -85,6 +102,11 @@ func (s *Server) Shutdown() error {
 		return fmt.Errorf("server shutdown failed: %v", err)
 	}
 
+	// Write out buffered wall views before the pool goes away
+	if err := s.views.Stop(ctx); err != nil {
+		logger.Global().Error("Failed to flush wall views", err)
+	}
+
 	if s.db != nil {
 		s.db.Close()
 		logger.Global().Info("Database connection closed.")
@@ -161,6 +183,8 @@ func (s *Server) registerRoutes(env string) {
 		// users
 		protected.GET("/v1/users/:id", s.getUser)
 		protected.POST("/v2/users", s.updateUserNew) // no test
+		protected.PUT("/v1/users/me/auto-archive", s.setAutoArchive)
+		protected.GET("/v1/users/me/auto-archive/preview", s.previewAutoArchive)
 
 		// Protected Walls Endpoint
 		protected.GET("/v1/walls/:id", s.getWall) // working
@@ -170,13 +194,54 @@ func (s *Server) registerRoutes(env string) {
 		protected.PUT("/v1/walls/:id", s.updateWall)              
 		protected.PUT("/v1/walls/:id/publicize", s.publicizeWall) 
 		protected.PUT("/v1/walls/:id/privatize", s.privatizeWall) 
+		protected.GET("/v1/walls/:id/revisions", s.listWallRevisions)
+		protected.POST("/v1/walls/:id/revisions/:revision_id/revert", s.revertWall)
 		protected.PUT("/v1/walls/:id/pin", s.pinWall)           
+		protected.PUT("/v1/walls/pins", s.setWallPins)
+		protected.POST("/v1/walls/:id/clone", s.cloneWall)
+		protected.GET("/v1/walls/:id/sections", s.listWallSections)
+		protected.POST("/v1/walls/:id/sections", s.createWallSection)
+		protected.PUT("/v1/walls/:id/sections/order", s.reorderWallSections)
+		protected.PUT("/v1/walls/:id/sections/:section_id", s.renameWallSection)
+		protected.DELETE("/v1/walls/:id/sections/:section_id", s.deleteWallSection)
+		protected.GET("/v1/wall-templates", s.listWallTemplates)
 		protected.DELETE("/v1/walls/:id", s.deleteWall)
+		protected.PUT("/v1/walls/:id/restore", s.restoreWall)
 
 		protected.GET("/v1/walls/archived", s.getArchivedWalls)
+		protected.GET("/v1/walls/followed", s.listFollowedWalls)
 		protected.PUT("/v1/walls/:id/archive", s.archiveWall)
 		protected.PUT("/v1/walls/:id/unarchive", s.unarchiveWall)
 
+		// moderation
+		protected.PUT("/v1/walls/:id/moderation", s.setWallModeration)
+		protected.GET("/v1/walls/:id/moderation/queue", s.listModerationQueue)
+		protected.PUT("/v1/walls/:id/moderation/posts", s.moderatePosts)
+		protected.PUT("/v1/posts/:id/moderation", s.moderatePost)
+		protected.GET("/v1/walls/:id/moderators", s.listWallModerators)
+		protected.POST("/v1/walls/:id/moderators", s.addWallModerator)
+		protected.DELETE("/v1/walls/:id/moderators/:user_id", s.removeWallModerator)
+
+		// anonymous posts
+		protected.PUT("/v1/walls/:id/anonymous", s.setWallAnonymous)
+		protected.GET("/v1/posts/:id/author", s.getPostAuthor)
+
+		// follows
+		protected.POST("/v1/walls/:id/follow", s.followWall)
+		protected.DELETE("/v1/walls/:id/follow", s.unfollowWall)
+		protected.PUT("/v1/walls/:id/follow/mute", s.muteWall)
+
+		// exports
+		protected.POST("/v1/walls/:id/export", s.exportWall)
+		protected.GET("/v1/exports/:id", s.getWallExport)
+
+		// analytics
+		protected.GET("/v1/walls/:id/analytics", s.getWallAnalytics)
+
+		// tags
+		protected.GET("/v1/tags", s.searchTags)
+		protected.GET("/v1/tags/:tag/walls", s.listWallsByTag)
+
 		// search
 		protected.POST("/v1/users/search", s.searchUsers)
 
@@ -193,12 +258,34 @@ func (s *Server) registerRoutes(env string) {
 		//posts
 		protected.GET("/v2/walls/:id/posts", s.listPostsByWallWithAuthorsDetails) 
 		protected.DELETE("/v1/posts/:id", s.deletePost)
+		protected.PUT("/v1/posts/:id/restore", s.restorePost)
+		protected.GET("/v1/trash", s.getTrash)
 		protected.POST("/v1/posts", s.createPost)
+		protected.GET("/v1/posts/scheduled", s.listScheduledPosts)
+		protected.PUT("/v1/posts/:id/schedule", s.reschedulePost)
+		protected.DELETE("/v1/posts/:id/schedule", s.cancelScheduledPost)
+		protected.PUT("/v1/posts/:id", s.updatePost)
+		protected.GET("/v1/posts/:id/revisions", s.listPostRevisions)
+		protected.PUT("/v1/posts/:id/layout", s.updatePostLayout)
+		protected.PUT("/v1/posts/:id/section", s.setPostSection)
+		protected.PUT("/v1/walls/:id/layout", s.updateWallLayout)
+
+		//comments
+		protected.GET("/v1/posts/:id/comments", s.listPostComments)
+		protected.POST("/v1/posts/:id/comments", s.createComment)
+		protected.GET("/v1/comments/:id/replies", s.listCommentReplies)
+		protected.PUT("/v1/comments/:id", s.updateComment)
+		protected.DELETE("/v1/comments/:id", s.deleteComment)
 
 		//likes
 		protected.POST("/v1/likes", s.updateLike)
 		protected.GET("/v1/likes/:post_id", s.getLike)
 
+		//reactions
+		protected.GET("/v1/reactions", s.listReactions)
+		protected.PUT("/v1/posts/:id/reaction", s.setReaction)
+		protected.DELETE("/v1/posts/:id/reaction", s.removeReaction)
+
 		//discover
 		protected.POST("/v1/friends/discover", s.discoverFriendsByMutuals)
 		protected.POST("/v1/friends/mutual", s.getMutualFriends)
@@ -208,6 +295,17 @@ func (s *Server) registerRoutes(env string) {
 		protected.PUT("/v1/notifications/:id/read", s.markNotificationAsRead)
 		protected.PUT("/v1/notifications/read-all", s.markAllNotificationsAsRead)
 		protected.GET("/v1/notifications/unread/count", s.getUnreadNotificationsCount)
+
+		//reports
+		protected.POST("/v1/reports", s.createReport)
+		protected.GET("/v1/reports", s.listReports)
+		protected.PUT("/v1/reports/:id", s.resolveReport)
+		protected.PUT("/v1/posts/:id/hide", s.hidePost)
+		protected.PUT("/v1/posts/:id/unhide", s.unhidePost)
+		protected.PUT("/v1/walls/:id/hide", s.hideWall)
+		protected.PUT("/v1/walls/:id/unhide", s.unhideWall)
+		protected.PUT("/v1/users/:id/suspend", s.suspendUser)
+		protected.PUT("/v1/users/:id/unsuspend", s.unsuspendUser)
 	}
 
 	s.router.GET("/api/v1/users", s.listUsers)
@@ -217,11 +315,11 @@ func (s *Server) registerRoutes(env string) {
 	s.router.GET("/api/v1/walls", s.listWalls)
 
 	s.router.GET("/api/v1/posts/:id", s.getPost)                                    
+	s.router.GET("/api/v1/posts/:id/strokes", s.getPostStrokes)                     
 	s.router.GET("/api/v1/posts", s.listPosts)                                      
 	s.router.GET("/api/v1/walls/:id/posts", s.listPostsByWall)                       
 	s.router.GET("/api/v1/posts/highlighted", s.getHighlightedPosts)                 
 	s.router.GET("/api/v1/walls/:id/posts/highlighted", s.getHighlightedPostsByWall) 
-	s.router.PUT("/api/v1/posts/:id", s.updatePost)                                  
 	s.router.PUT("/api/v1/posts/:id/highlight", s.highlightPost)                    
 	s.router.PUT("/api/v1/posts/:id/unhighlight", s.unhighlightPost)                
 
@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+type muteWallRequest struct {
+	Muted *bool `json:"muted" binding:"required"`
+}
+
+type wallSubscriptionResponse struct {
+	WallID    string    `json:"wall_id"`
+	UserID    string    `json:"user_id"`
+	Muted     bool      `json:"muted"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type followedWallResponse struct {
+	wallResponse
+	Muted bool `json:"muted"`
+}
+
+func newWallSubscriptionResponse(subscription db.WallSubscription) wallSubscriptionResponse {
+	return wallSubscriptionResponse{
+		WallID:    subscription.WallID.String(),
+		UserID:    subscription.UserID.String(),
+		Muted:     subscription.Muted,
+		CreatedAt: subscription.CreatedAt.Time,
+	}
+}
+
+// notifyWallFollowers tells everyone following a public wall about a new post.
+// The author and followers who muted the wall are skipped.
+func (s *Server) notifyWallFollowers(ctx context.Context, wall db.Wall, post db.Post) error {
+	if !wall.IsPublic.Bool || post.Status != db.PostStatusApproved {
+		return nil
+	}
+
+	followers, err := s.hub.ListWallFollowersToNotify(ctx, db.ListWallFollowersToNotifyParams{
+		WallID: wall.ID,
+		UserID: post.Author,
+	})
+	if err != nil {
+		return err
+	}
+	if len(followers) == 0 {
+		return nil
+	}
+
+	go func(followers []pgtype.UUID) {
+		bgCtx := context.Background()
+		message := fmt.Sprintf("New post on \"%s\"", wall.Title)
+
+		for _, follower := range followers {
+			if err := s.SendNotification(bgCtx, follower.String(), postNotificationSender(post, follower.String()), "followed_wall_post", wall.ID.String(), message); err != nil {
+				logger.Global().Error("Failed to send followed wall notification", err)
+			}
+		}
+	}(followers)
+
+	return nil
+}
+
+// FollowWall handler
+func (s *Server) followWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received follow wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.IsDeleted.Bool || !wall.IsPublic.Bool {
+		log.Error("Wall cannot be followed", errors.New("only public walls can be followed"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("only public walls can be followed")))
+		return
+	}
+
+	if wall.UserID == currentUser.ID {
+		log.Error("Cannot follow own wall", errors.New("cannot follow your own wall"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("cannot follow your own wall")))
+		return
+	}
+
+	blocked, err := s.hub.IsUserBlockedTx(ctx, wall.UserID, currentUser.ID)
+	if err != nil {
+		log.Error("Failed to check block status", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if blocked {
+		log.Error("Unauthorized to follow wall", errors.New("user not authorized to follow this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to follow this wall")))
+		return
+	}
+
+	subscription, err := s.hub.FollowWallTx(ctx, id, currentUser.ID)
+	if err != nil {
+		log.Error("Failed to follow wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall followed successfully")
+	ctx.JSON(http.StatusOK, newWallSubscriptionResponse(subscription))
+}
+
+// UnfollowWall handler
+func (s *Server) unfollowWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received unfollow wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := s.hub.UnfollowWallTx(ctx, id, currentUser.ID); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Not following wall", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("not following this wall")))
+			return
+		}
+		log.Error("Failed to unfollow wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall unfollowed successfully")
+	ctx.JSON(http.StatusOK, gin.H{"message": "Wall unfollowed successfully"})
+}
+
+// MuteWall handler turns notifications for a followed wall on or off
+func (s *Server) muteWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received mute wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req muteWallRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscription, err := s.hub.SetWallSubscriptionMuted(ctx, db.SetWallSubscriptionMutedParams{
+		WallID: id,
+		UserID: currentUser.ID,
+		Muted:  *req.Muted,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Not following wall", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("not following this wall")))
+			return
+		}
+		log.Error("Failed to update wall subscription", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall subscription updated successfully")
+	ctx.JSON(http.StatusOK, newWallSubscriptionResponse(subscription))
+}
+
+// ListFollowedWalls handler
+func (s *Server) listFollowedWalls(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list followed walls request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	walls, err := s.hub.ListFollowedWalls(ctx, currentUser.ID)
+	if err != nil {
+		log.Error("Failed to list followed walls", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]followedWallResponse, 0, len(walls))
+	for _, wall := range walls {
+		rsp = append(rsp, followedWallResponse{
+			wallResponse: newWallResponse(db.Wall{
+				ID:                wall.ID,
+				UserID:            wall.UserID,
+				Title:             wall.Title,
+				Description:       wall.Description,
+				BackgroundImage:   wall.BackgroundImage,
+				IsPublic:          wall.IsPublic,
+				IsArchived:        wall.IsArchived,
+				IsDeleted:         wall.IsDeleted,
+				PopularityScore:   wall.PopularityScore,
+				CreatedAt:         wall.CreatedAt,
+				UpdatedAt:         wall.UpdatedAt,
+				IsPinned:          wall.IsPinned,
+				ModerationEnabled: wall.ModerationEnabled,
+				DeletedAt:         wall.DeletedAt,
+				FollowerCount:     wall.FollowerCount,
+				PinOrder:          wall.PinOrder,
+				ArchiveWarnedAt:   wall.ArchiveWarnedAt,
+				UnarchivedAt:      wall.UnarchivedAt,
+			}),
+			Muted: wall.Muted,
+		})
+	}
+
+	log.Info("Followed walls retrieved successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
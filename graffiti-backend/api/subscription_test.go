@@ -0,0 +1,349 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestFollowWallAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	follower, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	privateWall := wall
+	privateWall.IsPublic = pgtype.Bool{Bool: false, Valid: true}
+
+	subscription := db.WallSubscription{WallID: wall.ID, UserID: follower.ID}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: follower,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					IsUserBlockedTx(gomock.Any(), owner.ID, follower.ID).
+					Times(1).
+					Return(false, nil)
+				mockHub.EXPECT().
+					FollowWallTx(gomock.Any(), wall.ID, follower.ID).
+					Times(1).
+					Return(subscription, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp wallSubscriptionResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, wall.ID.String(), rsp.WallID)
+				require.Equal(t, follower.ID.String(), rsp.UserID)
+				require.False(t, rsp.Muted)
+			},
+		},
+		{
+			name:        "BadRequest_OwnWall",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					FollowWallTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "BadRequest_PrivateWall",
+			currentUser: follower,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(privateWall, nil)
+				mockHub.EXPECT().
+					FollowWallTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized_Blocked",
+			currentUser: follower,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					IsUserBlockedTx(gomock.Any(), owner.ID, follower.ID).
+					Times(1).
+					Return(true, nil)
+				mockHub.EXPECT().
+					FollowWallTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: follower,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.Wall{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/walls/:id/follow", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.followWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/follow", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUnfollowWallAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	follower, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					UnfollowWallTx(gomock.Any(), wall.ID, follower.ID).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFollowing",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					UnfollowWallTx(gomock.Any(), wall.ID, follower.ID).
+					Times(1).
+					Return(db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.DELETE("/test/walls/:id/follow", func(ctx *gin.Context) {
+				ctx.Set("currentUser", follower)
+				server.unfollowWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/follow", wall.ID.String())
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestMuteWallAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	follower, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"muted": true},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallSubscriptionMuted(gomock.Any(), db.SetWallSubscriptionMutedParams{
+						WallID: wall.ID,
+						UserID: follower.ID,
+						Muted:  true,
+					}).
+					Times(1).
+					Return(db.WallSubscription{WallID: wall.ID, UserID: follower.ID, Muted: true}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFollowing",
+			body: gin.H{"muted": false},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallSubscriptionMuted(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WallSubscription{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_MissingMuted",
+			body: gin.H{},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallSubscriptionMuted(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/follow/mute", func(ctx *gin.Context) {
+				ctx.Set("currentUser", follower)
+				server.muteWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/test/walls/%s/follow/mute", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListFollowedWallsAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	follower, _ := randomUser(t)
+	wall := randomWall(t, owner.ID)
+	wall.FollowerCount = 3
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListFollowedWalls(gomock.Any(), follower.ID).
+		Times(1).
+		Return([]db.ListFollowedWallsRow{{
+			ID:            wall.ID,
+			UserID:        wall.UserID,
+			Title:         wall.Title,
+			IsPublic:      wall.IsPublic,
+			FollowerCount: wall.FollowerCount,
+			Muted:         true,
+		}}, nil)
+
+	server.router.GET("/test/walls/followed", func(ctx *gin.Context) {
+		ctx.Set("currentUser", follower)
+		server.listFollowedWalls(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/test/walls/followed", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	data, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+
+	var rsp []followedWallResponse
+	require.NoError(t, json.Unmarshal(data, &rsp))
+	require.Len(t, rsp, 1)
+	require.Equal(t, wall.ID.String(), rsp[0].ID)
+	require.Equal(t, int32(3), rsp[0].FollowerCount)
+	require.True(t, rsp[0].Muted)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const defaultTagSuggestions = 10
+
+type searchTagsRequest struct {
+	Query string `form:"q"`
+	Limit int32  `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type tagResponse struct {
+	Name       string `json:"name"`
+	UsageCount int32  `json:"usage_count"`
+}
+
+type tagWallsResponse struct {
+	Tag      string         `json:"tag"`
+	Page     int32          `json:"page"`
+	PageSize int32          `json:"page_size"`
+	HasMore  bool           `json:"has_more"`
+	Walls    []wallResponse `json:"walls"`
+}
+
+func tagNames(tags []db.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// ListWallsByTag handler pages through public walls with a tag, most popular first
+func (s *Server) listWallsByTag(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list walls by tag request")
+
+	var uri struct {
+		Tag string `uri:"tag" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req paginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	tag, err := util.NormalizeTag(uri.Tag)
+	if err != nil {
+		log.Error("Invalid tag", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Fetch one extra wall to know whether there is another page
+	walls, err := s.hub.ListPublicWallsByTag(ctx, db.ListPublicWallsByTagParams{
+		Name:   tag,
+		Limit:  req.PageSize + 1,
+		Offset: req.offset(),
+	})
+	if err != nil {
+		log.Error("Failed to list walls by tag", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := tagWallsResponse{
+		Tag:      tag,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		HasMore:  len(walls) > int(req.PageSize),
+		Walls:    make([]wallResponse, 0, len(walls)),
+	}
+	if rsp.HasMore {
+		walls = walls[:req.PageSize]
+	}
+	for _, wall := range walls {
+		rsp.Walls = append(rsp.Walls, newWallResponse(wall))
+	}
+
+	log.Info("Walls by tag listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// SearchTags handler suggests tags starting with the query, most used first.
+// An empty query returns the most used tags overall.
+func (s *Server) searchTags(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received search tags request")
+
+	var req searchTagsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultTagSuggestions
+	}
+
+	var prefix string
+	if req.Query != "" {
+		var err error
+		prefix, err = util.NormalizeTag(req.Query)
+		if err != nil {
+			// Nothing can match a prefix that isn't a valid tag
+			ctx.JSON(http.StatusOK, []tagResponse{})
+			return
+		}
+	}
+
+	// '_' is a LIKE wildcard, so match it literally
+	tags, err := s.hub.SearchTags(ctx, db.SearchTagsParams{
+		Prefix:     strings.ReplaceAll(prefix, "_", `\_`),
+		MaxResults: req.Limit,
+	})
+	if err != nil {
+		log.Error("Failed to search tags", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]tagResponse, 0, len(tags))
+	for _, tag := range tags {
+		rsp = append(rsp, tagResponse{
+			Name:       tag.Name,
+			UsageCount: tag.UsageCount,
+		})
+	}
+
+	log.Info("Tags searched successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func TestListWallsByTagAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	walls := make([]db.Wall, 3)
+	for i := range walls {
+		walls[i] = randomWall(t, user.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		tag           string
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK_HasMore",
+			tag:   "Street%20Art",
+			query: "?page=2&page_size=2",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPublicWallsByTag(gomock.Any(), db.ListPublicWallsByTagParams{
+						Name:   "street-art",
+						Limit:  3,
+						Offset: 2,
+					}).
+					Times(1).
+					Return(walls, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp tagWallsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, "street-art", rsp.Tag)
+				require.Equal(t, int32(2), rsp.Page)
+				require.True(t, rsp.HasMore)
+				require.Len(t, rsp.Walls, 2)
+				require.Equal(t, walls[0].ID.String(), rsp.Walls[0].ID)
+			},
+		},
+		{
+			name: "OK_LastPage",
+			tag:  "travel",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPublicWallsByTag(gomock.Any(), db.ListPublicWallsByTagParams{
+						Name:   "travel",
+						Limit:  defaultPageSize + 1,
+						Offset: 0,
+					}).
+					Times(1).
+					Return(walls, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp tagWallsResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.False(t, rsp.HasMore)
+				require.Len(t, rsp.Walls, 3)
+			},
+		},
+		{
+			name:  "BadRequest_PageSize",
+			tag:   "travel",
+			query: "?page_size=500",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPublicWallsByTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_InvalidTag",
+			tag:  "c++",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListPublicWallsByTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/tags/:tag/walls", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.listWallsByTag(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/tags/%s/walls%s", tc.tag, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSearchTagsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?q=%23Tra",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SearchTags(gomock.Any(), db.SearchTagsParams{
+						Prefix:     "tra",
+						MaxResults: defaultTagSuggestions,
+					}).
+					Times(1).
+					Return([]db.Tag{
+						{Name: "travel", UsageCount: 12},
+						{Name: "trains", UsageCount: 3},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp []tagResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Len(t, rsp, 2)
+				require.Equal(t, "travel", rsp[0].Name)
+				require.Equal(t, int32(12), rsp[0].UsageCount)
+			},
+		},
+		{
+			name:  "OK_EmptyQuery",
+			query: "?limit=5",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SearchTags(gomock.Any(), db.SearchTagsParams{
+						Prefix:     "",
+						MaxResults: 5,
+					}).
+					Times(1).
+					Return([]db.Tag{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OK_InvalidQuery",
+			query: "?q=c%2B%2B",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SearchTags(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, "[]", recorder.Body.String())
+			},
+		},
+		{
+			name:  "BadRequest_Limit",
+			query: "?limit=100",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SearchTags(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/tags", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.searchTags(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/test/tags"+tc.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type wallTemplateResponse struct {
+	ID                string   `json:"id"`
+	Slug              string   `json:"slug"`
+	Title             string   `json:"title"`
+	Description       string   `json:"description,omitempty"`
+	BackgroundImage   string   `json:"background_image,omitempty"`
+	ModerationEnabled bool     `json:"moderation_enabled"`
+	Tags              []string `json:"tags"`
+}
+
+func (s *Server) newWallTemplateResponse(template db.WallTemplate) wallTemplateResponse {
+	rsp := wallTemplateResponse{
+		ID:                template.ID.String(),
+		Slug:              template.Slug,
+		Title:             template.Title,
+		Description:       template.Description.String,
+		ModerationEnabled: template.ModerationEnabled,
+		Tags:              template.Tags,
+	}
+	if template.BackgroundKey.Valid {
+		rsp.BackgroundImage = s.publicURL(template.BackgroundKey.String)
+	}
+	if rsp.Tags == nil {
+		rsp.Tags = []string{}
+	}
+	return rsp
+}
+
+// applyWallTemplate fills in whatever the request left blank from the template
+func (s *Server) applyWallTemplate(arg *db.CreateTestWallParams, template db.WallTemplate) {
+	if arg.Title == "" {
+		arg.Title = template.Title
+	}
+	if !arg.Description.Valid && template.Description.Valid {
+		arg.Description = template.Description
+	}
+	if !arg.BackgroundImage.Valid && template.BackgroundKey.Valid {
+		arg.BackgroundImage = pgtype.Text{String: s.publicURL(template.BackgroundKey.String), Valid: true}
+	}
+}
+
+// ListWallTemplates handler
+func (s *Server) listWallTemplates(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list wall templates request")
+
+	templates, err := s.hub.ListWallTemplates(ctx)
+	if err != nil {
+		log.Error("Failed to list wall templates", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]wallTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		rsp = append(rsp, s.newWallTemplateResponse(template))
+	}
+
+	log.Info("Wall templates listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// createWallFromTemplate finishes createNewWall when the request names a template.
+// Fields set in the request win over the template's.
+func (s *Server) createWallFromTemplate(ctx *gin.Context, arg db.CreateTestWallParams, tags []string, templateID string) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+
+	var id pgtype.UUID
+	if err := id.Scan(templateID); err != nil {
+		log.Error("Invalid template ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	template, err := s.hub.GetWallTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall template not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("wall template not found")))
+			return
+		}
+		log.Error("Failed to get wall template", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	s.applyWallTemplate(&arg, template)
+	if len(tags) == 0 {
+		tags = template.Tags
+	}
+
+	result, err := s.hub.CreateWallTx(ctx, db.CreateWallTxParams{
+		Wall:              arg,
+		ModerationEnabled: template.ModerationEnabled,
+		Tags:              tags,
+	})
+	if err != nil {
+		log.Error("Failed to create wall from template", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newWallResponse(result.Wall)
+	rsp.Tags = tagNames(result.Tags)
+
+	log.Info("Wall created from template successfully")
+	ctx.JSON(http.StatusCreated, rsp)
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func randomWallTemplate(t *testing.T) db.WallTemplate {
+	var id pgtype.UUID
+	require.NoError(t, id.Scan(uuid.New().String()))
+
+	return db.WallTemplate{
+		ID:                id,
+		Slug:              "birthday",
+		Title:             "Happy Birthday!",
+		Description:       pgtype.Text{String: "Leave your wishes here.", Valid: true},
+		BackgroundKey:     pgtype.Text{String: "templates/birthday.jpg", Valid: true},
+		ModerationEnabled: true,
+		Tags:              []string{"birthday", "celebration"},
+	}
+}
+
+func TestListWallTemplatesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	template := randomWallTemplate(t)
+
+	server := newTestServer(t)
+	mockHub, ok := server.hub.(*mockdb.MockHub)
+	require.True(t, ok)
+
+	mockHub.EXPECT().
+		ListWallTemplates(gomock.Any()).
+		Times(1).
+		Return([]db.WallTemplate{template}, nil)
+
+	server.router.GET("/test/wall-templates", func(ctx *gin.Context) {
+		ctx.Set("currentUser", user)
+		server.listWallTemplates(ctx)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/test/wall-templates", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	data, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+
+	var rsp []wallTemplateResponse
+	require.NoError(t, json.Unmarshal(data, &rsp))
+	require.Len(t, rsp, 1)
+	require.Equal(t, template.ID.String(), rsp[0].ID)
+	require.Equal(t, server.publicURL("templates/birthday.jpg"), rsp[0].BackgroundImage)
+	require.Equal(t, template.Tags, rsp[0].Tags)
+}
+
+func TestCreateWallFromTemplateAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	template := randomWallTemplate(t)
+	wall := randomWall(t, user.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(server *Server, mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"template_id": template.ID.String(), "is_public": true},
+			setupMock: func(server *Server, mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallTemplate(gomock.Any(), template.ID).
+					Times(1).
+					Return(template, nil)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), db.CreateWallTxParams{
+						Wall: db.CreateTestWallParams{
+							UserID:          user.ID,
+							Title:           template.Title,
+							Description:     template.Description,
+							IsPublic:        pgtype.Bool{Bool: true, Valid: true},
+							BackgroundImage: pgtype.Text{String: server.publicURL("templates/birthday.jpg"), Valid: true},
+						},
+						ModerationEnabled: true,
+						Tags:              template.Tags,
+					}).
+					Times(1).
+					Return(db.CreateWallTxResult{
+						Wall: wall,
+						Tags: []db.Tag{{Name: "birthday"}, {Name: "celebration"}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp wallResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, wall.ID.String(), rsp.ID)
+				require.Equal(t, []string{"birthday", "celebration"}, rsp.Tags)
+			},
+		},
+		{
+			name: "OK_RequestOverridesTemplate",
+			body: gin.H{
+				"template_id": template.ID.String(),
+				"title":       "Mum's 60th",
+				"tags":        []string{"family"},
+			},
+			setupMock: func(server *Server, mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallTemplate(gomock.Any(), template.ID).
+					Times(1).
+					Return(template, nil)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateWallTxParams) (db.CreateWallTxResult, error) {
+						require.Equal(t, "Mum's 60th", arg.Wall.Title)
+						require.Equal(t, template.Description, arg.Wall.Description)
+						require.Equal(t, []string{"family"}, arg.Tags)
+						return db.CreateWallTxResult{Wall: wall}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"template_id": template.ID.String()},
+			setupMock: func(server *Server, mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallTemplate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WallTemplate{}, db.ErrRecordNotFound)
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_NoTitleOrTemplate",
+			body: gin.H{"description": "no title"},
+			setupMock: func(server *Server, mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWallTemplate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(server, mockHub)
+
+			server.router.POST("/test/walls", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createNewWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/test/walls", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
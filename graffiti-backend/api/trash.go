@@ -0,0 +1,295 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+type trashWallResponse struct {
+	wallResponse
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+type trashPostResponse struct {
+	postResponse
+	WallTitle string    `json:"wall_title"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+type trashResponse struct {
+	Walls []trashWallResponse `json:"walls"`
+	Posts []trashPostResponse `json:"posts"`
+}
+
+func (s *Server) newTrashWallResponse(wall db.Wall) trashWallResponse {
+	return trashWallResponse{
+		wallResponse: newWallResponse(wall),
+		DeletedAt:    wall.DeletedAt.Time,
+		PurgeAt:      wall.DeletedAt.Time.Add(s.purgeRetention()),
+	}
+}
+
+func (s *Server) newTrashPostResponse(post db.ListDeletedPostsByUserRow, viewer pgtype.UUID) trashPostResponse {
+	// The wall owner sees anonymous posts in their trash without the author
+	return trashPostResponse{
+		postResponse: newPostResponseFor(db.Post{
+			ID:             post.ID,
+			WallID:         post.WallID,
+			Author:         post.Author,
+			MediaUrl:       post.MediaUrl,
+			PostType:       post.PostType,
+			IsHighlighted:  post.IsHighlighted,
+			LikesCount:     post.LikesCount,
+			IsDeleted:      post.IsDeleted,
+			CreatedAt:      post.CreatedAt,
+			PosX:           post.PosX,
+			PosY:           post.PosY,
+			Rotation:       post.Rotation,
+			Scale:          post.Scale,
+			ZIndex:         post.ZIndex,
+			LayoutVersion:  post.LayoutVersion,
+			Status:         post.Status,
+			DeletedAt:      post.DeletedAt,
+			SectionID:      post.SectionID,
+			Caption:        post.Caption,
+			CommentsCount:  post.CommentsCount,
+			ReactionCounts: post.ReactionCounts,
+			EditedAt:       post.EditedAt,
+			IsHidden:       post.IsHidden,
+			PublishAt:      post.PublishAt,
+			IsAnonymous:    post.IsAnonymous,
+		}, viewer),
+		WallTitle: post.WallTitle,
+		DeletedAt: post.DeletedAt.Time,
+		PurgeAt:   post.DeletedAt.Time.Add(s.purgeRetention()),
+	}
+}
+
+// withinRetention reports whether soft-deleted content can still be restored
+func (s *Server) withinRetention(deletedAt pgtype.Timestamp) bool {
+	return !deletedAt.Valid || time.Since(deletedAt.Time) < s.purgeRetention()
+}
+
+// GetTrash handler lists the current user's walls and posts that can still be restored
+func (s *Server) getTrash(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received get trash request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	cutoff := pgtype.Timestamp{Time: time.Now().Add(-s.purgeRetention()), Valid: true}
+
+	walls, err := s.hub.ListDeletedWallsByUser(ctx, db.ListDeletedWallsByUserParams{
+		UserID:    currentUser.ID,
+		DeletedAt: cutoff,
+	})
+	if err != nil {
+		log.Error("Failed to list deleted walls", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	posts, err := s.hub.ListDeletedPostsByUser(ctx, db.ListDeletedPostsByUserParams{
+		Author:    currentUser.ID,
+		DeletedAt: cutoff,
+	})
+	if err != nil {
+		log.Error("Failed to list deleted posts", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := trashResponse{
+		Walls: make([]trashWallResponse, 0, len(walls)),
+		Posts: make([]trashPostResponse, 0, len(posts)),
+	}
+	for _, wall := range walls {
+		rsp.Walls = append(rsp.Walls, s.newTrashWallResponse(wall))
+	}
+	for _, post := range posts {
+		rsp.Posts = append(rsp.Posts, s.newTrashPostResponse(post, currentUser.ID))
+	}
+
+	log.Info("Trash retrieved successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// RestoreWall handler
+func (s *Server) restoreWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received restore wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to restore wall", errors.New("user not authorized to restore this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to restore this wall")))
+		return
+	}
+
+	if !wall.IsDeleted.Bool {
+		log.Error("Wall is not deleted", errors.New("wall is not in the trash"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("wall is not in the trash")))
+		return
+	}
+
+	if !s.withinRetention(wall.DeletedAt) {
+		log.Error("Wall is past the retention window", errors.New("wall can no longer be restored"))
+		ctx.JSON(http.StatusGone, errorResponse(errors.New("wall can no longer be restored")))
+		return
+	}
+
+	restored, err := s.hub.RestoreWallTx(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall was already restored", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("wall is not in the trash")))
+			return
+		}
+		log.Error("Failed to restore wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall restored successfully")
+	ctx.JSON(http.StatusOK, newWallResponse(restored))
+}
+
+// RestorePost handler
+func (s *Server) restorePost(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received restore post request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	post, err := s.hub.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, post.WallID)
+	if err != nil {
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Whoever could delete the post can bring it back
+	if wall.UserID != currentUser.ID && post.Author != currentUser.ID {
+		log.Error("Unauthorized to restore post", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	if !post.IsDeleted.Bool {
+		log.Error("Post is not deleted", errors.New("post is not in the trash"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("post is not in the trash")))
+		return
+	}
+
+	if wall.IsDeleted.Bool {
+		log.Error("Wall is deleted", errors.New("restore the wall before restoring its posts"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("restore the wall before restoring its posts")))
+		return
+	}
+
+	if !s.withinRetention(post.DeletedAt) {
+		log.Error("Post is past the retention window", errors.New("post can no longer be restored"))
+		ctx.JSON(http.StatusGone, errorResponse(errors.New("post can no longer be restored")))
+		return
+	}
+
+	restored, err := s.hub.RestorePost(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Post was already restored", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("post is not in the trash")))
+			return
+		}
+		log.Error("Failed to restore post", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Post restored successfully")
+	ctx.JSON(http.StatusOK, newPostResponseFor(restored, currentUser.ID))
+}
@@ -0,0 +1,416 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+func deletedAt(ago time.Duration) (pgtype.Bool, pgtype.Timestamp) {
+	return pgtype.Bool{Bool: true, Valid: true}, pgtype.Timestamp{Time: time.Now().Add(-ago), Valid: true}
+}
+
+func TestGetTrashAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	wall.IsDeleted, wall.DeletedAt = deletedAt(time.Hour)
+
+	otherWall := randomWall(t, user.ID)
+	post := randomPost(t, otherWall.ID, user.ID)
+	post.IsDeleted, post.DeletedAt = deletedAt(time.Minute)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListDeletedWallsByUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListDeletedWallsByUserParams) ([]db.Wall, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, -defaultPurgeRetentionDays), arg.DeletedAt.Time, time.Minute)
+						return []db.Wall{wall}, nil
+					})
+				mockHub.EXPECT().
+					ListDeletedPostsByUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListDeletedPostsByUserRow{{
+						ID:        post.ID,
+						WallID:    post.WallID,
+						Author:    post.Author,
+						MediaUrl:  post.MediaUrl,
+						PostType:  post.PostType,
+						IsDeleted: post.IsDeleted,
+						DeletedAt: post.DeletedAt,
+						Status:    post.Status,
+						IsHidden:  true,
+						EditedAt:  post.DeletedAt,
+						WallTitle: otherWall.Title,
+					}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp struct {
+					Walls []struct {
+						ID      string    `json:"id"`
+						PurgeAt time.Time `json:"purge_at"`
+					} `json:"walls"`
+					Posts []struct {
+						ID        string     `json:"id"`
+						WallTitle string     `json:"wall_title"`
+						IsHidden  bool       `json:"is_hidden"`
+						EditedAt  *time.Time `json:"edited_at"`
+					} `json:"posts"`
+				}
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Len(t, rsp.Walls, 1)
+				require.Equal(t, wall.ID.String(), rsp.Walls[0].ID)
+				require.WithinDuration(t, wall.DeletedAt.Time.AddDate(0, 0, defaultPurgeRetentionDays), rsp.Walls[0].PurgeAt, time.Second)
+				require.Len(t, rsp.Posts, 1)
+				require.Equal(t, post.ID.String(), rsp.Posts[0].ID)
+				require.Equal(t, otherWall.Title, rsp.Posts[0].WallTitle)
+				require.True(t, rsp.Posts[0].IsHidden)
+				require.NotNil(t, rsp.Posts[0].EditedAt)
+			},
+		},
+		{
+			name: "InternalError",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					ListDeletedWallsByUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("connection lost"))
+				mockHub.EXPECT().
+					ListDeletedPostsByUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.GET("/test/trash", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.getTrash(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/test/trash", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRestoreWallAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	wall := randomWall(t, user.ID)
+	wall.IsDeleted, wall.DeletedAt = deletedAt(time.Hour)
+
+	expired := wall
+	expired.IsDeleted, expired.DeletedAt = deletedAt(time.Duration(defaultPurgeRetentionDays+1) * 24 * time.Hour)
+
+	restored := wall
+	restored.IsDeleted = pgtype.Bool{Bool: false, Valid: true}
+	restored.DeletedAt = pgtype.Timestamp{}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					RestoreWallTx(gomock.Any(), wall.ID).
+					Times(1).
+					Return(restored, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWallResponse(t, recorder.Body, restored)
+			},
+		},
+		{
+			name:        "Unauthorized",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					RestoreWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "NotDeleted",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(restored, nil)
+				mockHub.EXPECT().
+					RestoreWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Expired",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(expired, nil)
+				mockHub.EXPECT().
+					RestoreWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			currentUser: user,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(db.Wall{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/:id/restore", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.restoreWall(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/walls/%s/restore", wall.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRestorePostAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	author, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	wall := randomWall(t, owner.ID)
+	deletedWall := wall
+	deletedWall.IsDeleted, deletedWall.DeletedAt = deletedAt(time.Hour)
+
+	post := randomPost(t, wall.ID, author.ID)
+	post.IsDeleted, post.DeletedAt = deletedAt(time.Hour)
+
+	restored := post
+	restored.IsDeleted = pgtype.Bool{Bool: false, Valid: true}
+	restored.DeletedAt = pgtype.Timestamp{}
+
+	testCases := []struct {
+		name          string
+		currentUser   db.User
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK_Author",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					RestorePost(gomock.Any(), post.ID).
+					Times(1).
+					Return(restored, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchPostResponse(t, recorder.Body, restored)
+			},
+		},
+		{
+			name:        "OK_WallOwner",
+			currentUser: owner,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					RestorePost(gomock.Any(), post.ID).
+					Times(1).
+					Return(restored, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "Unauthorized",
+			currentUser: otherUser,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					RestorePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "WallDeleted",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(deletedWall, nil)
+				mockHub.EXPECT().
+					RestorePost(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "AlreadyRestored",
+			currentUser: author,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetPost(gomock.Any(), post.ID).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), wall.ID).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					RestorePost(gomock.Any(), post.ID).
+					Times(1).
+					Return(db.Post{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/posts/:id/restore", func(ctx *gin.Context) {
+				ctx.Set("currentUser", tc.currentUser)
+				server.restorePost(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/test/posts/%s/restore", post.ID.String())
+			request, err := http.NewRequest(http.MethodPut, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
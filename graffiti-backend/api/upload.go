@@ -1,8 +1,11 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strings"
@@ -14,9 +17,37 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// maxDownloadSize caps how much of a single object is read into memory
+const maxDownloadSize = 20 << 20
+
+// maxDeleteBatch is the most keys S3 accepts in one DeleteObjects call
+const maxDeleteBatch = 1000
+
+// maxUploadSize is the largest file a client can presign an upload for
+const maxUploadSize = 10 << 20
+
+// uploadContentTypes maps each allowed file extension to the content type it must be uploaded with
+var uploadContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+var (
+	errUploadNotOurs    = errors.New("media must be uploaded through the presign endpoint")
+	errUploadNotFound   = errors.New("upload not found")
+	errUploadNotOwner   = errors.New("user not authorized to use this upload")
+	errUploadIncomplete = errors.New("upload has not completed")
+	errUploadMismatch   = errors.New("uploaded file does not match what was presigned")
 )
 
 type PresignRequest struct {
@@ -36,21 +67,24 @@ func (s *Server) presignHandler(ctx *gin.Context) {
 
 	user := ctx.MustGet("currentUser").(db.User)
 
-	// Validate file type (optional)
+	// Validate file type
 	ext := getFileExtension(req.Filename)
-	allowedExts := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-	}
+	contentType, allowed := uploadContentTypes[ext]
 
-	if !allowedExts[ext] {
+	if !allowed || req.ContentType != contentType {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"message": "Invalid file type. Only images are allowed.",
 		})
 		return
 	}
 
+	if req.FileSize <= 0 || req.FileSize > maxUploadSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"message": fmt.Sprintf("Invalid file size. Files must be at most %d MB.", maxUploadSize>>20),
+		})
+		return
+	}
+
 	// Generate unique filename
 	filename := uuid.New().String() + ext
 	key := "uploads/" + filename
@@ -64,7 +98,7 @@ func (s *Server) presignHandler(ctx *gin.Context) {
 	}
 
 	// Get presigned URL
-	presignedURL, err := s.generatePresignedURL(key, req.ContentType)
+	presignedURL, err := s.generatePresignedURL(key, req.ContentType, req.FileSize)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"message": "Failed to generate presigned URL: " + err.Error(),
@@ -72,9 +106,24 @@ func (s *Server) presignHandler(ctx *gin.Context) {
 		return
 	}
 
+	// Remember who asked for post media so only they can post it, and only once
+	if purgeableKey(key) {
+		_, err = s.hub.CreateUpload(ctx, db.CreateUploadParams{
+			Key:         key,
+			UserID:      user.ID,
+			ContentType: req.ContentType,
+			FileSize:    req.FileSize,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"message": "Failed to record upload: " + err.Error(),
+			})
+			return
+		}
+	}
+
 	// Public URL that will be accessible after upload
-	cloudFrontDomain := s.config.CloudfrontDomain
-	publicURL := fmt.Sprintf("https://%s/%s", cloudFrontDomain, key)
+	publicURL := s.publicURL(key)
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"presignedUrl": presignedURL,
@@ -83,6 +132,66 @@ func (s *Server) presignHandler(ctx *gin.Context) {
 	})
 }
 
+// publicURL returns the CloudFront URL an S3 key is served from
+func (s *Server) publicURL(key string) string {
+	return fmt.Sprintf("https://%s/%s", s.config.CloudfrontDomain, key)
+}
+
+// checkPostUpload makes sure mediaURL is a finished upload of the user's own
+// that no other post uses, and returns the key to claim for postID. An upload
+// postID already claimed needs no claiming, so its key comes back empty.
+// It writes the error response itself and returns false if the media can't be used.
+func (s *Server) checkPostUpload(ctx *gin.Context, user db.User, mediaURL string, postID pgtype.UUID) (string, bool) {
+	log := logger.GetMetadata(ctx.Request.Context()).GetLogger()
+
+	key := util.ExtractKeyFromMediaURL(mediaURL)
+	if !strings.HasPrefix(mediaURL, s.publicURL("")) || !purgeableKey(key) {
+		log.Error("Invalid media URL", errUploadNotOurs)
+		ctx.JSON(http.StatusBadRequest, errorResponse(errUploadNotOurs))
+		return "", false
+	}
+
+	upload, err := s.hub.GetUpload(ctx, key)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Upload not found", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(errUploadNotFound))
+			return "", false
+		}
+		log.Error("Failed to get upload", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return "", false
+	}
+
+	if upload.UserID != user.ID {
+		log.Error("Unauthorized to use upload", errUploadNotOwner)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errUploadNotOwner))
+		return "", false
+	}
+
+	if upload.ClaimedAt.Valid {
+		if postID.Valid && upload.PostID == postID {
+			return "", true
+		}
+		log.Error("Upload already used", db.ErrUploadClaimed)
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrUploadClaimed))
+		return "", false
+	}
+
+	if err := s.checkUploadedObject(ctx, upload); err != nil {
+		if errors.Is(err, errUploadIncomplete) || errors.Is(err, errUploadMismatch) {
+			log.Error("Invalid upload", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return "", false
+		}
+		log.Error("Failed to check upload", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return "", false
+	}
+
+	return key, true
+}
+
 func getFileExtension(filename string) string {
 	parts := strings.Split(filename, ".")
 	if len(parts) < 2 {
@@ -91,7 +200,7 @@ func getFileExtension(filename string) string {
 	return "." + strings.ToLower(parts[len(parts)-1])
 }
 
-func (s *Server) generatePresignedURL(key, contentType string) (string, error) {
+func (s *Server) generatePresignedURL(key, contentType string, fileSize int64) (string, error) {
 	// Get AWS config
 	cfg, err := s.getAWSConfig()
 	if err != nil {
@@ -107,10 +216,12 @@ func (s *Server) generatePresignedURL(key, contentType string) (string, error) {
 	bucketName := s.config.AWSS3Bucket
 
 	// Set up the presign parameters
+	// Signing the length and type means S3 rejects any other file
 	putObjectInput := &s3.PutObjectInput{
-		Bucket:      aws.String(bucketName),
-		Key:         aws.String(key),
-		ContentType: aws.String(contentType),
+		Bucket:        aws.String(bucketName),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(fileSize),
 	}
 
 	// Generate the presigned URL with expiration
@@ -209,3 +320,195 @@ func (s *Server) DeleteFile(ctx context.Context, key string) error {
 
 	return nil
 }
+
+// DeleteFiles removes a batch of objects from S3 and invalidates them in CloudFront
+// with a single request, so bulk cleanups don't flood the distribution
+func (s *Server) DeleteFiles(ctx context.Context, keys []string) error {
+
+	if s.config.Env == "unit-test" || len(keys) == 0 {
+		return nil
+	}
+
+	cfg, err := s.getAWSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	for start := 0; start < len(keys); start += maxDeleteBatch {
+		end := min(start+maxDeleteBatch, len(keys))
+
+		objects := make([]s3types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, s3types.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		out, err := s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.config.AWSS3Bucket),
+			Delete: &s3types.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete objects from S3: %w", err)
+		}
+		if len(out.Errors) > 0 {
+			return fmt.Errorf("failed to delete %s from S3: %s", aws.ToString(out.Errors[0].Key), aws.ToString(out.Errors[0].Message))
+		}
+	}
+
+	paths := make([]string, 0, len(keys))
+	for _, key := range keys {
+		paths = append(paths, "/"+key)
+	}
+
+	cfClient := cloudfront.NewFromConfig(cfg)
+	callerReference := fmt.Sprintf("invalidate-purge-%d", time.Now().UnixNano())
+
+	_, err = cfClient.CreateInvalidation(ctx, &cloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(s.config.CloudfrontDistributionID),
+		InvalidationBatch: &types.InvalidationBatch{
+			CallerReference: aws.String(callerReference),
+			Paths: &types.Paths{
+				Quantity: aws.Int32(int32(len(paths))),
+				Items:    paths,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invalidate CloudFront after delete: %w", err)
+	}
+
+	log.Printf("Invalidation request for %d paths sent to CloudFront after delete", len(paths))
+	return nil
+}
+
+// downloadFile reads an object from S3 and returns its body and content type
+func (s *Server) downloadFile(ctx context.Context, key string) ([]byte, string, error) {
+
+	if s.config.Env == "unit-test" {
+		return nil, "", nil
+	}
+
+	cfg, err := s.getAWSConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.AWSS3Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get object from S3: %w", err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(out.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read object from S3: %w", err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, "", fmt.Errorf("object %s exceeds %d bytes", key, maxDownloadSize)
+	}
+
+	return data, aws.ToString(out.ContentType), nil
+}
+
+// uploadFile stores data in S3 under the given key
+func (s *Server) uploadFile(ctx context.Context, key, contentType string, data []byte) error {
+	return s.uploadReader(ctx, key, contentType, bytes.NewReader(data), int64(len(data)))
+}
+
+// uploadReader stores size bytes read from body in S3 under the given key, for
+// objects too big to hold in memory
+func (s *Server) uploadReader(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+
+	if s.config.Env == "unit-test" {
+		return nil
+	}
+
+	cfg, err := s.getAWSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.config.AWSS3Bucket),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object to S3: %w", err)
+	}
+
+	return nil
+}
+
+// copyFile duplicates an S3 object under a new key
+func (s *Server) copyFile(ctx context.Context, srcKey, dstKey string) error {
+
+	if s.config.Env == "unit-test" {
+		return nil
+	}
+
+	cfg, err := s.getAWSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	_, err = s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(s.config.AWSS3Bucket),
+		CopySource: aws.String(s.config.AWSS3Bucket + "/" + srcKey),
+		Key:        aws.String(dstKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy object in S3: %w", err)
+	}
+
+	return nil
+}
+
+// checkUploadedObject confirms an upload made it to S3 with the content type
+// and size it was presigned for
+func (s *Server) checkUploadedObject(ctx context.Context, upload db.Upload) error {
+
+	if s.config.Env == "unit-test" {
+		return nil
+	}
+
+	cfg, err := s.getAWSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	out, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.config.AWSS3Bucket),
+		Key:    aws.String(upload.Key),
+	})
+	if err != nil {
+		var notFound *s3types.NotFound
+		if errors.As(err, &notFound) {
+			return errUploadIncomplete
+		}
+		return fmt.Errorf("failed to head object in S3: %w", err)
+	}
+
+	if aws.ToString(out.ContentType) != upload.ContentType || aws.ToInt64(out.ContentLength) != upload.FileSize {
+		return errUploadMismatch
+	}
+
+	return nil
+}
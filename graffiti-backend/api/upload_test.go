@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/vittotedja/graffiti/graffiti-backend/db/mock"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+)
+
+const testCloudfrontDomain = "cdn.graffiti.test"
+
+// randomUploadURL returns the CDN URL a new post upload would be served from
+func randomUploadURL() string {
+	return fmt.Sprintf("https://%s/uploads/%s.jpg", testCloudfrontDomain, uuid.New().String())
+}
+
+// expectPostUpload serves uploads from the test CDN and lets any upload key
+// through as a finished, unclaimed upload of userID's
+func expectPostUpload(server *Server, mockHub *mockdb.MockHub, userID pgtype.UUID) {
+	server.config.CloudfrontDomain = testCloudfrontDomain
+	server.config.Env = "unit-test"
+
+	mockHub.EXPECT().
+		GetUpload(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ interface{}, key string) (db.Upload, error) {
+			return db.Upload{Key: key, UserID: userID, ContentType: "image/jpeg", FileSize: 1024}, nil
+		})
+}
+
+func TestPresignAPIValidation(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "BadRequest_Extension",
+			body: gin.H{"filename": "clip.gif", "content_type": "image/gif", "file_size": 1024},
+		},
+		{
+			name: "BadRequest_ContentTypeMismatch",
+			body: gin.H{"filename": "photo.png", "content_type": "image/jpeg", "file_size": 1024},
+		},
+		{
+			name: "BadRequest_Empty",
+			body: gin.H{"filename": "photo.png", "content_type": "image/png", "file_size": 0},
+		},
+		{
+			name: "BadRequest_TooLarge",
+			body: gin.H{"filename": "photo.jpg", "content_type": "image/jpeg", "file_size": maxUploadSize + 1},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().
+				CreateUpload(gomock.Any(), gomock.Any()).
+				Times(0)
+
+			server.router.POST("/test/presign", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.presignHandler(ctx)
+			})
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/presign", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
+
+func TestCreatePostUploadChecks(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	wall := randomWall(t, user.ID)
+	post := randomPost(t, wall.ID, user.ID)
+
+	mediaURL := randomUploadURL()
+	key := mediaURL[len("https://"+testCloudfrontDomain+"/"):]
+	upload := db.Upload{Key: key, UserID: user.ID, ContentType: "image/jpeg", FileSize: 1024}
+
+	testCases := []struct {
+		name          string
+		mediaURL      string
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK_ClaimsUpload",
+			mediaURL: mediaURL,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), key).
+					Times(1).
+					Return(upload, nil)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), key).
+					Times(1).
+					Return(post, nil)
+				mockHub.EXPECT().
+					ListWallFollowersToNotify(gomock.Any(), gomock.Any()).
+					Return([]pgtype.UUID{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:     "BadRequest_ExternalURL",
+			mediaURL: "https://example.com/uploads/photo.jpg",
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "BadRequest_NotAnUpload",
+			mediaURL: fmt.Sprintf("https://%s/profiles/%s.jpg", testCloudfrontDomain, otherUser.ID.String()),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "BadRequest_UnknownUpload",
+			mediaURL: mediaURL,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), key).
+					Times(1).
+					Return(db.Upload{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Unauthorized_SomeoneElsesUpload",
+			mediaURL: mediaURL,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				foreign := upload
+				foreign.UserID = otherUser.ID
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), key).
+					Times(1).
+					Return(foreign, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "Conflict_AlreadyUsed",
+			mediaURL: mediaURL,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				claimed := upload
+				claimed.PostID = post.ID
+				claimed.ClaimedAt = pgtype.Timestamp{Valid: true}
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), key).
+					Times(1).
+					Return(claimed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "Conflict_ClaimedConcurrently",
+			mediaURL: mediaURL,
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetUpload(gomock.Any(), key).
+					Times(1).
+					Return(upload, nil)
+				mockHub.EXPECT().
+					CreatePostTx(gomock.Any(), gomock.Any(), key).
+					Times(1).
+					Return(db.Post{}, db.ErrUploadClaimed)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.config.CloudfrontDomain = testCloudfrontDomain
+			server.config.Env = "unit-test"
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			mockHub.EXPECT().GetWall(gomock.Any(), wall.ID).Return(wall, nil)
+			mockHub.EXPECT().
+				GetDefaultWallSection(gomock.Any(), wall.ID).
+				Return(db.WallSection{}, db.ErrRecordNotFound)
+			tc.setupMock(mockHub)
+
+			server.router.POST("/test/posts", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.createPost(ctx)
+			})
+
+			data, err := json.Marshal(gin.H{
+				"wall_id":   wall.ID,
+				"media_url": tc.mediaURL,
+				"post_type": "media",
+			})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/test/posts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -25,6 +25,7 @@ type getUserResponse struct {
 	OnboardingAt    string `json:"onboarding_at,omitempty"`
 	CreatedAt       string `json:"created_at"`
 	UpdatedAt       string `json:"updated_at"`
+	Role            string `json:"role,omitempty"`
 }
 
 type updateUserNewRequest struct {
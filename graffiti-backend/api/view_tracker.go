@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+const (
+	// viewFlushInterval is how often buffered wall views are written to Postgres
+	viewFlushInterval = 30 * time.Second
+	// maxPendingViews triggers an early flush once this many views are buffered
+	maxPendingViews = 5000
+)
+
+type wallViewKey struct {
+	wallID   pgtype.UUID
+	viewerID pgtype.UUID
+	date     time.Time
+}
+
+// viewTracker buffers wall views in memory and writes them to Postgres in batches.
+// Views are deduplicated per viewer per wall per day, both in the buffer and by
+// the primary key on wall_views. Owners viewing their own wall and views by users
+// deleted since are dropped when the batch is written, so a stale view can't make
+// the whole batch fail. A nil tracker ignores every call.
+type viewTracker struct {
+	hub     db.Hub
+	mu      sync.Mutex
+	pending map[wallViewKey]struct{}
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+func newViewTracker(hub db.Hub) *viewTracker {
+	return &viewTracker{
+		hub:     hub,
+		pending: make(map[wallViewKey]struct{}),
+		flushCh: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// Record buffers a view of a wall by a user
+func (t *viewTracker) Record(wallID, viewerID pgtype.UUID) {
+	if t == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	key := wallViewKey{
+		wallID:   wallID,
+		viewerID: viewerID,
+		date:     time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+
+	t.mu.Lock()
+	t.pending[key] = struct{}{}
+	full := len(t.pending) >= maxPendingViews
+	t.mu.Unlock()
+
+	if full {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush writes all buffered views in a single batch insert.
+// If the insert fails the views are put back so the next flush retries them.
+func (t *viewTracker) Flush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = make(map[wallViewKey]struct{})
+	t.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	arg := db.RecordWallViewsParams{
+		WallIds:   make([]pgtype.UUID, 0, len(batch)),
+		ViewerIds: make([]pgtype.UUID, 0, len(batch)),
+		ViewDates: make([]pgtype.Date, 0, len(batch)),
+	}
+	for key := range batch {
+		arg.WallIds = append(arg.WallIds, key.wallID)
+		arg.ViewerIds = append(arg.ViewerIds, key.viewerID)
+		arg.ViewDates = append(arg.ViewDates, pgtype.Date{Time: key.date, Valid: true})
+	}
+
+	if err := t.hub.RecordWallViews(ctx, arg); err != nil {
+		t.mu.Lock()
+		for key := range batch {
+			if len(t.pending) >= maxPendingViews {
+				break
+			}
+			t.pending[key] = struct{}{}
+		}
+		t.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Start flushes the buffer periodically until Stop is called
+func (t *viewTracker) Start() {
+	if t == nil {
+		return
+	}
+
+	go func() {
+		defer close(t.doneCh)
+
+		ticker := time.NewTicker(viewFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.stopCh:
+				return
+			case <-ticker.C:
+			case <-t.flushCh:
+			}
+
+			if err := t.Flush(context.Background()); err != nil {
+				logger.Global().Error("Failed to flush wall views", err)
+			}
+		}
+	}()
+}
+
+// Stop ends the flush loop and writes whatever is still buffered
+func (t *viewTracker) Stop(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	close(t.stopCh)
+	<-t.doneCh
+
+	return t.Flush(ctx)
+}
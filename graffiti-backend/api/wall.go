@@ -1,9 +1,9 @@
 package api
 
 import (
-	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -15,50 +15,71 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxPinnedWalls is how many walls a user can pin to their profile
+const maxPinnedWalls = 3
+
 // Wall request/response types
 type createTestWallRequest struct {
-	Title           string `json:"title" binding:"required"`
-	Description     string `json:"description"`
-	BackgroundImage string `json:"background_image"`
-	IsPublic        bool   `json:"is_public"`
+	Title           string   `json:"title" binding:"required_without=TemplateID"`
+	Description     string   `json:"description"`
+	BackgroundImage string   `json:"background_image"`
+	IsPublic        bool     `json:"is_public"`
+	Tags            []string `json:"tags"`
+	TemplateID      string   `json:"template_id" binding:"omitempty,uuid"`
 }
 type wallResponse struct {
-	ID              string    `json:"id"`
-	UserID          string    `json:"user_id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description,omitempty"`
-	BackgroundImage string    `json:"background_image,omitempty"`
-	IsPublic        bool      `json:"is_public"`
-	IsArchived      bool      `json:"is_archived"`
-	IsDeleted       bool      `json:"is_deleted"`
-	PopularityScore float64   `json:"popularity_score"`
-	IsPinned        bool      `json:"is_pinned"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID                string    `json:"id"`
+	UserID            string    `json:"user_id"`
+	Title             string    `json:"title"`
+	Description       string    `json:"description,omitempty"`
+	BackgroundImage   string    `json:"background_image,omitempty"`
+	IsPublic          bool      `json:"is_public"`
+	IsArchived        bool      `json:"is_archived"`
+	IsDeleted         bool      `json:"is_deleted"`
+	IsHidden          bool      `json:"is_hidden"`
+	PopularityScore   float64   `json:"popularity_score"`
+	IsPinned          bool      `json:"is_pinned"`
+	PinOrder          int32     `json:"pin_order,omitempty"`
+	ModerationEnabled bool      `json:"moderation_enabled"`
+	AllowAnonymous    bool      `json:"allow_anonymous"`
+	FollowerCount     int32     `json:"follower_count"`
+	Tags              []string  `json:"tags,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type updateWallRequest struct {
-	Title           string  `json:"title"`
-	Description     *string `json:"description"`
-	BackgroundImage *string `json:"background_image"`
-	IsPublic        *bool   `json:"is_public"`
+	Title           string    `json:"title"`
+	Description     *string   `json:"description"`
+	BackgroundImage *string   `json:"background_image"`
+	IsPublic        *bool     `json:"is_public"`
+	Tags            *[]string `json:"tags"`
+}
+
+type setWallPinsRequest struct {
+	WallIDs []string `json:"wall_ids" binding:"unique,dive,uuid"`
 }
 
 // Convert DB wall to API response
 func newWallResponse(wall db.Wall) wallResponse {
 	return wallResponse{
-		ID:              wall.ID.String(),
-		UserID:          wall.UserID.String(),
-		Title:           wall.Title,
-		Description:     wall.Description.String,
-		BackgroundImage: wall.BackgroundImage.String,
-		IsPublic:        wall.IsPublic.Bool,
-		IsArchived:      wall.IsArchived.Bool,
-		IsDeleted:       wall.IsDeleted.Bool,
-		IsPinned:        wall.IsPinned.Bool,
-		PopularityScore: wall.PopularityScore.Float64,
-		CreatedAt:       wall.CreatedAt.Time,
-		UpdatedAt:       wall.UpdatedAt.Time,
+		ID:                wall.ID.String(),
+		UserID:            wall.UserID.String(),
+		Title:             wall.Title,
+		Description:       wall.Description.String,
+		BackgroundImage:   wall.BackgroundImage.String,
+		IsPublic:          wall.IsPublic.Bool,
+		IsArchived:        wall.IsArchived.Bool,
+		IsDeleted:         wall.IsDeleted.Bool,
+		IsHidden:          wall.IsHidden,
+		IsPinned:          wall.IsPinned.Bool,
+		PinOrder:          wall.PinOrder.Int32,
+		ModerationEnabled: wall.ModerationEnabled.Bool,
+		AllowAnonymous:    wall.AllowAnonymous,
+		FollowerCount:     wall.FollowerCount,
+		PopularityScore:   wall.PopularityScore.Float64,
+		CreatedAt:         wall.CreatedAt.Time,
+		UpdatedAt:         wall.UpdatedAt.Time,
 	}
 }
 
@@ -74,6 +95,13 @@ func (s *Server) createNewWall(ctx *gin.Context) {
 		return
 	}
 
+	tags, err := util.NormalizeTags(req.Tags)
+	if err != nil {
+		log.Error("Invalid tags", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	user := ctx.MustGet("currentUser").(db.User)
 
 	arg := db.CreateTestWallParams{
@@ -87,15 +115,26 @@ func (s *Server) createNewWall(ctx *gin.Context) {
 		},
 	}
 
-	wall, err := s.hub.CreateTestWall(ctx, arg)
+	if req.TemplateID != "" {
+		s.createWallFromTemplate(ctx, arg, tags, req.TemplateID)
+		return
+	}
+
+	result, err := s.hub.CreateWallTx(ctx, db.CreateWallTxParams{
+		Wall: arg,
+		Tags: tags,
+	})
 	if err != nil {
 		log.Error("Failed to create wall", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	rsp := newWallResponse(result.Wall)
+	rsp.Tags = tagNames(result.Tags)
+
 	log.Info("Wall created successfully")
-	ctx.JSON(http.StatusCreated, newWallResponse(wall))
+	ctx.JSON(http.StatusCreated, rsp)
 }
 
 // GetWall handler
@@ -104,7 +143,7 @@ func (s *Server) getWall(ctx *gin.Context) {
 	log := meta.GetLogger()
 	log.Info("Received get wall request")
 
-	_, ok := ctx.MustGet("currentUser").(db.User)
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
 	if !ok {
 		log.Error("Failed to get current user from context", errors.New("unauthorized"))
 		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
@@ -135,8 +174,29 @@ func (s *Server) getWall(ctx *gin.Context) {
 		return
 	}
 
+	// Walls hidden after being reported stay visible to their owner and moderators
+	if wall.IsHidden && wall.UserID != currentUser.ID && !isModerator(currentUser) {
+		log.Error("Wall is hidden", errors.New("wall not found"))
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("wall not found")))
+		return
+	}
+
+	if !wall.IsDeleted.Bool {
+		s.views.Record(wall.ID, currentUser.ID)
+	}
+
+	tags, err := s.hub.ListTagsByWall(ctx, wall.ID)
+	if err != nil {
+		log.Error("Failed to list wall tags", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newWallResponse(wall)
+	rsp.Tags = tagNames(tags)
+
 	log.Info("Wall retrieved successfully")
-	ctx.JSON(http.StatusOK, newWallResponse(wall))
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 func (s *Server) getOwnWall(ctx *gin.Context) {
@@ -243,12 +303,15 @@ func (s *Server) listWallsByUser(ctx *gin.Context) {
 	if isFriend {
 		// If friends, show both public and private walls
 		for _, wall := range walls {
+			if wall.IsHidden {
+				continue
+			}
 			filteredWalls = append(filteredWalls, newWallResponse(wall))
 		}
 	} else {
-		// If not friends, only show public walls
+		// If not friends, only show public walls, and hidden ones only to their owner
 		for _, wall := range walls {
-			if wall.IsPublic.Bool {
+			if wall.IsPublic.Bool && (!wall.IsHidden || wall.UserID == me.ID) {
 				filteredWalls = append(filteredWalls, newWallResponse(wall))
 			}
 		}
@@ -281,6 +344,17 @@ func (s *Server) updateWall(ctx *gin.Context) {
 		return
 	}
 
+	var tags []string
+	if req.Tags != nil {
+		var err error
+		tags, err = util.NormalizeTags(*req.Tags)
+		if err != nil {
+			log.Error("Invalid tags", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
 	var id pgtype.UUID
 	if err := id.Scan(uri.ID); err != nil {
 		log.Error("Invalid ID", err)
@@ -324,15 +398,29 @@ func (s *Server) updateWall(ctx *gin.Context) {
 		arg.IsPublic = pgtype.Bool{Bool: *req.IsPublic, Valid: true}
 	}
 
-	wall, err := s.hub.UpdateWall(ctx, arg)
+	// Tags are only replaced when the request includes them; an empty list clears them
+	txArg := db.UpdateWallTxParams{
+		Wall:    arg,
+		ActorID: currentUser.ID,
+	}
+	if req.Tags != nil {
+		txArg.Tags = &tags
+	}
+
+	result, err := s.hub.UpdateWallTx(ctx, txArg)
 	if err != nil {
 		log.Error("Failed to update wall", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	rsp := newWallResponse(result.Wall)
+	if req.Tags != nil {
+		rsp.Tags = tagNames(result.Tags)
+	}
+
 	log.Info("Wall updated successfully")
-	ctx.JSON(http.StatusOK, newWallResponse(wall))
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 // PublicizeWall handler
@@ -378,7 +466,7 @@ func (s *Server) publicizeWall(ctx *gin.Context) {
 		return
 	}
 
-	wall, err := s.hub.PublicizeWall(ctx, id)
+	wall, err := s.hub.SetWallVisibilityTx(ctx, id, user.ID, true)
 	if err != nil {
 		log.Error("Failed to publicize wall", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -432,7 +520,7 @@ func (s *Server) privatizeWall(ctx *gin.Context) {
 		return
 	}
 
-	wall, err := s.hub.PrivatizeWall(ctx, id)
+	wall, err := s.hub.SetWallVisibilityTx(ctx, id, user.ID, false)
 	if err != nil {
 		log.Error("Failed to privatize wall", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -624,42 +712,13 @@ func (s *Server) deleteWall(ctx *gin.Context) {
 		return
 	}
 
-	if err := s.hub.DeleteWall(ctx, id); err != nil {
+	// Posts are deleted along with the wall and come back with it on restore
+	if err := s.hub.DeleteWallTx(ctx, id); err != nil {
 		log.Error("Failed to delete wall", err)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	if currentWall.BackgroundImage.Valid {
-		key := util.ExtractKeyFromMediaURL(currentWall.BackgroundImage.String)
-		go func(key string) {
-			// Use a background context so it won't get canceled when request is done
-			bgCtx := context.Background()
-
-			if err := s.DeleteFile(bgCtx, key); err != nil {
-				log.Error("Failed to delete media from S3", err)
-			}
-		}(key)
-	}
-
-	go func(wallID pgtype.UUID) {
-		bgCtx := context.Background()
-
-		posts, err := s.hub.ListPostsByWall(bgCtx, wallID)
-		if err != nil {
-			log.Error("Failed to get posts by wall", err)
-			return
-		}
-
-		for _, post := range posts {
-			if err := s.hub.DeletePost(bgCtx, post.ID); err != nil {
-				log.Error("Failed to delete post", err)
-			}
-		}
-
-		log.Info("Posts deleted successfully")
-	}(id)
-
 	log.Info("Wall deleted successfully")
 	ctx.JSON(http.StatusOK, gin.H{"message": "Wall deleted successfully"})
 }
@@ -705,6 +764,21 @@ func (s *Server) pinWall(ctx *gin.Context) {
 		return
 	}
 
+	if !currentWall.IsPinned.Bool {
+		pinned, err := s.hub.CountPinnedWalls(ctx, currentUser.ID)
+		if err != nil {
+			log.Error("Failed to count pinned walls", err)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if pinned >= maxPinnedWalls {
+			err := fmt.Errorf("cannot pin more than %d walls", maxPinnedWalls)
+			log.Error("Pinned wall limit reached", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
 	wall, err := s.hub.PinUnpinWall(ctx, id)
 	if err != nil {
 		log.Error("Failed to update wall", err)
@@ -716,3 +790,66 @@ func (s *Server) pinWall(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, newWallResponse(wall))
 
 }
+
+// SetWallPins handler replaces the current user's pinned walls with the given walls, in order.
+// An empty list unpins every wall.
+func (s *Server) setWallPins(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received set wall pins request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var req setWallPinsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Error("Failed to bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if len(req.WallIDs) > maxPinnedWalls {
+		err := fmt.Errorf("cannot pin more than %d walls", maxPinnedWalls)
+		log.Error("Pinned wall limit reached", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wallIDs := make([]pgtype.UUID, len(req.WallIDs))
+	for i, wallID := range req.WallIDs {
+		if err := wallIDs[i].Scan(wallID); err != nil {
+			log.Error("Invalid wall ID", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	walls, err := s.hub.SetWallPinsTx(ctx, currentUser.ID, wallIDs)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrWallNotPinnable) {
+			log.Error("Wall cannot be pinned", err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		log.Error("Failed to set wall pins", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	responses := make([]wallResponse, 0, len(walls))
+	for _, wall := range walls {
+		responses = append(responses, newWallResponse(wall))
+	}
+
+	log.Info("Wall pins updated successfully")
+	ctx.JSON(http.StatusOK, responses)
+}
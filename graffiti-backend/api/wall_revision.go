@@ -0,0 +1,219 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/vittotedja/graffiti/graffiti-backend/db/sqlc"
+	"github.com/vittotedja/graffiti/graffiti-backend/util/logger"
+)
+
+// wallRevisionResponse is how a wall looked before the change made by the actor
+type wallRevisionResponse struct {
+	ID                  string    `json:"id"`
+	WallID              string    `json:"wall_id"`
+	ActorID             string    `json:"actor_id,omitempty"`
+	ActorUsername       string    `json:"actor_username,omitempty"`
+	ActorFullname       string    `json:"actor_fullname,omitempty"`
+	ActorProfilePicture string    `json:"actor_profile_picture,omitempty"`
+	Title               string    `json:"title"`
+	Description         string    `json:"description"`
+	BackgroundImage     string    `json:"background_image"`
+	IsPublic            bool      `json:"is_public"`
+	ChangedFields       []string  `json:"changed_fields"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type wallRevisionsResponse struct {
+	Page      int32                  `json:"page"`
+	PageSize  int32                  `json:"page_size"`
+	HasMore   bool                   `json:"has_more"`
+	Revisions []wallRevisionResponse `json:"revisions"`
+}
+
+func newWallRevisionResponse(revision db.ListWallRevisionsRow) wallRevisionResponse {
+	rsp := wallRevisionResponse{
+		ID:                  revision.ID.String(),
+		WallID:              revision.WallID.String(),
+		ActorUsername:       revision.ActorUsername.String,
+		ActorFullname:       revision.ActorFullname.String,
+		ActorProfilePicture: revision.ActorProfilePicture.String,
+		Title:               revision.Title,
+		Description:         revision.Description.String,
+		BackgroundImage:     revision.BackgroundImage.String,
+		IsPublic:            revision.IsPublic.Bool,
+		ChangedFields:       revision.ChangedFields,
+		CreatedAt:           revision.CreatedAt.Time,
+	}
+	if revision.ActorID.Valid {
+		rsp.ActorID = revision.ActorID.String()
+	}
+	if rsp.ChangedFields == nil {
+		rsp.ChangedFields = []string{}
+	}
+	return rsp
+}
+
+// ListWallRevisions handler returns a wall's edit history, newest first
+func (s *Server) listWallRevisions(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received list wall revisions request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req paginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Error("Failed to bind query", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	req.setDefaults()
+
+	var id pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to view wall history", errors.New("user not authorized to view the history of this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to view the history of this wall")))
+		return
+	}
+
+	// Fetch one extra revision to know whether there is another page
+	revisions, err := s.hub.ListWallRevisions(ctx, db.ListWallRevisionsParams{
+		WallID: id,
+		Limit:  req.PageSize + 1,
+		Offset: req.offset(),
+	})
+	if err != nil {
+		log.Error("Failed to list wall revisions", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := wallRevisionsResponse{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		HasMore:   len(revisions) > int(req.PageSize),
+		Revisions: make([]wallRevisionResponse, 0, len(revisions)),
+	}
+	if rsp.HasMore {
+		revisions = revisions[:req.PageSize]
+	}
+	for _, revision := range revisions {
+		rsp.Revisions = append(rsp.Revisions, newWallRevisionResponse(revision))
+	}
+
+	log.Info("Wall revisions listed successfully")
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// RevertWall handler puts a wall back the way it was before a revision
+func (s *Server) revertWall(ctx *gin.Context) {
+	meta := logger.GetMetadata(ctx.Request.Context())
+	log := meta.GetLogger()
+	log.Info("Received revert wall request")
+
+	currentUser, ok := ctx.MustGet("currentUser").(db.User)
+	if !ok {
+		log.Error("Failed to get current user from context", errors.New("unauthorized"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	var uri struct {
+		ID         string `uri:"id" binding:"required,uuid"`
+		RevisionID string `uri:"revision_id" binding:"required,uuid"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		log.Error("Failed to bind URI", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var id, revisionID pgtype.UUID
+	if err := id.Scan(uri.ID); err != nil {
+		log.Error("Invalid ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := revisionID.Scan(uri.RevisionID); err != nil {
+		log.Error("Invalid revision ID", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	wall, err := s.hub.GetWall(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Wall not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		log.Error("Failed to get wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if wall.UserID != currentUser.ID {
+		log.Error("Unauthorized to revert wall", errors.New("user not authorized to revert this wall"))
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("user not authorized to revert this wall")))
+		return
+	}
+
+	if wall.IsDeleted.Bool {
+		log.Error("Cannot revert deleted wall", errors.New("restore the wall before reverting it"))
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("restore the wall before reverting it")))
+		return
+	}
+
+	reverted, err := s.hub.RevertWallTx(ctx, id, revisionID, currentUser.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Error("Revision not found", err)
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("revision not found")))
+			return
+		}
+		log.Error("Failed to revert wall", err)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info("Wall reverted successfully")
+	ctx.JSON(http.StatusOK, newWallResponse(reverted))
+}
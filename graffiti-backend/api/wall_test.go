@@ -42,14 +42,16 @@ func TestCreateWallAPI(t *testing.T) {
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					CreateTestWall(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(_ interface{}, params db.CreateTestWallParams) (db.Wall, error) {
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, arg db.CreateWallTxParams) (db.CreateWallTxResult, error) {
+						params := arg.Wall
 						require.Equal(t, user.ID, params.UserID)
 						require.Equal(t, wall.Title, params.Title)
 						require.Equal(t, wall.Description.String, params.Description.String)
 						require.Equal(t, wall.BackgroundImage.String, params.BackgroundImage.String)
 						require.Equal(t, wall.IsPublic.Bool, params.IsPublic.Bool)
-						return wall, nil
+						require.Empty(t, arg.Tags)
+						return db.CreateWallTxResult{Wall: wall}, nil
 					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -57,6 +59,52 @@ func TestCreateWallAPI(t *testing.T) {
 				requireBodyMatchWallResponse(t, recorder.Body, wall)
 			},
 		},
+		{
+			name: "OK_WithTags",
+			body: gin.H{
+				"title":     wall.Title,
+				"is_public": true,
+				"tags":      []string{"#Street Art", "travel", "street art"},
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				// The wall and its tags are written together
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateWallTxParams) (db.CreateWallTxResult, error) {
+						require.Equal(t, []string{"street-art", "travel"}, arg.Tags)
+						return db.CreateWallTxResult{
+							Wall: wall,
+							Tags: []db.Tag{{Name: "street-art"}, {Name: "travel"}},
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp wallResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, []string{"street-art", "travel"}, rsp.Tags)
+			},
+		},
+		{
+			name: "BadRequest_TooManyTags",
+			body: gin.H{
+				"title": wall.Title,
+				"tags":  []string{"a", "b", "c", "d", "e", "f"},
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					CreateWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "BadRequest",
 			body: gin.H{
@@ -66,9 +114,9 @@ func TestCreateWallAPI(t *testing.T) {
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					CreateTestWall(gomock.Any(), gomock.Any()).
+					CreateWallTx(gomock.Any(), gomock.Any()).
 					Times(0).MaxTimes(1).
-					Return(db.Wall{}, sql.ErrConnDone)
+					Return(db.CreateWallTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -84,9 +132,9 @@ func TestCreateWallAPI(t *testing.T) {
 			},
 			setupMock: func(mockHub *mockdb.MockHub) {
 				mockHub.EXPECT().
-					CreateTestWall(gomock.Any(), gomock.Any()).
+					CreateWallTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.Wall{}, sql.ErrConnDone)
+					Return(db.CreateWallTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -149,10 +197,21 @@ func TestGetWallAPI(t *testing.T) {
 					GetWall(gomock.Any(), gomock.Eq(id)).
 					Times(1).
 					Return(wall, nil)
+				mockHub.EXPECT().
+					ListTagsByWall(gomock.Any(), gomock.Eq(id)).
+					Times(1).
+					Return([]db.Tag{{Name: "travel"}}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchWallResponse(t, recorder.Body, wall)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp wallResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Equal(t, wall.ID.String(), rsp.ID)
+				require.Equal(t, []string{"travel"}, rsp.Tags)
 			},
 		},
 		{
@@ -325,12 +384,15 @@ func TestUpdateWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					UpdateWall(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(_ interface{}, params db.UpdateWallParams) (db.Wall, error) {
+					UpdateWallTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, arg db.UpdateWallTxParams) (db.UpdateWallTxResult, error) {
+						params := arg.Wall
 						require.Equal(t, wall.ID.String(), params.ID.String())
 						require.Equal(t, newTitle, params.Title)
 						require.Equal(t, newDescription, params.Description.String)
-						return updatedWall, nil
+						// Tags left out of the request are left alone
+						require.Nil(t, arg.Tags)
+						return db.UpdateWallTxResult{Wall: updatedWall}, nil
 					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -338,6 +400,48 @@ func TestUpdateWallAPI(t *testing.T) {
 				requireBodyMatchWallResponse(t, recorder.Body, updatedWall)
 			},
 		},
+		{
+			name:   "OK_ClearTags",
+			wallID: wall.ID.String(),
+			body: gin.H{
+				"tags": []string{},
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(wall, nil)
+				mockHub.EXPECT().
+					UpdateWallTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateWallTxParams) (db.UpdateWallTxResult, error) {
+						require.NotNil(t, arg.Tags)
+						require.Empty(t, *arg.Tags)
+						return db.UpdateWallTxResult{Wall: wall, Tags: []db.Tag{}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "BadRequest_InvalidTag",
+			wallID: wall.ID.String(),
+			body: gin.H{
+				"tags": []string{"c++"},
+			},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(0)
+				mockHub.EXPECT().
+					UpdateWallTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:   "WallNotFound",
 			wallID: uuid.New().String(),
@@ -352,7 +456,7 @@ func TestUpdateWallAPI(t *testing.T) {
 					Return(db.Wall{}, db.ErrRecordNotFound)
 
 				mockHub.EXPECT().
-					UpdateWall(gomock.Any(), gomock.Any()).
+					UpdateWallTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -378,7 +482,7 @@ func TestUpdateWallAPI(t *testing.T) {
 					Return(differentUserWall, nil)
 
 				mockHub.EXPECT().
-					UpdateWall(gomock.Any(), gomock.Any()).
+					UpdateWallTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -397,7 +501,7 @@ func TestUpdateWallAPI(t *testing.T) {
 					GetWall(gomock.Any(), gomock.Any()).
 					Times(0)
 				mockHub.EXPECT().
-					UpdateWall(gomock.Any(), gomock.Any()).
+					UpdateWallTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -418,9 +522,9 @@ func TestUpdateWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					UpdateWall(gomock.Any(), gomock.Any()).
+					UpdateWallTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.Wall{}, sql.ErrConnDone)
+					Return(db.UpdateWallTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -483,14 +587,9 @@ func TestDeleteWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					DeleteWall(gomock.Any(), gomock.Any()).
+					DeleteWallTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
-
-				mockHub.EXPECT().
-					ListPostsByWall(gomock.Any(), gomock.Any()).
-					AnyTimes().
-					Return([]db.Post{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -506,7 +605,7 @@ func TestDeleteWallAPI(t *testing.T) {
 					Return(db.Wall{}, db.ErrRecordNotFound)
 
 				mockHub.EXPECT().
-					DeleteWall(gomock.Any(), gomock.Any()).
+					DeleteWallTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -528,7 +627,7 @@ func TestDeleteWallAPI(t *testing.T) {
 					Return(differentUserWall, nil)
 
 				mockHub.EXPECT().
-					DeleteWall(gomock.Any(), gomock.Any()).
+					DeleteWallTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -543,7 +642,7 @@ func TestDeleteWallAPI(t *testing.T) {
 					GetWall(gomock.Any(), gomock.Any()).
 					Times(0)
 				mockHub.EXPECT().
-					DeleteWall(gomock.Any(), gomock.Any()).
+					DeleteWallTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -560,7 +659,7 @@ func TestDeleteWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					DeleteWall(gomock.Any(), gomock.Any()).
+					DeleteWallTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -622,7 +721,7 @@ func TestPublicizeWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					PublicizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), true).
 					Times(1).
 					Return(publicizedWall, nil)
 			},
@@ -641,7 +740,7 @@ func TestPublicizeWallAPI(t *testing.T) {
 					Return(db.Wall{}, db.ErrRecordNotFound)
 
 				mockHub.EXPECT().
-					PublicizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), true).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -663,7 +762,7 @@ func TestPublicizeWallAPI(t *testing.T) {
 					Return(differentUserWall, nil)
 
 				mockHub.EXPECT().
-					PublicizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), true).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -678,7 +777,7 @@ func TestPublicizeWallAPI(t *testing.T) {
 					GetWall(gomock.Any(), gomock.Any()).
 					Times(0)
 				mockHub.EXPECT().
-					PublicizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), true).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -695,7 +794,7 @@ func TestPublicizeWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					PublicizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), true).
 					Times(1).
 					Return(db.Wall{}, sql.ErrConnDone)
 			},
@@ -762,7 +861,7 @@ func TestPrivatizeWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					PrivatizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), false).
 					Times(1).
 					Return(privatizedWall, nil)
 			},
@@ -781,7 +880,7 @@ func TestPrivatizeWallAPI(t *testing.T) {
 					Return(db.Wall{}, db.ErrRecordNotFound)
 
 				mockHub.EXPECT().
-					PrivatizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), false).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -803,7 +902,7 @@ func TestPrivatizeWallAPI(t *testing.T) {
 					Return(differentUserWall, nil)
 
 				mockHub.EXPECT().
-					PrivatizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), false).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -818,7 +917,7 @@ func TestPrivatizeWallAPI(t *testing.T) {
 					GetWall(gomock.Any(), gomock.Any()).
 					Times(0)
 				mockHub.EXPECT().
-					PrivatizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), false).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -835,7 +934,7 @@ func TestPrivatizeWallAPI(t *testing.T) {
 					Return(wall, nil)
 
 				mockHub.EXPECT().
-					PrivatizeWall(gomock.Any(), gomock.Any()).
+					SetWallVisibilityTx(gomock.Any(), gomock.Any(), gomock.Any(), false).
 					Times(1).
 					Return(db.Wall{}, sql.ErrConnDone)
 			},
@@ -902,6 +1001,11 @@ func TestPinWallAPI(t *testing.T) {
 					Times(1).
 					Return(wall, nil)
 
+				mockHub.EXPECT().
+					CountPinnedWalls(gomock.Any(), user.ID).
+					Times(1).
+					Return(int64(0), nil)
+
 				mockHub.EXPECT().
 					PinUnpinWall(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -912,6 +1016,51 @@ func TestPinWallAPI(t *testing.T) {
 				requireBodyMatchWallResponse(t, recorder.Body, pinnedWall)
 			},
 		},
+		{
+			name:   "OK_Unpin",
+			wallID: wall.ID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pinnedWall, nil)
+
+				mockHub.EXPECT().
+					CountPinnedWalls(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				mockHub.EXPECT().
+					PinUnpinWall(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(wall, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWallResponse(t, recorder.Body, wall)
+			},
+		},
+		{
+			name:   "BadRequest_PinLimit",
+			wallID: wall.ID.String(),
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					GetWall(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(wall, nil)
+
+				mockHub.EXPECT().
+					CountPinnedWalls(gomock.Any(), user.ID).
+					Times(1).
+					Return(int64(maxPinnedWalls), nil)
+
+				mockHub.EXPECT().
+					PinUnpinWall(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:   "WallNotFound",
 			wallID: uuid.New().String(),
@@ -975,6 +1124,11 @@ func TestPinWallAPI(t *testing.T) {
 					Times(1).
 					Return(wall, nil)
 
+				mockHub.EXPECT().
+					CountPinnedWalls(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+
 				mockHub.EXPECT().
 					PinUnpinWall(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -1018,6 +1172,147 @@ func TestPinWallAPI(t *testing.T) {
 	}
 }
 
+// TestSetWallPinsAPI tests the setWallPins handler
+func TestSetWallPinsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	first := randomWall(t, user.ID)
+	second := randomWall(t, user.ID)
+
+	tooManyPins := make([]string, maxPinnedWalls+1)
+	for i := range tooManyPins {
+		tooManyPins[i] = uuid.New().String()
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupMock     func(mockHub *mockdb.MockHub)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"wall_ids": []string{second.ID.String(), first.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				pinnedSecond := second
+				pinnedSecond.IsPinned = pgtype.Bool{Bool: true, Valid: true}
+				pinnedSecond.PinOrder = pgtype.Int4{Int32: 1, Valid: true}
+				pinnedFirst := first
+				pinnedFirst.IsPinned = pgtype.Bool{Bool: true, Valid: true}
+				pinnedFirst.PinOrder = pgtype.Int4{Int32: 2, Valid: true}
+
+				mockHub.EXPECT().
+					SetWallPinsTx(gomock.Any(), user.ID, []pgtype.UUID{second.ID, first.ID}).
+					Times(1).
+					Return([]db.Wall{pinnedSecond, pinnedFirst}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp []wallResponse
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Len(t, rsp, 2)
+				require.Equal(t, second.ID.String(), rsp[0].ID)
+				require.Equal(t, int32(1), rsp[0].PinOrder)
+				require.Equal(t, int32(2), rsp[1].PinOrder)
+			},
+		},
+		{
+			name: "OK_UnpinAll",
+			body: gin.H{"wall_ids": []string{}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallPinsTx(gomock.Any(), user.ID, []pgtype.UUID{}).
+					Times(1).
+					Return([]db.Wall{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_TooMany",
+			body: gin.H{"wall_ids": tooManyPins},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallPinsTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_Duplicate",
+			body: gin.H{"wall_ids": []string{first.ID.String(), first.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallPinsTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest_NotPinnable",
+			body: gin.H{"wall_ids": []string{first.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallPinsTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrWallNotPinnable)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"wall_ids": []string{first.ID.String()}},
+			setupMock: func(mockHub *mockdb.MockHub) {
+				mockHub.EXPECT().
+					SetWallPinsTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			mockHub, ok := server.hub.(*mockdb.MockHub)
+			require.True(t, ok)
+
+			tc.setupMock(mockHub)
+
+			server.router.PUT("/test/walls/pins", func(ctx *gin.Context) {
+				ctx.Set("currentUser", user)
+				server.setWallPins(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/test/walls/pins", bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 // TestGetOwnWallAPI tests the getOwnWall handler
 func TestGetOwnWallAPI(t *testing.T) {
 	user, _ := randomUser(t)
@@ -0,0 +1,6 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_wall_exports_wall_id;
+DROP INDEX IF EXISTS idx_wall_exports_user_id;
+
+-- Then drop the table
+DROP TABLE IF EXISTS wall_exports;
@@ -0,0 +1,19 @@
+-- Create wall exports table
+CREATE TABLE IF NOT EXISTS wall_exports (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "wall_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "archive_url" varchar,
+    "image_url" varchar,
+    "error" varchar,
+    "created_at" timestamp DEFAULT (now ()),
+    "completed_at" timestamp,
+
+    CONSTRAINT "wall_exports_wall_fk" FOREIGN KEY ("wall_id") REFERENCES "walls"("id") ON DELETE CASCADE,
+    CONSTRAINT "wall_exports_user_fk" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE INDEX idx_wall_exports_wall_id ON "wall_exports"("wall_id");
+CREATE INDEX idx_wall_exports_user_id ON "wall_exports"("user_id");
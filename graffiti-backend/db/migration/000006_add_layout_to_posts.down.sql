@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_posts_wall_id_z_index;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS pos_x,
+DROP COLUMN IF EXISTS pos_y,
+DROP COLUMN IF EXISTS rotation,
+DROP COLUMN IF EXISTS scale,
+DROP COLUMN IF EXISTS z_index,
+DROP COLUMN IF EXISTS layout_version;
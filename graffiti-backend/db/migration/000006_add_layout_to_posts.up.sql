@@ -0,0 +1,10 @@
+-- Canvas placement for posts on a wall
+ALTER TABLE posts
+ADD COLUMN pos_x double precision NOT NULL DEFAULT 0,
+ADD COLUMN pos_y double precision NOT NULL DEFAULT 0,
+ADD COLUMN rotation double precision NOT NULL DEFAULT 0,
+ADD COLUMN scale double precision NOT NULL DEFAULT 1,
+ADD COLUMN z_index integer NOT NULL DEFAULT 0,
+ADD COLUMN layout_version integer NOT NULL DEFAULT 1;
+
+CREATE INDEX idx_posts_wall_id_z_index ON "posts"("wall_id", "z_index");
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_posts_wall_id_status;
+
+DROP TABLE IF EXISTS wall_moderators;
+
+ALTER TABLE walls
+DROP COLUMN IF EXISTS moderation_enabled;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS "post_status";
@@ -0,0 +1,20 @@
+CREATE TYPE "post_status" AS ENUM ('pending', 'approved', 'rejected');
+
+ALTER TABLE posts
+ADD COLUMN status post_status NOT NULL DEFAULT 'approved';
+
+ALTER TABLE walls
+ADD COLUMN moderation_enabled boolean DEFAULT false;
+
+-- Users allowed to review posts on a wall besides its owner
+CREATE TABLE IF NOT EXISTS wall_moderators (
+    "wall_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "created_at" timestamp DEFAULT (now ()),
+
+    PRIMARY KEY ("wall_id", "user_id"),
+    CONSTRAINT "wall_moderators_wall_fk" FOREIGN KEY ("wall_id") REFERENCES "walls"("id") ON DELETE CASCADE,
+    CONSTRAINT "wall_moderators_user_fk" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_posts_wall_id_status ON "posts"("wall_id", "status");
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+DROP INDEX IF EXISTS idx_walls_deleted_at;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE walls
+DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Track when walls and posts were soft-deleted so they can be purged later
+ALTER TABLE walls
+ADD COLUMN deleted_at timestamp;
+
+ALTER TABLE posts
+ADD COLUMN deleted_at timestamp;
+
+-- Rows deleted before this migration start their retention window now
+UPDATE walls SET deleted_at = now() WHERE is_deleted = true AND deleted_at IS NULL;
+UPDATE posts SET deleted_at = now() WHERE is_deleted = true AND deleted_at IS NULL;
+
+CREATE INDEX idx_walls_deleted_at ON "walls"("deleted_at") WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_posts_deleted_at ON "posts"("deleted_at") WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,11 @@
+-- One row per viewer per wall per day, so repeat visits on the same day count once
+CREATE TABLE IF NOT EXISTS wall_views (
+    "wall_id" uuid NOT NULL,
+    "viewer_id" uuid NOT NULL,
+    "view_date" date NOT NULL,
+    "created_at" timestamp DEFAULT (now ()),
+
+    PRIMARY KEY ("wall_id", "view_date", "viewer_id"),
+    CONSTRAINT "wall_views_wall_fk" FOREIGN KEY ("wall_id") REFERENCES "walls"("id") ON DELETE CASCADE,
+    CONSTRAINT "wall_views_viewer_fk" FOREIGN KEY ("viewer_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
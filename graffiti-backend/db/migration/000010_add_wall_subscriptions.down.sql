@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_wall_subscriptions_user_id;
+
+DROP TABLE IF EXISTS wall_subscriptions;
+
+ALTER TABLE walls
+DROP COLUMN IF EXISTS follower_count;
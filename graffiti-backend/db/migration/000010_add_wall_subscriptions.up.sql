@@ -0,0 +1,16 @@
+ALTER TABLE walls
+ADD COLUMN follower_count integer NOT NULL DEFAULT 0;
+
+-- Users following a wall to hear about its new posts
+CREATE TABLE IF NOT EXISTS wall_subscriptions (
+    "wall_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "muted" boolean NOT NULL DEFAULT false,
+    "created_at" timestamp DEFAULT (now ()),
+
+    PRIMARY KEY ("wall_id", "user_id"),
+    CONSTRAINT "wall_subscriptions_wall_fk" FOREIGN KEY ("wall_id") REFERENCES "walls"("id") ON DELETE CASCADE,
+    CONSTRAINT "wall_subscriptions_user_fk" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_wall_subscriptions_user_id ON "wall_subscriptions"("user_id");
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tags_name_prefix;
+
+DROP INDEX IF EXISTS idx_wall_tags_tag_id;
+
+DROP TABLE IF EXISTS wall_tags;
+
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,21 @@
+-- Normalized tags that walls can be browsed by
+CREATE TABLE IF NOT EXISTS tags (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "name" varchar(32) UNIQUE NOT NULL,
+    "usage_count" integer NOT NULL DEFAULT 0,
+    "created_at" timestamp DEFAULT (now ())
+);
+
+CREATE TABLE IF NOT EXISTS wall_tags (
+    "wall_id" uuid NOT NULL,
+    "tag_id" uuid NOT NULL,
+
+    PRIMARY KEY ("wall_id", "tag_id"),
+    CONSTRAINT "wall_tags_wall_fk" FOREIGN KEY ("wall_id") REFERENCES "walls"("id") ON DELETE CASCADE,
+    CONSTRAINT "wall_tags_tag_fk" FOREIGN KEY ("tag_id") REFERENCES "tags"("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_wall_tags_tag_id ON "wall_tags"("tag_id");
+
+-- Supports prefix matching for tag autocomplete
+CREATE INDEX idx_tags_name_prefix ON "tags"("name" varchar_pattern_ops);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_walls_user_id_pin_order;
+
+ALTER TABLE walls
+DROP COLUMN IF EXISTS pin_order;
@@ -0,0 +1,19 @@
+ALTER TABLE walls
+ADD COLUMN pin_order integer;
+
+-- Keep the oldest pinned walls of each user, up to the pin limit, in the order they were created
+UPDATE walls w
+SET pin_order = ranked.pin_order
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY created_at ASC) AS pin_order
+    FROM walls
+    WHERE is_pinned = true
+) ranked
+WHERE w.id = ranked.id;
+
+UPDATE walls
+SET is_pinned = false,
+    pin_order = NULL
+WHERE pin_order > 3;
+
+CREATE UNIQUE INDEX idx_walls_user_id_pin_order ON "walls"("user_id", "pin_order") WHERE pin_order IS NOT NULL;
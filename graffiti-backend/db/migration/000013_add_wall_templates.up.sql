@@ -0,0 +1,21 @@
+-- System-provided starting points for new walls.
+-- background_key is an S3 key, served through CloudFront like uploaded media.
+CREATE TABLE IF NOT EXISTS wall_templates (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "slug" varchar(64) UNIQUE NOT NULL,
+    "title" varchar NOT NULL,
+    "description" text,
+    "background_key" varchar,
+    "moderation_enabled" boolean NOT NULL DEFAULT false,
+    "tags" text[] NOT NULL DEFAULT '{}',
+    "sort_order" integer NOT NULL DEFAULT 0,
+    "created_at" timestamp DEFAULT (now ())
+);
+
+INSERT INTO wall_templates (slug, title, description, background_key, moderation_enabled, tags, sort_order) VALUES
+    ('birthday', 'Happy Birthday!', 'Leave your birthday wishes, photos and memories here.', 'templates/birthday.jpg', false, '{birthday,celebration}', 1),
+    ('farewell', 'Farewell & Good Luck', 'Say goodbye and share your favourite moments together.', 'templates/farewell.jpg', false, '{farewell}', 2),
+    ('graduation', 'Congratulations, Graduate!', 'Celebrate the big day with messages and photos.', 'templates/graduation.jpg', false, '{graduation,celebration}', 3),
+    ('wedding', 'Wedding Guestbook', 'Share your wishes for the happy couple.', 'templates/wedding.jpg', true, '{wedding}', 4),
+    ('get-well', 'Get Well Soon', 'Send some cheer and good vibes.', 'templates/get-well.jpg', false, '{get-well}', 5)
+ON CONFLICT (slug) DO NOTHING;
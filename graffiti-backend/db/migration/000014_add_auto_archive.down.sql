@@ -0,0 +1,8 @@
+ALTER TABLE walls
+DROP COLUMN IF EXISTS unarchived_at;
+
+ALTER TABLE walls
+DROP COLUMN IF EXISTS archive_warned_at;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS auto_archive_days;
@@ -0,0 +1,11 @@
+-- Opt-in: archive walls that get no new posts for this many days
+ALTER TABLE users
+ADD COLUMN auto_archive_days integer;
+
+-- When the owner was last warned about an upcoming auto-archive
+ALTER TABLE walls
+ADD COLUMN archive_warned_at timestamp;
+
+-- Unarchiving counts as activity, so the wall isn't archived again straight away
+ALTER TABLE walls
+ADD COLUMN unarchived_at timestamp;
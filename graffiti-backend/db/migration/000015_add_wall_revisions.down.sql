@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS walls_set_updated_at ON walls;
+
+DROP FUNCTION IF EXISTS set_wall_updated_at ();
+
+DROP TABLE IF EXISTS wall_revisions;
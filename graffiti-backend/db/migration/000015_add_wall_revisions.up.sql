@@ -0,0 +1,35 @@
+-- Each revision keeps the wall as it was before a change, so reverting to a
+-- revision undoes that change and everything after it.
+CREATE TABLE IF NOT EXISTS wall_revisions (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "wall_id" uuid NOT NULL REFERENCES walls (id) ON DELETE CASCADE,
+    "actor_id" uuid REFERENCES users (id) ON DELETE SET NULL,
+    "title" varchar NOT NULL,
+    "description" varchar,
+    "background_image" varchar,
+    "is_public" boolean,
+    "changed_fields" text[] NOT NULL DEFAULT '{}',
+    "created_at" timestamp NOT NULL DEFAULT (now ())
+);
+
+CREATE INDEX IF NOT EXISTS idx_wall_revisions_wall_id_created_at ON wall_revisions (wall_id, created_at DESC);
+
+-- updated_at follows edits to the wall itself, not counters and flags kept
+-- up to date by the app such as popularity, followers or pins.
+CREATE OR REPLACE FUNCTION set_wall_updated_at () RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER walls_set_updated_at
+BEFORE UPDATE ON walls
+FOR EACH ROW
+WHEN (
+    OLD.title IS DISTINCT FROM NEW.title
+    OR OLD.description IS DISTINCT FROM NEW.description
+    OR OLD.background_image IS DISTINCT FROM NEW.background_image
+    OR OLD.is_public IS DISTINCT FROM NEW.is_public
+)
+EXECUTE FUNCTION set_wall_updated_at ();
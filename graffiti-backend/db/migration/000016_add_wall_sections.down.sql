@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_posts_section_id;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS section_id;
+
+DROP TABLE IF EXISTS wall_sections;
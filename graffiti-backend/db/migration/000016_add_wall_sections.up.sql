@@ -0,0 +1,21 @@
+-- Optional named sections inside a wall. Once a wall has sections, every post
+-- on it belongs to one, and posts from a deleted section move to the default one.
+CREATE TABLE IF NOT EXISTS wall_sections (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "wall_id" uuid NOT NULL REFERENCES walls (id) ON DELETE CASCADE,
+    "name" varchar(64) NOT NULL,
+    "position" integer NOT NULL DEFAULT 0,
+    "is_default" boolean NOT NULL DEFAULT false,
+    "created_at" timestamp DEFAULT (now ())
+);
+
+CREATE INDEX IF NOT EXISTS idx_wall_sections_wall_id_position ON wall_sections (wall_id, position);
+
+-- One default section per wall
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wall_sections_wall_id_default ON wall_sections (wall_id)
+WHERE is_default;
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS section_id uuid REFERENCES wall_sections (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_section_id ON posts (section_id);
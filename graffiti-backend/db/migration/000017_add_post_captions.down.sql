@@ -0,0 +1,3 @@
+-- Postgres can't drop an enum value, so 'text' stays on post_type
+ALTER TABLE posts
+DROP COLUMN IF EXISTS caption;
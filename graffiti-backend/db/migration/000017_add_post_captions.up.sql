@@ -0,0 +1,7 @@
+-- Text posts carry only a caption, media posts may add one below the media.
+-- The new enum value can't be used in this migration's transaction, so the
+-- caption requirement for text posts is enforced by the API.
+ALTER TYPE post_type ADD VALUE IF NOT EXISTS 'text';
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS caption varchar(500);
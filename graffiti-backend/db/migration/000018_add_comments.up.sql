@@ -0,0 +1,21 @@
+-- Comments on posts with a single level of replies. Deleted comments are kept
+-- so their replies still have a parent to hang from.
+CREATE TABLE IF NOT EXISTS comments (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "post_id" uuid NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    "author" uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    "parent_id" uuid REFERENCES comments (id) ON DELETE CASCADE,
+    "body" varchar(1000) NOT NULL,
+    "is_deleted" boolean NOT NULL DEFAULT false,
+    "deleted_at" timestamp,
+    "edited_at" timestamp,
+    "created_at" timestamp DEFAULT (now ())
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_post_id_created_at ON comments (post_id, created_at)
+WHERE parent_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id_created_at ON comments (parent_id, created_at);
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS comments_count integer NOT NULL DEFAULT 0;
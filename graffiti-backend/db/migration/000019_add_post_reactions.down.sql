@@ -0,0 +1,7 @@
+ALTER TABLE posts
+DROP COLUMN IF EXISTS reaction_counts;
+
+ALTER TABLE likes
+DROP COLUMN IF EXISTS reaction;
+
+DROP INDEX IF EXISTS idx_likes_post_id_user_id_unique;
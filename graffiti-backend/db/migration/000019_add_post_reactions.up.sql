@@ -0,0 +1,23 @@
+-- A like becomes a reaction: each user has at most one per post, which they can
+-- change. Existing likes are thumbs-up reactions. likes_count counts only the
+-- thumbs-up reactions on a post (see 000029), reaction_counts has every emoji.
+DELETE FROM likes l
+USING likes d
+WHERE l.post_id = d.post_id AND l.user_id = d.user_id
+    AND (l.liked_at, l.id) > (d.liked_at, d.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_likes_post_id_user_id_unique ON likes (post_id, user_id);
+
+ALTER TABLE likes
+ADD COLUMN IF NOT EXISTS reaction varchar(16) NOT NULL DEFAULT '👍';
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS reaction_counts jsonb NOT NULL DEFAULT '{}';
+
+UPDATE posts p
+SET likes_count = l.count,
+    reaction_counts = jsonb_build_object('👍', l.count)
+FROM (
+    SELECT post_id, COUNT(*)::int AS count FROM likes GROUP BY post_id
+) l
+WHERE p.id = l.post_id;
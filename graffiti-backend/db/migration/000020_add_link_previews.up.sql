@@ -0,0 +1,10 @@
+-- Metadata fetched for embed_link posts, shared by every post with the same URL
+CREATE TABLE IF NOT EXISTS link_previews (
+    "url" text PRIMARY KEY,
+    "title" varchar(300) NOT NULL DEFAULT '',
+    "description" varchar(1000) NOT NULL DEFAULT '',
+    "thumbnail_url" text NOT NULL DEFAULT '',
+    "provider" varchar(100) NOT NULL DEFAULT '',
+    "embed_html" text NOT NULL DEFAULT '',
+    "fetched_at" timestamp NOT NULL DEFAULT (now ())
+);
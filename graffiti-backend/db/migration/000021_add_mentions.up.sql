@@ -0,0 +1,21 @@
+-- @username mentions in post captions and comments. Offsets are in UTF-16 code
+-- units so clients can slice the text directly.
+CREATE TABLE IF NOT EXISTS mentions (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "user_id" uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    "mentioned_by" uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    "post_id" uuid REFERENCES posts (id) ON DELETE CASCADE,
+    "comment_id" uuid REFERENCES comments (id) ON DELETE CASCADE,
+    "start_offset" integer NOT NULL,
+    "end_offset" integer NOT NULL,
+    "created_at" timestamp DEFAULT (now ()),
+    CONSTRAINT mentions_single_source CHECK ((post_id IS NULL) <> (comment_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_mentions_post_id ON mentions (post_id)
+WHERE post_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_mentions_comment_id ON mentions (comment_id)
+WHERE comment_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_mentions_user_id ON mentions (user_id);
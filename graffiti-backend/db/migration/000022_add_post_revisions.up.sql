@@ -0,0 +1,18 @@
+-- Each revision keeps a post's content as it was before an edit. The media
+-- it points to stays in storage until the revision is pruned.
+CREATE TABLE IF NOT EXISTS post_revisions (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "post_id" uuid NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    "editor_id" uuid REFERENCES users (id) ON DELETE SET NULL,
+    "media_url" varchar,
+    "post_type" post_type,
+    "caption" varchar(500),
+    "created_at" timestamp NOT NULL DEFAULT (now ())
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_id_created_at ON post_revisions (post_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_post_revisions_created_at ON post_revisions (created_at);
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS edited_at timestamp;
@@ -0,0 +1,15 @@
+ALTER TABLE walls
+DROP COLUMN IF EXISTS is_hidden;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS is_hidden;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS suspended_until,
+DROP COLUMN IF EXISTS role;
+
+DROP TABLE IF EXISTS reports;
+
+DROP TYPE IF EXISTS report_status;
+
+DROP TYPE IF EXISTS report_target_type;
@@ -0,0 +1,38 @@
+CREATE TYPE "report_target_type" AS ENUM ('post', 'wall', 'user');
+
+CREATE TYPE "report_status" AS ENUM ('open', 'resolved', 'dismissed');
+
+-- Reports point at a post, wall or user by ID. There is no foreign key since
+-- the target depends on target_type, and a report outlives what it's about.
+CREATE TABLE IF NOT EXISTS reports (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "reporter_id" uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    "target_type" report_target_type NOT NULL,
+    "target_id" uuid NOT NULL,
+    "reason" varchar(32) NOT NULL,
+    "detail" varchar(1000) NOT NULL DEFAULT '',
+    "status" report_status NOT NULL DEFAULT 'open',
+    "resolved_by" uuid REFERENCES users (id) ON DELETE SET NULL,
+    "resolution_note" varchar(1000) NOT NULL DEFAULT '',
+    "resolved_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT (now ())
+);
+
+-- One open report per reporter and target, so a single user can't push
+-- content over the auto-hide threshold
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_reporter_target ON reports (reporter_id, target_type, target_id)
+WHERE status = 'open';
+
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports (target_type, target_id);
+
+CREATE INDEX IF NOT EXISTS idx_reports_status_created_at ON reports (status, created_at);
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS role varchar NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'admin')),
+ADD COLUMN IF NOT EXISTS suspended_until timestamp;
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS is_hidden boolean NOT NULL DEFAULT false;
+
+ALTER TABLE walls
+ADD COLUMN IF NOT EXISTS is_hidden boolean NOT NULL DEFAULT false;
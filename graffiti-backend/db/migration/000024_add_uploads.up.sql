@@ -0,0 +1,13 @@
+-- Each upload is recorded when it's presigned so a post can only use media
+-- that the author uploaded themselves, with the size and type they declared
+CREATE TABLE IF NOT EXISTS uploads (
+    "key" varchar PRIMARY KEY,
+    "user_id" uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    "content_type" varchar NOT NULL,
+    "file_size" bigint NOT NULL,
+    "post_id" uuid REFERENCES posts (id) ON DELETE SET NULL,
+    "claimed_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT (now ())
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_user_id ON uploads (user_id);
@@ -0,0 +1,16 @@
+-- Album posts show several media items in order. The post's own media_url
+-- holds the first item as a cover so anything that only knows about single
+-- media posts still has something to show.
+ALTER TYPE post_type ADD VALUE IF NOT EXISTS 'album';
+
+CREATE TABLE IF NOT EXISTS post_media (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    "post_id" uuid NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    "position" int NOT NULL,
+    "media_url" varchar NOT NULL,
+    "width" int NOT NULL,
+    "height" int NOT NULL,
+    "alt_text" varchar(300) NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL DEFAULT (now ()),
+    UNIQUE (post_id, position)
+);
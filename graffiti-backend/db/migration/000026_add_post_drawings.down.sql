@@ -0,0 +1,2 @@
+-- Postgres can't drop an enum value, so 'drawing' stays on post_type
+DROP TABLE IF EXISTS post_drawings;
@@ -0,0 +1,12 @@
+-- Drawing posts keep the strokes they were drawn with so they can be replayed
+-- or edited. The post's media_url points at a PNG preview rendered from them,
+-- so feeds show drawings like any other media.
+ALTER TYPE post_type ADD VALUE IF NOT EXISTS 'drawing';
+
+CREATE TABLE IF NOT EXISTS post_drawings (
+    "post_id" uuid PRIMARY KEY REFERENCES posts (id) ON DELETE CASCADE,
+    "width" int NOT NULL,
+    "height" int NOT NULL,
+    "strokes" jsonb NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now ())
+);
@@ -0,0 +1,5 @@
+-- Postgres can't drop an enum value, so 'scheduled' stays on post_status
+DROP INDEX IF EXISTS posts_publish_at_idx;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,9 @@
+-- Scheduled posts wait with the 'scheduled' status, seen only by their author,
+-- until a job publishes them once publish_at has passed
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS publish_at timestamp;
+
+CREATE INDEX IF NOT EXISTS posts_publish_at_idx ON posts (publish_at)
+WHERE publish_at IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE posts
+DROP COLUMN IF EXISTS is_anonymous;
+
+ALTER TABLE walls
+DROP COLUMN IF EXISTS allow_anonymous;
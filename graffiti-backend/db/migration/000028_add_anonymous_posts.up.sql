@@ -0,0 +1,8 @@
+-- Walls can let people post without showing who they are. The author is
+-- still recorded on the post for moderation and deletion rights, it's only
+-- hidden from everyone else.
+ALTER TABLE walls
+ADD COLUMN IF NOT EXISTS allow_anonymous boolean NOT NULL DEFAULT false;
+
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS is_anonymous boolean NOT NULL DEFAULT false;
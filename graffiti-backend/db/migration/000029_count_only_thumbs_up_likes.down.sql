@@ -0,0 +1,5 @@
+UPDATE posts p
+SET likes_count = (
+    SELECT COUNT(*)::int FROM likes l
+    WHERE l.post_id = p.id
+);
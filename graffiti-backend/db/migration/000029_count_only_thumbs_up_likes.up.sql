@@ -0,0 +1,7 @@
+-- likes_count only counts thumbs-up reactions from now on, matching what
+-- "liked" means for a user. reaction_counts still has every emoji.
+UPDATE posts p
+SET likes_count = (
+    SELECT COUNT(*)::int FROM likes l
+    WHERE l.post_id = p.id AND l.reaction = '👍'
+);
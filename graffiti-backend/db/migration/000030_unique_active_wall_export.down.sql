@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_wall_exports_active_user_id;
@@ -0,0 +1,15 @@
+-- Each user has at most one export being built. Anything left over from
+-- before is given up on, keeping only the newest.
+UPDATE wall_exports e
+SET status = 'failed',
+    error = 'export timed out',
+    completed_at = now()
+WHERE e.status IN ('pending', 'processing')
+    AND EXISTS (
+        SELECT 1 FROM wall_exports n
+        WHERE n.user_id = e.user_id AND n.status IN ('pending', 'processing')
+            AND (n.created_at, n.id) > (e.created_at, e.id)
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wall_exports_active_user_id ON wall_exports (user_id)
+WHERE status IN ('pending', 'processing');
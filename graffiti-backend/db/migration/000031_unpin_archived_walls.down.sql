@@ -0,0 +1 @@
+-- Pins removed by the up migration aren't restored
@@ -0,0 +1,7 @@
+-- Archived and deleted walls no longer keep their pin, so bringing one back
+-- can't take a user past the pinned wall limit
+UPDATE walls
+SET is_pinned = false,
+    pin_order = NULL
+WHERE (is_archived = true OR is_deleted = true)
+    AND (is_pinned = true OR pin_order IS NOT NULL);
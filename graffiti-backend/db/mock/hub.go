@@ -80,6 +80,78 @@ func (mr *MockHubMockRecorder) AddLikesCount(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLikesCount", reflect.TypeOf((*MockHub)(nil).AddLikesCount), arg0, arg1)
 }
 
+// AddPostCommentsCount mocks base method.
+func (m *MockHub) AddPostCommentsCount(arg0 context.Context, arg1 db.AddPostCommentsCountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPostCommentsCount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPostCommentsCount indicates an expected call of AddPostCommentsCount.
+func (mr *MockHubMockRecorder) AddPostCommentsCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPostCommentsCount", reflect.TypeOf((*MockHub)(nil).AddPostCommentsCount), arg0, arg1)
+}
+
+// AddPostReactionCount mocks base method.
+func (m *MockHub) AddPostReactionCount(arg0 context.Context, arg1 db.AddPostReactionCountParams) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPostReactionCount", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPostReactionCount indicates an expected call of AddPostReactionCount.
+func (mr *MockHubMockRecorder) AddPostReactionCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPostReactionCount", reflect.TypeOf((*MockHub)(nil).AddPostReactionCount), arg0, arg1)
+}
+
+// AddWallFollowerCount mocks base method.
+func (m *MockHub) AddWallFollowerCount(arg0 context.Context, arg1 db.AddWallFollowerCountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWallFollowerCount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWallFollowerCount indicates an expected call of AddWallFollowerCount.
+func (mr *MockHubMockRecorder) AddWallFollowerCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWallFollowerCount", reflect.TypeOf((*MockHub)(nil).AddWallFollowerCount), arg0, arg1)
+}
+
+// AddWallModerator mocks base method.
+func (m *MockHub) AddWallModerator(arg0 context.Context, arg1 db.AddWallModeratorParams) (db.WallModerator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWallModerator", arg0, arg1)
+	ret0, _ := ret[0].(db.WallModerator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWallModerator indicates an expected call of AddWallModerator.
+func (mr *MockHubMockRecorder) AddWallModerator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWallModerator", reflect.TypeOf((*MockHub)(nil).AddWallModerator), arg0, arg1)
+}
+
+// AddWallTag mocks base method.
+func (m *MockHub) AddWallTag(arg0 context.Context, arg1 db.AddWallTagParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWallTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWallTag indicates an expected call of AddWallTag.
+func (mr *MockHubMockRecorder) AddWallTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWallTag", reflect.TypeOf((*MockHub)(nil).AddWallTag), arg0, arg1)
+}
+
 // ArchiveWall mocks base method.
 func (m *MockHub) ArchiveWall(arg0 context.Context, arg1 pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -94,6 +166,20 @@ func (mr *MockHubMockRecorder) ArchiveWall(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveWall", reflect.TypeOf((*MockHub)(nil).ArchiveWall), arg0, arg1)
 }
 
+// AssignUnsectionedPosts mocks base method.
+func (m *MockHub) AssignUnsectionedPosts(arg0 context.Context, arg1 db.AssignUnsectionedPostsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUnsectionedPosts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignUnsectionedPosts indicates an expected call of AssignUnsectionedPosts.
+func (mr *MockHubMockRecorder) AssignUnsectionedPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUnsectionedPosts", reflect.TypeOf((*MockHub)(nil).AssignUnsectionedPosts), arg0, arg1)
+}
+
 // BlockFriendship mocks base method.
 func (m *MockHub) BlockFriendship(arg0 context.Context, arg1 pgtype.UUID) (db.Friendship, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +209,95 @@ func (mr *MockHubMockRecorder) BlockUserTx(arg0, arg1, arg2 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserTx", reflect.TypeOf((*MockHub)(nil).BlockUserTx), arg0, arg1, arg2)
 }
 
+// ClaimUpload mocks base method.
+func (m *MockHub) ClaimUpload(arg0 context.Context, arg1 db.ClaimUploadParams) (db.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUpload", arg0, arg1)
+	ret0, _ := ret[0].(db.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUpload indicates an expected call of ClaimUpload.
+func (mr *MockHubMockRecorder) ClaimUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUpload", reflect.TypeOf((*MockHub)(nil).ClaimUpload), arg0, arg1)
+}
+
+// ClearWallPins mocks base method.
+func (m *MockHub) ClearWallPins(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearWallPins", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearWallPins indicates an expected call of ClearWallPins.
+func (mr *MockHubMockRecorder) ClearWallPins(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearWallPins", reflect.TypeOf((*MockHub)(nil).ClearWallPins), arg0, arg1)
+}
+
+// CompleteWallExport mocks base method.
+func (m *MockHub) CompleteWallExport(arg0 context.Context, arg1 db.CompleteWallExportParams) (db.WallExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteWallExport", arg0, arg1)
+	ret0, _ := ret[0].(db.WallExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteWallExport indicates an expected call of CompleteWallExport.
+func (mr *MockHubMockRecorder) CompleteWallExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteWallExport", reflect.TypeOf((*MockHub)(nil).CompleteWallExport), arg0, arg1)
+}
+
+// CountOpenReportsForTarget mocks base method.
+func (m *MockHub) CountOpenReportsForTarget(arg0 context.Context, arg1 db.CountOpenReportsForTargetParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenReportsForTarget", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenReportsForTarget indicates an expected call of CountOpenReportsForTarget.
+func (mr *MockHubMockRecorder) CountOpenReportsForTarget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenReportsForTarget", reflect.TypeOf((*MockHub)(nil).CountOpenReportsForTarget), arg0, arg1)
+}
+
+// CountPinnedWalls mocks base method.
+func (m *MockHub) CountPinnedWalls(arg0 context.Context, arg1 pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPinnedWalls", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPinnedWalls indicates an expected call of CountPinnedWalls.
+func (mr *MockHubMockRecorder) CountPinnedWalls(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPinnedWalls", reflect.TypeOf((*MockHub)(nil).CountPinnedWalls), arg0, arg1)
+}
+
+// CountUniqueWallVisitors mocks base method.
+func (m *MockHub) CountUniqueWallVisitors(arg0 context.Context, arg1 db.CountUniqueWallVisitorsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUniqueWallVisitors", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUniqueWallVisitors indicates an expected call of CountUniqueWallVisitors.
+func (mr *MockHubMockRecorder) CountUniqueWallVisitors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUniqueWallVisitors", reflect.TypeOf((*MockHub)(nil).CountUniqueWallVisitors), arg0, arg1)
+}
+
 // CountUnreadNotifications mocks base method.
 func (m *MockHub) CountUnreadNotifications(arg0 context.Context, arg1 pgtype.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +313,66 @@ func (mr *MockHubMockRecorder) CountUnreadNotifications(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockHub)(nil).CountUnreadNotifications), arg0, arg1)
 }
 
+// CreateAlbumPostTx mocks base method.
+func (m *MockHub) CreateAlbumPostTx(arg0 context.Context, arg1 db.CreateAlbumPostTxParams) (db.CreateAlbumPostTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbumPostTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateAlbumPostTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbumPostTx indicates an expected call of CreateAlbumPostTx.
+func (mr *MockHubMockRecorder) CreateAlbumPostTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbumPostTx", reflect.TypeOf((*MockHub)(nil).CreateAlbumPostTx), arg0, arg1)
+}
+
+// CreateComment mocks base method.
+func (m *MockHub) CreateComment(arg0 context.Context, arg1 db.CreateCommentParams) (db.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1)
+	ret0, _ := ret[0].(db.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockHubMockRecorder) CreateComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockHub)(nil).CreateComment), arg0, arg1)
+}
+
+// CreateCommentTx mocks base method.
+func (m *MockHub) CreateCommentTx(arg0 context.Context, arg1 db.CreateCommentParams) (db.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCommentTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCommentTx indicates an expected call of CreateCommentTx.
+func (mr *MockHubMockRecorder) CreateCommentTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommentTx", reflect.TypeOf((*MockHub)(nil).CreateCommentTx), arg0, arg1)
+}
+
+// CreateDrawingPostTx mocks base method.
+func (m *MockHub) CreateDrawingPostTx(arg0 context.Context, arg1 db.CreateDrawingPostTxParams) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDrawingPostTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDrawingPostTx indicates an expected call of CreateDrawingPostTx.
+func (mr *MockHubMockRecorder) CreateDrawingPostTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDrawingPostTx", reflect.TypeOf((*MockHub)(nil).CreateDrawingPostTx), arg0, arg1)
+}
+
 // CreateFriendRequestTx mocks base method.
 func (m *MockHub) CreateFriendRequestTx(arg0 context.Context, arg1, arg2 pgtype.UUID) (db.Friendship, error) {
 	m.ctrl.T.Helper()
@@ -197,6 +432,21 @@ func (mr *MockHubMockRecorder) CreateLikeTx(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLikeTx", reflect.TypeOf((*MockHub)(nil).CreateLikeTx), arg0, arg1, arg2)
 }
 
+// CreateMention mocks base method.
+func (m *MockHub) CreateMention(arg0 context.Context, arg1 db.CreateMentionParams) (db.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMention", arg0, arg1)
+	ret0, _ := ret[0].(db.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMention indicates an expected call of CreateMention.
+func (mr *MockHubMockRecorder) CreateMention(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMention", reflect.TypeOf((*MockHub)(nil).CreateMention), arg0, arg1)
+}
+
 // CreateNotification mocks base method.
 func (m *MockHub) CreateNotification(arg0 context.Context, arg1 db.CreateNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +492,96 @@ func (mr *MockHubMockRecorder) CreatePost(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockHub)(nil).CreatePost), arg0, arg1)
 }
 
+// CreatePostDrawing mocks base method.
+func (m *MockHub) CreatePostDrawing(arg0 context.Context, arg1 db.CreatePostDrawingParams) (db.PostDrawing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePostDrawing", arg0, arg1)
+	ret0, _ := ret[0].(db.PostDrawing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePostDrawing indicates an expected call of CreatePostDrawing.
+func (mr *MockHubMockRecorder) CreatePostDrawing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePostDrawing", reflect.TypeOf((*MockHub)(nil).CreatePostDrawing), arg0, arg1)
+}
+
+// CreatePostMedia mocks base method.
+func (m *MockHub) CreatePostMedia(arg0 context.Context, arg1 db.CreatePostMediaParams) (db.PostMedium, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePostMedia", arg0, arg1)
+	ret0, _ := ret[0].(db.PostMedium)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePostMedia indicates an expected call of CreatePostMedia.
+func (mr *MockHubMockRecorder) CreatePostMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePostMedia", reflect.TypeOf((*MockHub)(nil).CreatePostMedia), arg0, arg1)
+}
+
+// CreatePostRevision mocks base method.
+func (m *MockHub) CreatePostRevision(arg0 context.Context, arg1 db.CreatePostRevisionParams) (db.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePostRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePostRevision indicates an expected call of CreatePostRevision.
+func (mr *MockHubMockRecorder) CreatePostRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePostRevision", reflect.TypeOf((*MockHub)(nil).CreatePostRevision), arg0, arg1)
+}
+
+// CreatePostTx mocks base method.
+func (m *MockHub) CreatePostTx(arg0 context.Context, arg1 db.CreatePostParams, arg2 string) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePostTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePostTx indicates an expected call of CreatePostTx.
+func (mr *MockHubMockRecorder) CreatePostTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePostTx", reflect.TypeOf((*MockHub)(nil).CreatePostTx), arg0, arg1, arg2)
+}
+
+// CreateReport mocks base method.
+func (m *MockHub) CreateReport(arg0 context.Context, arg1 db.CreateReportParams) (db.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", arg0, arg1)
+	ret0, _ := ret[0].(db.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockHubMockRecorder) CreateReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockHub)(nil).CreateReport), arg0, arg1)
+}
+
+// CreateReportTx mocks base method.
+func (m *MockHub) CreateReportTx(arg0 context.Context, arg1 db.CreateReportParams, arg2 int64) (db.CreateReportTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.CreateReportTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReportTx indicates an expected call of CreateReportTx.
+func (mr *MockHubMockRecorder) CreateReportTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportTx", reflect.TypeOf((*MockHub)(nil).CreateReportTx), arg0, arg1, arg2)
+}
+
 // CreateTestWall mocks base method.
 func (m *MockHub) CreateTestWall(arg0 context.Context, arg1 db.CreateTestWallParams) (db.Wall, error) {
 	m.ctrl.T.Helper()
@@ -257,6 +597,21 @@ func (mr *MockHubMockRecorder) CreateTestWall(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTestWall", reflect.TypeOf((*MockHub)(nil).CreateTestWall), arg0, arg1)
 }
 
+// CreateUpload mocks base method.
+func (m *MockHub) CreateUpload(arg0 context.Context, arg1 db.CreateUploadParams) (db.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", arg0, arg1)
+	ret0, _ := ret[0].(db.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockHubMockRecorder) CreateUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockHub)(nil).CreateUpload), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockHub) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -287,90 +642,356 @@ func (mr *MockHubMockRecorder) CreateWall(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWall", reflect.TypeOf((*MockHub)(nil).CreateWall), arg0, arg1)
 }
 
-// DeleteFriendship mocks base method.
-func (m *MockHub) DeleteFriendship(arg0 context.Context, arg1 pgtype.UUID) error {
+// CreateWallExport mocks base method.
+func (m *MockHub) CreateWallExport(arg0 context.Context, arg1 db.CreateWallExportParams) (db.WallExport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFriendship", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWallExport", arg0, arg1)
+	ret0, _ := ret[0].(db.WallExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteFriendship indicates an expected call of DeleteFriendship.
-func (mr *MockHubMockRecorder) DeleteFriendship(arg0, arg1 interface{}) *gomock.Call {
+// CreateWallExport indicates an expected call of CreateWallExport.
+func (mr *MockHubMockRecorder) CreateWallExport(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendship", reflect.TypeOf((*MockHub)(nil).DeleteFriendship), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallExport", reflect.TypeOf((*MockHub)(nil).CreateWallExport), arg0, arg1)
 }
 
-// DeleteLike mocks base method.
-func (m *MockHub) DeleteLike(arg0 context.Context, arg1 db.DeleteLikeParams) error {
+// CreateWallRevision mocks base method.
+func (m *MockHub) CreateWallRevision(arg0 context.Context, arg1 db.CreateWallRevisionParams) (db.WallRevision, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLike", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWallRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.WallRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteLike indicates an expected call of DeleteLike.
-func (mr *MockHubMockRecorder) DeleteLike(arg0, arg1 interface{}) *gomock.Call {
+// CreateWallRevision indicates an expected call of CreateWallRevision.
+func (mr *MockHubMockRecorder) CreateWallRevision(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLike", reflect.TypeOf((*MockHub)(nil).DeleteLike), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallRevision", reflect.TypeOf((*MockHub)(nil).CreateWallRevision), arg0, arg1)
 }
 
-// DeleteNotification mocks base method.
-func (m *MockHub) DeleteNotification(arg0 context.Context, arg1 pgtype.UUID) error {
+// CreateWallSection mocks base method.
+func (m *MockHub) CreateWallSection(arg0 context.Context, arg1 db.CreateWallSectionParams) (db.WallSection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNotification", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWallSection", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteNotification indicates an expected call of DeleteNotification.
-func (mr *MockHubMockRecorder) DeleteNotification(arg0, arg1 interface{}) *gomock.Call {
+// CreateWallSection indicates an expected call of CreateWallSection.
+func (mr *MockHubMockRecorder) CreateWallSection(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockHub)(nil).DeleteNotification), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallSection", reflect.TypeOf((*MockHub)(nil).CreateWallSection), arg0, arg1)
 }
 
-// DeletePost mocks base method.
-func (m *MockHub) DeletePost(arg0 context.Context, arg1 pgtype.UUID) error {
+// CreateWallSectionTx mocks base method.
+func (m *MockHub) CreateWallSectionTx(arg0 context.Context, arg1 pgtype.UUID, arg2 string) (db.WallSection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePost", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWallSectionTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeletePost indicates an expected call of DeletePost.
-func (mr *MockHubMockRecorder) DeletePost(arg0, arg1 interface{}) *gomock.Call {
+// CreateWallSectionTx indicates an expected call of CreateWallSectionTx.
+func (mr *MockHubMockRecorder) CreateWallSectionTx(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockHub)(nil).DeletePost), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallSectionTx", reflect.TypeOf((*MockHub)(nil).CreateWallSectionTx), arg0, arg1, arg2)
 }
 
-// DeleteUser mocks base method.
-func (m *MockHub) DeleteUser(arg0 context.Context, arg1 pgtype.UUID) error {
+// CreateWallSubscription mocks base method.
+func (m *MockHub) CreateWallSubscription(arg0 context.Context, arg1 db.CreateWallSubscriptionParams) (db.WallSubscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWallSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockHubMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// CreateWallSubscription indicates an expected call of CreateWallSubscription.
+func (mr *MockHubMockRecorder) CreateWallSubscription(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockHub)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallSubscription", reflect.TypeOf((*MockHub)(nil).CreateWallSubscription), arg0, arg1)
 }
 
-// DeleteWall mocks base method.
-func (m *MockHub) DeleteWall(arg0 context.Context, arg1 pgtype.UUID) error {
+// CreateWallTx mocks base method.
+func (m *MockHub) CreateWallTx(arg0 context.Context, arg1 db.CreateWallTxParams) (db.CreateWallTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWall", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWallTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateWallTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteWall indicates an expected call of DeleteWall.
+// CreateWallTx indicates an expected call of CreateWallTx.
+func (mr *MockHubMockRecorder) CreateWallTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallTx", reflect.TypeOf((*MockHub)(nil).CreateWallTx), arg0, arg1)
+}
+
+// DeleteCommentMentions mocks base method.
+func (m *MockHub) DeleteCommentMentions(arg0 context.Context, arg1 pgtype.UUID) ([]pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCommentMentions", arg0, arg1)
+	ret0, _ := ret[0].([]pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCommentMentions indicates an expected call of DeleteCommentMentions.
+func (mr *MockHubMockRecorder) DeleteCommentMentions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommentMentions", reflect.TypeOf((*MockHub)(nil).DeleteCommentMentions), arg0, arg1)
+}
+
+// DeleteCommentTx mocks base method.
+func (m *MockHub) DeleteCommentTx(arg0 context.Context, arg1, arg2 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCommentTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCommentTx indicates an expected call of DeleteCommentTx.
+func (mr *MockHubMockRecorder) DeleteCommentTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommentTx", reflect.TypeOf((*MockHub)(nil).DeleteCommentTx), arg0, arg1, arg2)
+}
+
+// DeleteFriendship mocks base method.
+func (m *MockHub) DeleteFriendship(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFriendship", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFriendship indicates an expected call of DeleteFriendship.
+func (mr *MockHubMockRecorder) DeleteFriendship(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendship", reflect.TypeOf((*MockHub)(nil).DeleteFriendship), arg0, arg1)
+}
+
+// DeleteLike mocks base method.
+func (m *MockHub) DeleteLike(arg0 context.Context, arg1 db.DeleteLikeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLike", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLike indicates an expected call of DeleteLike.
+func (mr *MockHubMockRecorder) DeleteLike(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLike", reflect.TypeOf((*MockHub)(nil).DeleteLike), arg0, arg1)
+}
+
+// DeleteLikesByPosts mocks base method.
+func (m *MockHub) DeleteLikesByPosts(arg0 context.Context, arg1 []pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikesByPosts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLikesByPosts indicates an expected call of DeleteLikesByPosts.
+func (mr *MockHubMockRecorder) DeleteLikesByPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikesByPosts", reflect.TypeOf((*MockHub)(nil).DeleteLikesByPosts), arg0, arg1)
+}
+
+// DeleteNotification mocks base method.
+func (m *MockHub) DeleteNotification(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotification indicates an expected call of DeleteNotification.
+func (mr *MockHubMockRecorder) DeleteNotification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockHub)(nil).DeleteNotification), arg0, arg1)
+}
+
+// DeletePost mocks base method.
+func (m *MockHub) DeletePost(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePost", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePost indicates an expected call of DeletePost.
+func (mr *MockHubMockRecorder) DeletePost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockHub)(nil).DeletePost), arg0, arg1)
+}
+
+// DeletePostMentions mocks base method.
+func (m *MockHub) DeletePostMentions(arg0 context.Context, arg1 pgtype.UUID) ([]pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePostMentions", arg0, arg1)
+	ret0, _ := ret[0].([]pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePostMentions indicates an expected call of DeletePostMentions.
+func (mr *MockHubMockRecorder) DeletePostMentions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePostMentions", reflect.TypeOf((*MockHub)(nil).DeletePostMentions), arg0, arg1)
+}
+
+// DeletePostRevisions mocks base method.
+func (m *MockHub) DeletePostRevisions(arg0 context.Context, arg1 []pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePostRevisions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePostRevisions indicates an expected call of DeletePostRevisions.
+func (mr *MockHubMockRecorder) DeletePostRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePostRevisions", reflect.TypeOf((*MockHub)(nil).DeletePostRevisions), arg0, arg1)
+}
+
+// DeletePostsByWall mocks base method.
+func (m *MockHub) DeletePostsByWall(arg0 context.Context, arg1 db.DeletePostsByWallParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePostsByWall", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePostsByWall indicates an expected call of DeletePostsByWall.
+func (mr *MockHubMockRecorder) DeletePostsByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePostsByWall", reflect.TypeOf((*MockHub)(nil).DeletePostsByWall), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockHub) DeleteUser(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockHubMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockHub)(nil).DeleteUser), arg0, arg1)
+}
+
+// DeleteWall mocks base method.
+func (m *MockHub) DeleteWall(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWall", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWall indicates an expected call of DeleteWall.
 func (mr *MockHubMockRecorder) DeleteWall(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWall", reflect.TypeOf((*MockHub)(nil).DeleteWall), arg0, arg1)
 }
 
+// DeleteWallExports mocks base method.
+func (m *MockHub) DeleteWallExports(arg0 context.Context, arg1 []pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallExports", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWallExports indicates an expected call of DeleteWallExports.
+func (mr *MockHubMockRecorder) DeleteWallExports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallExports", reflect.TypeOf((*MockHub)(nil).DeleteWallExports), arg0, arg1)
+}
+
+// DeleteWallSection mocks base method.
+func (m *MockHub) DeleteWallSection(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallSection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWallSection indicates an expected call of DeleteWallSection.
+func (mr *MockHubMockRecorder) DeleteWallSection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallSection", reflect.TypeOf((*MockHub)(nil).DeleteWallSection), arg0, arg1)
+}
+
+// DeleteWallSectionTx mocks base method.
+func (m *MockHub) DeleteWallSectionTx(arg0 context.Context, arg1, arg2 pgtype.UUID) (db.DeleteWallSectionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallSectionTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.DeleteWallSectionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWallSectionTx indicates an expected call of DeleteWallSectionTx.
+func (mr *MockHubMockRecorder) DeleteWallSectionTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallSectionTx", reflect.TypeOf((*MockHub)(nil).DeleteWallSectionTx), arg0, arg1, arg2)
+}
+
+// DeleteWallSubscription mocks base method.
+func (m *MockHub) DeleteWallSubscription(arg0 context.Context, arg1 db.DeleteWallSubscriptionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallSubscription", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWallSubscription indicates an expected call of DeleteWallSubscription.
+func (mr *MockHubMockRecorder) DeleteWallSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallSubscription", reflect.TypeOf((*MockHub)(nil).DeleteWallSubscription), arg0, arg1)
+}
+
+// DeleteWallTags mocks base method.
+func (m *MockHub) DeleteWallTags(arg0 context.Context, arg1 pgtype.UUID) ([]pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallTags", arg0, arg1)
+	ret0, _ := ret[0].([]pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWallTags indicates an expected call of DeleteWallTags.
+func (mr *MockHubMockRecorder) DeleteWallTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallTags", reflect.TypeOf((*MockHub)(nil).DeleteWallTags), arg0, arg1)
+}
+
+// DeleteWallTx mocks base method.
+func (m *MockHub) DeleteWallTx(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWallTx indicates an expected call of DeleteWallTx.
+func (mr *MockHubMockRecorder) DeleteWallTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallTx", reflect.TypeOf((*MockHub)(nil).DeleteWallTx), arg0, arg1)
+}
+
 // DiscoverFriendsByMutuals mocks base method.
 func (m *MockHub) DiscoverFriendsByMutuals(arg0 context.Context, arg1 pgtype.UUID) ([]db.DiscoverFriendsByMutualsRow, error) {
 	m.ctrl.T.Helper()
@@ -386,6 +1007,34 @@ func (mr *MockHubMockRecorder) DiscoverFriendsByMutuals(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverFriendsByMutuals", reflect.TypeOf((*MockHub)(nil).DiscoverFriendsByMutuals), arg0, arg1)
 }
 
+// ExpireWallExports mocks base method.
+func (m *MockHub) ExpireWallExports(arg0 context.Context, arg1 db.ExpireWallExportsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireWallExports", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireWallExports indicates an expected call of ExpireWallExports.
+func (mr *MockHubMockRecorder) ExpireWallExports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireWallExports", reflect.TypeOf((*MockHub)(nil).ExpireWallExports), arg0, arg1)
+}
+
+// FailWallExport mocks base method.
+func (m *MockHub) FailWallExport(arg0 context.Context, arg1 db.FailWallExportParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailWallExport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailWallExport indicates an expected call of FailWallExport.
+func (mr *MockHubMockRecorder) FailWallExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailWallExport", reflect.TypeOf((*MockHub)(nil).FailWallExport), arg0, arg1)
+}
+
 // FinishOnboarding mocks base method.
 func (m *MockHub) FinishOnboarding(arg0 context.Context, arg1 pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -400,6 +1049,21 @@ func (mr *MockHubMockRecorder) FinishOnboarding(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishOnboarding", reflect.TypeOf((*MockHub)(nil).FinishOnboarding), arg0, arg1)
 }
 
+// FollowWallTx mocks base method.
+func (m *MockHub) FollowWallTx(arg0 context.Context, arg1, arg2 pgtype.UUID) (db.WallSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowWallTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.WallSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowWallTx indicates an expected call of FollowWallTx.
+func (mr *MockHubMockRecorder) FollowWallTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowWallTx", reflect.TypeOf((*MockHub)(nil).FollowWallTx), arg0, arg1, arg2)
+}
+
 // GetArchivedWalls mocks base method.
 func (m *MockHub) GetArchivedWalls(arg0 context.Context, arg1 pgtype.UUID) ([]db.Wall, error) {
 	m.ctrl.T.Helper()
@@ -415,6 +1079,36 @@ func (mr *MockHubMockRecorder) GetArchivedWalls(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedWalls", reflect.TypeOf((*MockHub)(nil).GetArchivedWalls), arg0, arg1)
 }
 
+// GetComment mocks base method.
+func (m *MockHub) GetComment(arg0 context.Context, arg1 pgtype.UUID) (db.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", arg0, arg1)
+	ret0, _ := ret[0].(db.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockHubMockRecorder) GetComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockHub)(nil).GetComment), arg0, arg1)
+}
+
+// GetDefaultWallSection mocks base method.
+func (m *MockHub) GetDefaultWallSection(arg0 context.Context, arg1 pgtype.UUID) (db.WallSection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultWallSection", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultWallSection indicates an expected call of GetDefaultWallSection.
+func (mr *MockHubMockRecorder) GetDefaultWallSection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultWallSection", reflect.TypeOf((*MockHub)(nil).GetDefaultWallSection), arg0, arg1)
+}
+
 // GetFriendsTx mocks base method.
 func (m *MockHub) GetFriendsTx(arg0 context.Context, arg1 pgtype.UUID) ([]db.Friendship, error) {
 	m.ctrl.T.Helper()
@@ -490,6 +1184,21 @@ func (mr *MockHubMockRecorder) GetLike(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLike", reflect.TypeOf((*MockHub)(nil).GetLike), arg0, arg1)
 }
 
+// GetLinkPreview mocks base method.
+func (m *MockHub) GetLinkPreview(arg0 context.Context, arg1 string) (db.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkPreview", arg0, arg1)
+	ret0, _ := ret[0].(db.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkPreview indicates an expected call of GetLinkPreview.
+func (mr *MockHubMockRecorder) GetLinkPreview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkPreview", reflect.TypeOf((*MockHub)(nil).GetLinkPreview), arg0, arg1)
+}
+
 // GetNotificationsByUser mocks base method.
 func (m *MockHub) GetNotificationsByUser(arg0 context.Context, arg1 pgtype.UUID) ([]db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -595,53 +1304,113 @@ func (mr *MockHubMockRecorder) GetPost(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockHub)(nil).GetPost), arg0, arg1)
 }
 
-// GetSentFriendRequestsTx mocks base method.
-func (m *MockHub) GetSentFriendRequestsTx(arg0 context.Context, arg1 pgtype.UUID) ([]db.Friendship, error) {
+// GetPostDrawing mocks base method.
+func (m *MockHub) GetPostDrawing(arg0 context.Context, arg1 pgtype.UUID) (db.PostDrawing, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSentFriendRequestsTx", arg0, arg1)
-	ret0, _ := ret[0].([]db.Friendship)
+	ret := m.ctrl.Call(m, "GetPostDrawing", arg0, arg1)
+	ret0, _ := ret[0].(db.PostDrawing)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSentFriendRequestsTx indicates an expected call of GetSentFriendRequestsTx.
-func (mr *MockHubMockRecorder) GetSentFriendRequestsTx(arg0, arg1 interface{}) *gomock.Call {
+// GetPostDrawing indicates an expected call of GetPostDrawing.
+func (mr *MockHubMockRecorder) GetPostDrawing(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentFriendRequestsTx", reflect.TypeOf((*MockHub)(nil).GetSentFriendRequestsTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostDrawing", reflect.TypeOf((*MockHub)(nil).GetPostDrawing), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockHub) GetUser(arg0 context.Context, arg1 pgtype.UUID) (db.User, error) {
+// GetPostForUpdate mocks base method.
+func (m *MockHub) GetPostForUpdate(arg0 context.Context, arg1 pgtype.UUID) (db.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "GetPostForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockHubMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// GetPostForUpdate indicates an expected call of GetPostForUpdate.
+func (mr *MockHubMockRecorder) GetPostForUpdate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockHub)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostForUpdate", reflect.TypeOf((*MockHub)(nil).GetPostForUpdate), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockHub) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+// GetReport mocks base method.
+func (m *MockHub) GetReport(arg0 context.Context, arg1 pgtype.UUID) (db.Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "GetReport", arg0, arg1)
+	ret0, _ := ret[0].(db.Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockHubMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// GetReport indicates an expected call of GetReport.
+func (mr *MockHubMockRecorder) GetReport(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockHub)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockHub)(nil).GetReport), arg0, arg1)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockHub) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+// GetSentFriendRequestsTx mocks base method.
+func (m *MockHub) GetSentFriendRequestsTx(arg0 context.Context, arg1 pgtype.UUID) ([]db.Friendship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentFriendRequestsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Friendship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentFriendRequestsTx indicates an expected call of GetSentFriendRequestsTx.
+func (mr *MockHubMockRecorder) GetSentFriendRequestsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentFriendRequestsTx", reflect.TypeOf((*MockHub)(nil).GetSentFriendRequestsTx), arg0, arg1)
+}
+
+// GetUpload mocks base method.
+func (m *MockHub) GetUpload(arg0 context.Context, arg1 string) (db.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", arg0, arg1)
+	ret0, _ := ret[0].(db.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockHubMockRecorder) GetUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockHub)(nil).GetUpload), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockHub) GetUser(arg0 context.Context, arg1 pgtype.UUID) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockHubMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockHub)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockHub) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockHubMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockHub)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockHub) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
 	ret0, _ := ret[0].(db.User)
@@ -670,6 +1439,156 @@ func (mr *MockHubMockRecorder) GetWall(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWall", reflect.TypeOf((*MockHub)(nil).GetWall), arg0, arg1)
 }
 
+// GetWallExport mocks base method.
+func (m *MockHub) GetWallExport(arg0 context.Context, arg1 pgtype.UUID) (db.WallExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallExport", arg0, arg1)
+	ret0, _ := ret[0].(db.WallExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallExport indicates an expected call of GetWallExport.
+func (mr *MockHubMockRecorder) GetWallExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallExport", reflect.TypeOf((*MockHub)(nil).GetWallExport), arg0, arg1)
+}
+
+// GetWallForUpdate mocks base method.
+func (m *MockHub) GetWallForUpdate(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallForUpdate indicates an expected call of GetWallForUpdate.
+func (mr *MockHubMockRecorder) GetWallForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallForUpdate", reflect.TypeOf((*MockHub)(nil).GetWallForUpdate), arg0, arg1)
+}
+
+// GetWallRevision mocks base method.
+func (m *MockHub) GetWallRevision(arg0 context.Context, arg1 db.GetWallRevisionParams) (db.WallRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.WallRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallRevision indicates an expected call of GetWallRevision.
+func (mr *MockHubMockRecorder) GetWallRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallRevision", reflect.TypeOf((*MockHub)(nil).GetWallRevision), arg0, arg1)
+}
+
+// GetWallSection mocks base method.
+func (m *MockHub) GetWallSection(arg0 context.Context, arg1 db.GetWallSectionParams) (db.WallSection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallSection", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallSection indicates an expected call of GetWallSection.
+func (mr *MockHubMockRecorder) GetWallSection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallSection", reflect.TypeOf((*MockHub)(nil).GetWallSection), arg0, arg1)
+}
+
+// GetWallSubscription mocks base method.
+func (m *MockHub) GetWallSubscription(arg0 context.Context, arg1 db.GetWallSubscriptionParams) (db.WallSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallSubscription indicates an expected call of GetWallSubscription.
+func (mr *MockHubMockRecorder) GetWallSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallSubscription", reflect.TypeOf((*MockHub)(nil).GetWallSubscription), arg0, arg1)
+}
+
+// GetWallTemplate mocks base method.
+func (m *MockHub) GetWallTemplate(arg0 context.Context, arg1 pgtype.UUID) (db.WallTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.WallTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallTemplate indicates an expected call of GetWallTemplate.
+func (mr *MockHubMockRecorder) GetWallTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallTemplate", reflect.TypeOf((*MockHub)(nil).GetWallTemplate), arg0, arg1)
+}
+
+// HardDeletePosts mocks base method.
+func (m *MockHub) HardDeletePosts(arg0 context.Context, arg1 []pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeletePosts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HardDeletePosts indicates an expected call of HardDeletePosts.
+func (mr *MockHubMockRecorder) HardDeletePosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeletePosts", reflect.TypeOf((*MockHub)(nil).HardDeletePosts), arg0, arg1)
+}
+
+// HardDeleteWalls mocks base method.
+func (m *MockHub) HardDeleteWalls(arg0 context.Context, arg1 []pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteWalls", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HardDeleteWalls indicates an expected call of HardDeleteWalls.
+func (mr *MockHubMockRecorder) HardDeleteWalls(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteWalls", reflect.TypeOf((*MockHub)(nil).HardDeleteWalls), arg0, arg1)
+}
+
+// HidePost mocks base method.
+func (m *MockHub) HidePost(arg0 context.Context, arg1 pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HidePost", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HidePost indicates an expected call of HidePost.
+func (mr *MockHubMockRecorder) HidePost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HidePost", reflect.TypeOf((*MockHub)(nil).HidePost), arg0, arg1)
+}
+
+// HideWall mocks base method.
+func (m *MockHub) HideWall(arg0 context.Context, arg1 pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideWall", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HideWall indicates an expected call of HideWall.
+func (mr *MockHubMockRecorder) HideWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideWall", reflect.TypeOf((*MockHub)(nil).HideWall), arg0, arg1)
+}
+
 // HighlightPost mocks base method.
 func (m *MockHub) HighlightPost(arg0 context.Context, arg1 pgtype.UUID) (db.Post, error) {
 	m.ctrl.T.Helper()
@@ -715,6 +1634,141 @@ func (mr *MockHubMockRecorder) IsUserBlockedTx(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBlockedTx", reflect.TypeOf((*MockHub)(nil).IsUserBlockedTx), arg0, arg1, arg2)
 }
 
+// IsWallModerator mocks base method.
+func (m *MockHub) IsWallModerator(arg0 context.Context, arg1 db.IsWallModeratorParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWallModerator", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsWallModerator indicates an expected call of IsWallModerator.
+func (mr *MockHubMockRecorder) IsWallModerator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWallModerator", reflect.TypeOf((*MockHub)(nil).IsWallModerator), arg0, arg1)
+}
+
+// ListAutoArchiveCandidates mocks base method.
+func (m *MockHub) ListAutoArchiveCandidates(arg0 context.Context, arg1 int32) ([]db.ListAutoArchiveCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAutoArchiveCandidates", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListAutoArchiveCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAutoArchiveCandidates indicates an expected call of ListAutoArchiveCandidates.
+func (mr *MockHubMockRecorder) ListAutoArchiveCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAutoArchiveCandidates", reflect.TypeOf((*MockHub)(nil).ListAutoArchiveCandidates), arg0, arg1)
+}
+
+// ListClonablePostsByWall mocks base method.
+func (m *MockHub) ListClonablePostsByWall(arg0 context.Context, arg1 db.ListClonablePostsByWallParams) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClonablePostsByWall", arg0, arg1)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClonablePostsByWall indicates an expected call of ListClonablePostsByWall.
+func (mr *MockHubMockRecorder) ListClonablePostsByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClonablePostsByWall", reflect.TypeOf((*MockHub)(nil).ListClonablePostsByWall), arg0, arg1)
+}
+
+// ListCommentReplies mocks base method.
+func (m *MockHub) ListCommentReplies(arg0 context.Context, arg1 db.ListCommentRepliesParams) ([]db.ListCommentRepliesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentReplies", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCommentRepliesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentReplies indicates an expected call of ListCommentReplies.
+func (mr *MockHubMockRecorder) ListCommentReplies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentReplies", reflect.TypeOf((*MockHub)(nil).ListCommentReplies), arg0, arg1)
+}
+
+// ListCommentsByPost mocks base method.
+func (m *MockHub) ListCommentsByPost(arg0 context.Context, arg1 db.ListCommentsByPostParams) ([]db.ListCommentsByPostRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsByPost", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCommentsByPostRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsByPost indicates an expected call of ListCommentsByPost.
+func (mr *MockHubMockRecorder) ListCommentsByPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsByPost", reflect.TypeOf((*MockHub)(nil).ListCommentsByPost), arg0, arg1)
+}
+
+// ListDeletedPostsByUser mocks base method.
+func (m *MockHub) ListDeletedPostsByUser(arg0 context.Context, arg1 db.ListDeletedPostsByUserParams) ([]db.ListDeletedPostsByUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedPostsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDeletedPostsByUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedPostsByUser indicates an expected call of ListDeletedPostsByUser.
+func (mr *MockHubMockRecorder) ListDeletedPostsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedPostsByUser", reflect.TypeOf((*MockHub)(nil).ListDeletedPostsByUser), arg0, arg1)
+}
+
+// ListDeletedWallsByUser mocks base method.
+func (m *MockHub) ListDeletedWallsByUser(arg0 context.Context, arg1 db.ListDeletedWallsByUserParams) ([]db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedWallsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedWallsByUser indicates an expected call of ListDeletedWallsByUser.
+func (mr *MockHubMockRecorder) ListDeletedWallsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedWallsByUser", reflect.TypeOf((*MockHub)(nil).ListDeletedWallsByUser), arg0, arg1)
+}
+
+// ListDueScheduledPosts mocks base method.
+func (m *MockHub) ListDueScheduledPosts(arg0 context.Context, arg1 db.ListDueScheduledPostsParams) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduledPosts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduledPosts indicates an expected call of ListDueScheduledPosts.
+func (mr *MockHubMockRecorder) ListDueScheduledPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduledPosts", reflect.TypeOf((*MockHub)(nil).ListDueScheduledPosts), arg0, arg1)
+}
+
+// ListFollowedWalls mocks base method.
+func (m *MockHub) ListFollowedWalls(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListFollowedWallsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowedWalls", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFollowedWallsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowedWalls indicates an expected call of ListFollowedWalls.
+func (mr *MockHubMockRecorder) ListFollowedWalls(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowedWalls", reflect.TypeOf((*MockHub)(nil).ListFollowedWalls), arg0, arg1)
+}
+
 // ListFriendsDetailsByStatus mocks base method.
 func (m *MockHub) ListFriendsDetailsByStatus(arg0 context.Context, arg1 db.ListFriendsDetailsByStatusParams) ([]db.ListFriendsDetailsByStatusRow, error) {
 	m.ctrl.T.Helper()
@@ -790,330 +1844,1299 @@ func (mr *MockHubMockRecorder) ListFriendshipsByUserIdAndStatus(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFriendshipsByUserIdAndStatus", reflect.TypeOf((*MockHub)(nil).ListFriendshipsByUserIdAndStatus), arg0, arg1)
 }
 
+// ListInactiveWallsByUser mocks base method.
+func (m *MockHub) ListInactiveWallsByUser(arg0 context.Context, arg1 db.ListInactiveWallsByUserParams) ([]db.ListInactiveWallsByUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInactiveWallsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListInactiveWallsByUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInactiveWallsByUser indicates an expected call of ListInactiveWallsByUser.
+func (mr *MockHubMockRecorder) ListInactiveWallsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInactiveWallsByUser", reflect.TypeOf((*MockHub)(nil).ListInactiveWallsByUser), arg0, arg1)
+}
+
 // ListLikes mocks base method.
 func (m *MockHub) ListLikes(arg0 context.Context) ([]db.Like, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLikes", arg0)
-	ret0, _ := ret[0].([]db.Like)
+	ret := m.ctrl.Call(m, "ListLikes", arg0)
+	ret0, _ := ret[0].([]db.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockHubMockRecorder) ListLikes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockHub)(nil).ListLikes), arg0)
+}
+
+// ListLikesByPost mocks base method.
+func (m *MockHub) ListLikesByPost(arg0 context.Context, arg1 pgtype.UUID) ([]db.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikesByPost", arg0, arg1)
+	ret0, _ := ret[0].([]db.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikesByPost indicates an expected call of ListLikesByPost.
+func (mr *MockHubMockRecorder) ListLikesByPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikesByPost", reflect.TypeOf((*MockHub)(nil).ListLikesByPost), arg0, arg1)
+}
+
+// ListLikesByUser mocks base method.
+func (m *MockHub) ListLikesByUser(arg0 context.Context, arg1 pgtype.UUID) ([]db.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikesByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikesByUser indicates an expected call of ListLikesByUser.
+func (mr *MockHubMockRecorder) ListLikesByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikesByUser", reflect.TypeOf((*MockHub)(nil).ListLikesByUser), arg0, arg1)
+}
+
+// ListLinkPreviewsByURL mocks base method.
+func (m *MockHub) ListLinkPreviewsByURL(arg0 context.Context, arg1 []string) ([]db.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinkPreviewsByURL", arg0, arg1)
+	ret0, _ := ret[0].([]db.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinkPreviewsByURL indicates an expected call of ListLinkPreviewsByURL.
+func (mr *MockHubMockRecorder) ListLinkPreviewsByURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinkPreviewsByURL", reflect.TypeOf((*MockHub)(nil).ListLinkPreviewsByURL), arg0, arg1)
+}
+
+// ListMediaURLsInUse mocks base method.
+func (m *MockHub) ListMediaURLsInUse(arg0 context.Context, arg1 db.ListMediaURLsInUseParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMediaURLsInUse", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMediaURLsInUse indicates an expected call of ListMediaURLsInUse.
+func (mr *MockHubMockRecorder) ListMediaURLsInUse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaURLsInUse", reflect.TypeOf((*MockHub)(nil).ListMediaURLsInUse), arg0, arg1)
+}
+
+// ListMentionableUsers mocks base method.
+func (m *MockHub) ListMentionableUsers(arg0 context.Context, arg1 db.ListMentionableUsersParams) ([]db.ListMentionableUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMentionableUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMentionableUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMentionableUsers indicates an expected call of ListMentionableUsers.
+func (mr *MockHubMockRecorder) ListMentionableUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMentionableUsers", reflect.TypeOf((*MockHub)(nil).ListMentionableUsers), arg0, arg1)
+}
+
+// ListMentionsByComments mocks base method.
+func (m *MockHub) ListMentionsByComments(arg0 context.Context, arg1 []pgtype.UUID) ([]db.ListMentionsByCommentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMentionsByComments", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMentionsByCommentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMentionsByComments indicates an expected call of ListMentionsByComments.
+func (mr *MockHubMockRecorder) ListMentionsByComments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMentionsByComments", reflect.TypeOf((*MockHub)(nil).ListMentionsByComments), arg0, arg1)
+}
+
+// ListMentionsByPosts mocks base method.
+func (m *MockHub) ListMentionsByPosts(arg0 context.Context, arg1 []pgtype.UUID) ([]db.ListMentionsByPostsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMentionsByPosts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMentionsByPostsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMentionsByPosts indicates an expected call of ListMentionsByPosts.
+func (mr *MockHubMockRecorder) ListMentionsByPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMentionsByPosts", reflect.TypeOf((*MockHub)(nil).ListMentionsByPosts), arg0, arg1)
+}
+
+// ListMutualFriends mocks base method.
+func (m *MockHub) ListMutualFriends(arg0 context.Context, arg1 db.ListMutualFriendsParams) ([]db.ListMutualFriendsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMutualFriends", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMutualFriendsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMutualFriends indicates an expected call of ListMutualFriends.
+func (mr *MockHubMockRecorder) ListMutualFriends(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMutualFriends", reflect.TypeOf((*MockHub)(nil).ListMutualFriends), arg0, arg1)
+}
+
+// ListPendingPostsByWall mocks base method.
+func (m *MockHub) ListPendingPostsByWall(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListPendingPostsByWallRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingPostsByWall", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPendingPostsByWallRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingPostsByWall indicates an expected call of ListPendingPostsByWall.
+func (mr *MockHubMockRecorder) ListPendingPostsByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingPostsByWall", reflect.TypeOf((*MockHub)(nil).ListPendingPostsByWall), arg0, arg1)
+}
+
+// ListPostLikesByWall mocks base method.
+func (m *MockHub) ListPostLikesByWall(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListPostLikesByWallRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostLikesByWall", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPostLikesByWallRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostLikesByWall indicates an expected call of ListPostLikesByWall.
+func (mr *MockHubMockRecorder) ListPostLikesByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostLikesByWall", reflect.TypeOf((*MockHub)(nil).ListPostLikesByWall), arg0, arg1)
+}
+
+// ListPostMediaByPosts mocks base method.
+func (m *MockHub) ListPostMediaByPosts(arg0 context.Context, arg1 []pgtype.UUID) ([]db.PostMedium, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostMediaByPosts", arg0, arg1)
+	ret0, _ := ret[0].([]db.PostMedium)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostMediaByPosts indicates an expected call of ListPostMediaByPosts.
+func (mr *MockHubMockRecorder) ListPostMediaByPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostMediaByPosts", reflect.TypeOf((*MockHub)(nil).ListPostMediaByPosts), arg0, arg1)
+}
+
+// ListPostRevisionMediaByPosts mocks base method.
+func (m *MockHub) ListPostRevisionMediaByPosts(arg0 context.Context, arg1 []pgtype.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostRevisionMediaByPosts", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostRevisionMediaByPosts indicates an expected call of ListPostRevisionMediaByPosts.
+func (mr *MockHubMockRecorder) ListPostRevisionMediaByPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostRevisionMediaByPosts", reflect.TypeOf((*MockHub)(nil).ListPostRevisionMediaByPosts), arg0, arg1)
+}
+
+// ListPostRevisions mocks base method.
+func (m *MockHub) ListPostRevisions(arg0 context.Context, arg1 db.ListPostRevisionsParams) ([]db.ListPostRevisionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPostRevisionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostRevisions indicates an expected call of ListPostRevisions.
+func (mr *MockHubMockRecorder) ListPostRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostRevisions", reflect.TypeOf((*MockHub)(nil).ListPostRevisions), arg0, arg1)
+}
+
+// ListPosts mocks base method.
+func (m *MockHub) ListPosts(arg0 context.Context) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPosts", arg0)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockHubMockRecorder) ListPosts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockHub)(nil).ListPosts), arg0)
+}
+
+// ListPostsByWall mocks base method.
+func (m *MockHub) ListPostsByWall(arg0 context.Context, arg1 pgtype.UUID) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostsByWall", arg0, arg1)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostsByWall indicates an expected call of ListPostsByWall.
+func (mr *MockHubMockRecorder) ListPostsByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostsByWall", reflect.TypeOf((*MockHub)(nil).ListPostsByWall), arg0, arg1)
+}
+
+// ListPostsByWallWithAuthorsDetails mocks base method.
+func (m *MockHub) ListPostsByWallWithAuthorsDetails(arg0 context.Context, arg1 db.ListPostsByWallWithAuthorsDetailsParams) ([]db.ListPostsByWallWithAuthorsDetailsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostsByWallWithAuthorsDetails", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPostsByWallWithAuthorsDetailsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostsByWallWithAuthorsDetails indicates an expected call of ListPostsByWallWithAuthorsDetails.
+func (mr *MockHubMockRecorder) ListPostsByWallWithAuthorsDetails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostsByWallWithAuthorsDetails", reflect.TypeOf((*MockHub)(nil).ListPostsByWallWithAuthorsDetails), arg0, arg1)
+}
+
+// ListPrunablePostRevisions mocks base method.
+func (m *MockHub) ListPrunablePostRevisions(arg0 context.Context, arg1 db.ListPrunablePostRevisionsParams) ([]db.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrunablePostRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrunablePostRevisions indicates an expected call of ListPrunablePostRevisions.
+func (mr *MockHubMockRecorder) ListPrunablePostRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrunablePostRevisions", reflect.TypeOf((*MockHub)(nil).ListPrunablePostRevisions), arg0, arg1)
+}
+
+// ListPrunableWallExports mocks base method.
+func (m *MockHub) ListPrunableWallExports(arg0 context.Context, arg1 db.ListPrunableWallExportsParams) ([]db.WallExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrunableWallExports", arg0, arg1)
+	ret0, _ := ret[0].([]db.WallExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrunableWallExports indicates an expected call of ListPrunableWallExports.
+func (mr *MockHubMockRecorder) ListPrunableWallExports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrunableWallExports", reflect.TypeOf((*MockHub)(nil).ListPrunableWallExports), arg0, arg1)
+}
+
+// ListPublicWallsByTag mocks base method.
+func (m *MockHub) ListPublicWallsByTag(arg0 context.Context, arg1 db.ListPublicWallsByTagParams) ([]db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublicWallsByTag", arg0, arg1)
+	ret0, _ := ret[0].([]db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublicWallsByTag indicates an expected call of ListPublicWallsByTag.
+func (mr *MockHubMockRecorder) ListPublicWallsByTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublicWallsByTag", reflect.TypeOf((*MockHub)(nil).ListPublicWallsByTag), arg0, arg1)
+}
+
+// ListPurgeablePosts mocks base method.
+func (m *MockHub) ListPurgeablePosts(arg0 context.Context, arg1 db.ListPurgeablePostsParams) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeablePosts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeablePosts indicates an expected call of ListPurgeablePosts.
+func (mr *MockHubMockRecorder) ListPurgeablePosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeablePosts", reflect.TypeOf((*MockHub)(nil).ListPurgeablePosts), arg0, arg1)
+}
+
+// ListPurgeableWalls mocks base method.
+func (m *MockHub) ListPurgeableWalls(arg0 context.Context, arg1 db.ListPurgeableWallsParams) ([]db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeableWalls", arg0, arg1)
+	ret0, _ := ret[0].([]db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeableWalls indicates an expected call of ListPurgeableWalls.
+func (mr *MockHubMockRecorder) ListPurgeableWalls(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeableWalls", reflect.TypeOf((*MockHub)(nil).ListPurgeableWalls), arg0, arg1)
+}
+
+// ListReceivedPendingFriendRequests mocks base method.
+func (m *MockHub) ListReceivedPendingFriendRequests(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListReceivedPendingFriendRequestsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReceivedPendingFriendRequests", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListReceivedPendingFriendRequestsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReceivedPendingFriendRequests indicates an expected call of ListReceivedPendingFriendRequests.
+func (mr *MockHubMockRecorder) ListReceivedPendingFriendRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReceivedPendingFriendRequests", reflect.TypeOf((*MockHub)(nil).ListReceivedPendingFriendRequests), arg0, arg1)
+}
+
+// ListReports mocks base method.
+func (m *MockHub) ListReports(arg0 context.Context, arg1 db.ListReportsParams) ([]db.ListReportsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReports", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListReportsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReports indicates an expected call of ListReports.
+func (mr *MockHubMockRecorder) ListReports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReports", reflect.TypeOf((*MockHub)(nil).ListReports), arg0, arg1)
+}
+
+// ListScheduledPostsByAuthor mocks base method.
+func (m *MockHub) ListScheduledPostsByAuthor(arg0 context.Context, arg1 db.ListScheduledPostsByAuthorParams) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledPostsByAuthor", arg0, arg1)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledPostsByAuthor indicates an expected call of ListScheduledPostsByAuthor.
+func (mr *MockHubMockRecorder) ListScheduledPostsByAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledPostsByAuthor", reflect.TypeOf((*MockHub)(nil).ListScheduledPostsByAuthor), arg0, arg1)
+}
+
+// ListSentPendingFriendRequests mocks base method.
+func (m *MockHub) ListSentPendingFriendRequests(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListSentPendingFriendRequestsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSentPendingFriendRequests", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListSentPendingFriendRequestsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSentPendingFriendRequests indicates an expected call of ListSentPendingFriendRequests.
+func (mr *MockHubMockRecorder) ListSentPendingFriendRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSentPendingFriendRequests", reflect.TypeOf((*MockHub)(nil).ListSentPendingFriendRequests), arg0, arg1)
+}
+
+// ListTagsByWall mocks base method.
+func (m *MockHub) ListTagsByWall(arg0 context.Context, arg1 pgtype.UUID) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsByWall", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsByWall indicates an expected call of ListTagsByWall.
+func (mr *MockHubMockRecorder) ListTagsByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsByWall", reflect.TypeOf((*MockHub)(nil).ListTagsByWall), arg0, arg1)
+}
+
+// ListTopWallContributors mocks base method.
+func (m *MockHub) ListTopWallContributors(arg0 context.Context, arg1 db.ListTopWallContributorsParams) ([]db.ListTopWallContributorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTopWallContributors", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTopWallContributorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTopWallContributors indicates an expected call of ListTopWallContributors.
+func (mr *MockHubMockRecorder) ListTopWallContributors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopWallContributors", reflect.TypeOf((*MockHub)(nil).ListTopWallContributors), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockHub) ListUsers(arg0 context.Context) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockHubMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockHub)(nil).ListUsers), arg0)
+}
+
+// ListWallFollowersToNotify mocks base method.
+func (m *MockHub) ListWallFollowersToNotify(arg0 context.Context, arg1 db.ListWallFollowersToNotifyParams) ([]pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallFollowersToNotify", arg0, arg1)
+	ret0, _ := ret[0].([]pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallFollowersToNotify indicates an expected call of ListWallFollowersToNotify.
+func (mr *MockHubMockRecorder) ListWallFollowersToNotify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallFollowersToNotify", reflect.TypeOf((*MockHub)(nil).ListWallFollowersToNotify), arg0, arg1)
+}
+
+// ListWallModerators mocks base method.
+func (m *MockHub) ListWallModerators(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListWallModeratorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallModerators", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWallModeratorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallModerators indicates an expected call of ListWallModerators.
+func (mr *MockHubMockRecorder) ListWallModerators(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallModerators", reflect.TypeOf((*MockHub)(nil).ListWallModerators), arg0, arg1)
+}
+
+// ListWallRevisions mocks base method.
+func (m *MockHub) ListWallRevisions(arg0 context.Context, arg1 db.ListWallRevisionsParams) ([]db.ListWallRevisionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWallRevisionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallRevisions indicates an expected call of ListWallRevisions.
+func (mr *MockHubMockRecorder) ListWallRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallRevisions", reflect.TypeOf((*MockHub)(nil).ListWallRevisions), arg0, arg1)
+}
+
+// ListWallSections mocks base method.
+func (m *MockHub) ListWallSections(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListWallSectionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallSections", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWallSectionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallSections indicates an expected call of ListWallSections.
+func (mr *MockHubMockRecorder) ListWallSections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallSections", reflect.TypeOf((*MockHub)(nil).ListWallSections), arg0, arg1)
+}
+
+// ListWallTemplates mocks base method.
+func (m *MockHub) ListWallTemplates(arg0 context.Context) ([]db.WallTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallTemplates", arg0)
+	ret0, _ := ret[0].([]db.WallTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallTemplates indicates an expected call of ListWallTemplates.
+func (mr *MockHubMockRecorder) ListWallTemplates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallTemplates", reflect.TypeOf((*MockHub)(nil).ListWallTemplates), arg0)
+}
+
+// ListWallViewsByDay mocks base method.
+func (m *MockHub) ListWallViewsByDay(arg0 context.Context, arg1 db.ListWallViewsByDayParams) ([]db.ListWallViewsByDayRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallViewsByDay", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWallViewsByDayRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallViewsByDay indicates an expected call of ListWallViewsByDay.
+func (mr *MockHubMockRecorder) ListWallViewsByDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallViewsByDay", reflect.TypeOf((*MockHub)(nil).ListWallViewsByDay), arg0, arg1)
+}
+
+// ListWalls mocks base method.
+func (m *MockHub) ListWalls(arg0 context.Context) ([]db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWalls", arg0)
+	ret0, _ := ret[0].([]db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWalls indicates an expected call of ListWalls.
+func (mr *MockHubMockRecorder) ListWalls(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalls", reflect.TypeOf((*MockHub)(nil).ListWalls), arg0)
+}
+
+// ListWallsByUser mocks base method.
+func (m *MockHub) ListWallsByUser(arg0 context.Context, arg1 pgtype.UUID) ([]db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallsByUser indicates an expected call of ListWallsByUser.
+func (mr *MockHubMockRecorder) ListWallsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallsByUser", reflect.TypeOf((*MockHub)(nil).ListWallsByUser), arg0, arg1)
+}
+
+// MarkAllNotificationsAsRead mocks base method.
+func (m *MockHub) MarkAllNotificationsAsRead(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsAsRead indicates an expected call of MarkAllNotificationsAsRead.
+func (mr *MockHubMockRecorder) MarkAllNotificationsAsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsAsRead", reflect.TypeOf((*MockHub)(nil).MarkAllNotificationsAsRead), arg0, arg1)
+}
+
+// MarkNotificationAsRead mocks base method.
+func (m *MockHub) MarkNotificationAsRead(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationAsRead indicates an expected call of MarkNotificationAsRead.
+func (mr *MockHubMockRecorder) MarkNotificationAsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationAsRead", reflect.TypeOf((*MockHub)(nil).MarkNotificationAsRead), arg0, arg1)
+}
+
+// MarkWallArchiveWarned mocks base method.
+func (m *MockHub) MarkWallArchiveWarned(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWallArchiveWarned", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWallArchiveWarned indicates an expected call of MarkWallArchiveWarned.
+func (mr *MockHubMockRecorder) MarkWallArchiveWarned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWallArchiveWarned", reflect.TypeOf((*MockHub)(nil).MarkWallArchiveWarned), arg0, arg1)
+}
+
+// MarkWallExportProcessing mocks base method.
+func (m *MockHub) MarkWallExportProcessing(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWallExportProcessing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWallExportProcessing indicates an expected call of MarkWallExportProcessing.
+func (mr *MockHubMockRecorder) MarkWallExportProcessing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWallExportProcessing", reflect.TypeOf((*MockHub)(nil).MarkWallExportProcessing), arg0, arg1)
+}
+
+// ModeratePost mocks base method.
+func (m *MockHub) ModeratePost(arg0 context.Context, arg1 db.ModeratePostParams) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModeratePost", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModeratePost indicates an expected call of ModeratePost.
+func (mr *MockHubMockRecorder) ModeratePost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModeratePost", reflect.TypeOf((*MockHub)(nil).ModeratePost), arg0, arg1)
+}
+
+// ModeratePostsTx mocks base method.
+func (m *MockHub) ModeratePostsTx(arg0 context.Context, arg1 pgtype.UUID, arg2 []pgtype.UUID, arg3 db.PostStatus) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModeratePostsTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModeratePostsTx indicates an expected call of ModeratePostsTx.
+func (mr *MockHubMockRecorder) ModeratePostsTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModeratePostsTx", reflect.TypeOf((*MockHub)(nil).ModeratePostsTx), arg0, arg1, arg2, arg3)
+}
+
+// MoveSectionPosts mocks base method.
+func (m *MockHub) MoveSectionPosts(arg0 context.Context, arg1 db.MoveSectionPostsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveSectionPosts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveSectionPosts indicates an expected call of MoveSectionPosts.
+func (mr *MockHubMockRecorder) MoveSectionPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveSectionPosts", reflect.TypeOf((*MockHub)(nil).MoveSectionPosts), arg0, arg1)
+}
+
+// PinUnpinWall mocks base method.
+func (m *MockHub) PinUnpinWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinUnpinWall", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinUnpinWall indicates an expected call of PinUnpinWall.
+func (mr *MockHubMockRecorder) PinUnpinWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinUnpinWall", reflect.TypeOf((*MockHub)(nil).PinUnpinWall), arg0, arg1)
+}
+
+// PrivatizeWall mocks base method.
+func (m *MockHub) PrivatizeWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivatizeWall", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrivatizeWall indicates an expected call of PrivatizeWall.
+func (mr *MockHubMockRecorder) PrivatizeWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivatizeWall", reflect.TypeOf((*MockHub)(nil).PrivatizeWall), arg0, arg1)
+}
+
+// PublicizeWall mocks base method.
+func (m *MockHub) PublicizeWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicizeWall", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublicizeWall indicates an expected call of PublicizeWall.
+func (mr *MockHubMockRecorder) PublicizeWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicizeWall", reflect.TypeOf((*MockHub)(nil).PublicizeWall), arg0, arg1)
+}
+
+// PublishScheduledPost mocks base method.
+func (m *MockHub) PublishScheduledPost(arg0 context.Context, arg1 db.PublishScheduledPostParams) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduledPost", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduledPost indicates an expected call of PublishScheduledPost.
+func (mr *MockHubMockRecorder) PublishScheduledPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduledPost", reflect.TypeOf((*MockHub)(nil).PublishScheduledPost), arg0, arg1)
+}
+
+// PurgeDeletedTx mocks base method.
+func (m *MockHub) PurgeDeletedTx(arg0 context.Context, arg1, arg2 []pgtype.UUID) (db.PurgeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.PurgeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedTx indicates an expected call of PurgeDeletedTx.
+func (mr *MockHubMockRecorder) PurgeDeletedTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedTx", reflect.TypeOf((*MockHub)(nil).PurgeDeletedTx), arg0, arg1, arg2)
+}
+
+// RecordWallViews mocks base method.
+func (m *MockHub) RecordWallViews(arg0 context.Context, arg1 db.RecordWallViewsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWallViews", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWallViews indicates an expected call of RecordWallViews.
+func (mr *MockHubMockRecorder) RecordWallViews(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWallViews", reflect.TypeOf((*MockHub)(nil).RecordWallViews), arg0, arg1)
+}
+
+// RefreshMaterializedViews mocks base method.
+func (m *MockHub) RefreshMaterializedViews(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshMaterializedViews", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshMaterializedViews indicates an expected call of RefreshMaterializedViews.
+func (mr *MockHubMockRecorder) RefreshMaterializedViews(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshMaterializedViews", reflect.TypeOf((*MockHub)(nil).RefreshMaterializedViews), arg0)
+}
+
+// RefreshTagUsageCounts mocks base method.
+func (m *MockHub) RefreshTagUsageCounts(arg0 context.Context, arg1 []pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTagUsageCounts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshTagUsageCounts indicates an expected call of RefreshTagUsageCounts.
+func (mr *MockHubMockRecorder) RefreshTagUsageCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTagUsageCounts", reflect.TypeOf((*MockHub)(nil).RefreshTagUsageCounts), arg0, arg1)
+}
+
+// RejectFriendship mocks base method.
+func (m *MockHub) RejectFriendship(arg0 context.Context, arg1 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectFriendship", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectFriendship indicates an expected call of RejectFriendship.
+func (mr *MockHubMockRecorder) RejectFriendship(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFriendship", reflect.TypeOf((*MockHub)(nil).RejectFriendship), arg0, arg1)
+}
+
+// RemoveLikesCount mocks base method.
+func (m *MockHub) RemoveLikesCount(arg0 context.Context, arg1 pgtype.UUID) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLikesCount", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveLikesCount indicates an expected call of RemoveLikesCount.
+func (mr *MockHubMockRecorder) RemoveLikesCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLikesCount", reflect.TypeOf((*MockHub)(nil).RemoveLikesCount), arg0, arg1)
+}
+
+// RemoveReactionTx mocks base method.
+func (m *MockHub) RemoveReactionTx(arg0 context.Context, arg1, arg2 pgtype.UUID) (db.ReactionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReactionTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.ReactionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReactionTx indicates an expected call of RemoveReactionTx.
+func (mr *MockHubMockRecorder) RemoveReactionTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReactionTx", reflect.TypeOf((*MockHub)(nil).RemoveReactionTx), arg0, arg1, arg2)
+}
+
+// RemoveWallModerator mocks base method.
+func (m *MockHub) RemoveWallModerator(arg0 context.Context, arg1 db.RemoveWallModeratorParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWallModerator", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWallModerator indicates an expected call of RemoveWallModerator.
+func (mr *MockHubMockRecorder) RemoveWallModerator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWallModerator", reflect.TypeOf((*MockHub)(nil).RemoveWallModerator), arg0, arg1)
+}
+
+// RenameWallSection mocks base method.
+func (m *MockHub) RenameWallSection(arg0 context.Context, arg1 db.RenameWallSectionParams) (db.WallSection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameWallSection", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameWallSection indicates an expected call of RenameWallSection.
+func (mr *MockHubMockRecorder) RenameWallSection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameWallSection", reflect.TypeOf((*MockHub)(nil).RenameWallSection), arg0, arg1)
+}
+
+// ReorderWallSectionsTx mocks base method.
+func (m *MockHub) ReorderWallSectionsTx(arg0 context.Context, arg1 pgtype.UUID, arg2 []pgtype.UUID) ([]db.WallSection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderWallSectionsTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderWallSectionsTx indicates an expected call of ReorderWallSectionsTx.
+func (mr *MockHubMockRecorder) ReorderWallSectionsTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderWallSectionsTx", reflect.TypeOf((*MockHub)(nil).ReorderWallSectionsTx), arg0, arg1, arg2)
+}
+
+// ReplaceMentionsTx mocks base method.
+func (m *MockHub) ReplaceMentionsTx(arg0 context.Context, arg1 db.ReplaceMentionsTxParams) (db.ReplaceMentionsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMentionsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReplaceMentionsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceMentionsTx indicates an expected call of ReplaceMentionsTx.
+func (mr *MockHubMockRecorder) ReplaceMentionsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMentionsTx", reflect.TypeOf((*MockHub)(nil).ReplaceMentionsTx), arg0, arg1)
+}
+
+// ReschedulePost mocks base method.
+func (m *MockHub) ReschedulePost(arg0 context.Context, arg1 db.ReschedulePostParams) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReschedulePost", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReschedulePost indicates an expected call of ReschedulePost.
+func (mr *MockHubMockRecorder) ReschedulePost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReschedulePost", reflect.TypeOf((*MockHub)(nil).ReschedulePost), arg0, arg1)
+}
+
+// ResolveReportsByTarget mocks base method.
+func (m *MockHub) ResolveReportsByTarget(arg0 context.Context, arg1 db.ResolveReportsByTargetParams) ([]db.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReportsByTarget", arg0, arg1)
+	ret0, _ := ret[0].([]db.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveReportsByTarget indicates an expected call of ResolveReportsByTarget.
+func (mr *MockHubMockRecorder) ResolveReportsByTarget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReportsByTarget", reflect.TypeOf((*MockHub)(nil).ResolveReportsByTarget), arg0, arg1)
+}
+
+// RestorePost mocks base method.
+func (m *MockHub) RestorePost(arg0 context.Context, arg1 pgtype.UUID) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePost", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePost indicates an expected call of RestorePost.
+func (mr *MockHubMockRecorder) RestorePost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePost", reflect.TypeOf((*MockHub)(nil).RestorePost), arg0, arg1)
+}
+
+// RestorePostsByWall mocks base method.
+func (m *MockHub) RestorePostsByWall(arg0 context.Context, arg1 db.RestorePostsByWallParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePostsByWall", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestorePostsByWall indicates an expected call of RestorePostsByWall.
+func (mr *MockHubMockRecorder) RestorePostsByWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePostsByWall", reflect.TypeOf((*MockHub)(nil).RestorePostsByWall), arg0, arg1)
+}
+
+// RestoreWall mocks base method.
+func (m *MockHub) RestoreWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWall", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreWall indicates an expected call of RestoreWall.
+func (mr *MockHubMockRecorder) RestoreWall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWall", reflect.TypeOf((*MockHub)(nil).RestoreWall), arg0, arg1)
+}
+
+// RestoreWallTx mocks base method.
+func (m *MockHub) RestoreWallTx(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWallTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLikes indicates an expected call of ListLikes.
-func (mr *MockHubMockRecorder) ListLikes(arg0 interface{}) *gomock.Call {
+// RestoreWallTx indicates an expected call of RestoreWallTx.
+func (mr *MockHubMockRecorder) RestoreWallTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockHub)(nil).ListLikes), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWallTx", reflect.TypeOf((*MockHub)(nil).RestoreWallTx), arg0, arg1)
 }
 
-// ListLikesByPost mocks base method.
-func (m *MockHub) ListLikesByPost(arg0 context.Context, arg1 pgtype.UUID) ([]db.Like, error) {
+// RevertWallToRevision mocks base method.
+func (m *MockHub) RevertWallToRevision(arg0 context.Context, arg1 db.RevertWallToRevisionParams) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLikesByPost", arg0, arg1)
-	ret0, _ := ret[0].([]db.Like)
+	ret := m.ctrl.Call(m, "RevertWallToRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLikesByPost indicates an expected call of ListLikesByPost.
-func (mr *MockHubMockRecorder) ListLikesByPost(arg0, arg1 interface{}) *gomock.Call {
+// RevertWallToRevision indicates an expected call of RevertWallToRevision.
+func (mr *MockHubMockRecorder) RevertWallToRevision(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikesByPost", reflect.TypeOf((*MockHub)(nil).ListLikesByPost), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertWallToRevision", reflect.TypeOf((*MockHub)(nil).RevertWallToRevision), arg0, arg1)
 }
 
-// ListLikesByUser mocks base method.
-func (m *MockHub) ListLikesByUser(arg0 context.Context, arg1 pgtype.UUID) ([]db.Like, error) {
+// RevertWallTx mocks base method.
+func (m *MockHub) RevertWallTx(arg0 context.Context, arg1, arg2, arg3 pgtype.UUID) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLikesByUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.Like)
+	ret := m.ctrl.Call(m, "RevertWallTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLikesByUser indicates an expected call of ListLikesByUser.
-func (mr *MockHubMockRecorder) ListLikesByUser(arg0, arg1 interface{}) *gomock.Call {
+// RevertWallTx indicates an expected call of RevertWallTx.
+func (mr *MockHubMockRecorder) RevertWallTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikesByUser", reflect.TypeOf((*MockHub)(nil).ListLikesByUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertWallTx", reflect.TypeOf((*MockHub)(nil).RevertWallTx), arg0, arg1, arg2, arg3)
 }
 
-// ListMutualFriends mocks base method.
-func (m *MockHub) ListMutualFriends(arg0 context.Context, arg1 db.ListMutualFriendsParams) ([]db.ListMutualFriendsRow, error) {
+// SearchTags mocks base method.
+func (m *MockHub) SearchTags(arg0 context.Context, arg1 db.SearchTagsParams) ([]db.Tag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMutualFriends", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListMutualFriendsRow)
+	ret := m.ctrl.Call(m, "SearchTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMutualFriends indicates an expected call of ListMutualFriends.
-func (mr *MockHubMockRecorder) ListMutualFriends(arg0, arg1 interface{}) *gomock.Call {
+// SearchTags indicates an expected call of SearchTags.
+func (mr *MockHubMockRecorder) SearchTags(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMutualFriends", reflect.TypeOf((*MockHub)(nil).ListMutualFriends), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTags", reflect.TypeOf((*MockHub)(nil).SearchTags), arg0, arg1)
 }
 
-// ListPosts mocks base method.
-func (m *MockHub) ListPosts(arg0 context.Context) ([]db.Post, error) {
+// SearchUsersILike mocks base method.
+func (m *MockHub) SearchUsersILike(arg0 context.Context, arg1 pgtype.Text) ([]db.SearchUsersILikeRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPosts", arg0)
-	ret0, _ := ret[0].([]db.Post)
+	ret := m.ctrl.Call(m, "SearchUsersILike", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchUsersILikeRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPosts indicates an expected call of ListPosts.
-func (mr *MockHubMockRecorder) ListPosts(arg0 interface{}) *gomock.Call {
+// SearchUsersILike indicates an expected call of SearchUsersILike.
+func (mr *MockHubMockRecorder) SearchUsersILike(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockHub)(nil).ListPosts), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersILike", reflect.TypeOf((*MockHub)(nil).SearchUsersILike), arg0, arg1)
 }
 
-// ListPostsByWall mocks base method.
-func (m *MockHub) ListPostsByWall(arg0 context.Context, arg1 pgtype.UUID) ([]db.Post, error) {
+// SearchUsersTrigram mocks base method.
+func (m *MockHub) SearchUsersTrigram(arg0 context.Context, arg1 string) ([]db.SearchUsersTrigramRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPostsByWall", arg0, arg1)
-	ret0, _ := ret[0].([]db.Post)
+	ret := m.ctrl.Call(m, "SearchUsersTrigram", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchUsersTrigramRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPostsByWall indicates an expected call of ListPostsByWall.
-func (mr *MockHubMockRecorder) ListPostsByWall(arg0, arg1 interface{}) *gomock.Call {
+// SearchUsersTrigram indicates an expected call of SearchUsersTrigram.
+func (mr *MockHubMockRecorder) SearchUsersTrigram(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostsByWall", reflect.TypeOf((*MockHub)(nil).ListPostsByWall), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersTrigram", reflect.TypeOf((*MockHub)(nil).SearchUsersTrigram), arg0, arg1)
 }
 
-// ListPostsByWallWithAuthorsDetails mocks base method.
-func (m *MockHub) ListPostsByWallWithAuthorsDetails(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListPostsByWallWithAuthorsDetailsRow, error) {
+// SetLikeReaction mocks base method.
+func (m *MockHub) SetLikeReaction(arg0 context.Context, arg1 db.SetLikeReactionParams) (db.Like, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPostsByWallWithAuthorsDetails", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListPostsByWallWithAuthorsDetailsRow)
+	ret := m.ctrl.Call(m, "SetLikeReaction", arg0, arg1)
+	ret0, _ := ret[0].(db.Like)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPostsByWallWithAuthorsDetails indicates an expected call of ListPostsByWallWithAuthorsDetails.
-func (mr *MockHubMockRecorder) ListPostsByWallWithAuthorsDetails(arg0, arg1 interface{}) *gomock.Call {
+// SetLikeReaction indicates an expected call of SetLikeReaction.
+func (mr *MockHubMockRecorder) SetLikeReaction(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostsByWallWithAuthorsDetails", reflect.TypeOf((*MockHub)(nil).ListPostsByWallWithAuthorsDetails), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikeReaction", reflect.TypeOf((*MockHub)(nil).SetLikeReaction), arg0, arg1)
 }
 
-// ListReceivedPendingFriendRequests mocks base method.
-func (m *MockHub) ListReceivedPendingFriendRequests(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListReceivedPendingFriendRequestsRow, error) {
+// SetPostHidden mocks base method.
+func (m *MockHub) SetPostHidden(arg0 context.Context, arg1 db.SetPostHiddenParams) (db.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListReceivedPendingFriendRequests", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListReceivedPendingFriendRequestsRow)
+	ret := m.ctrl.Call(m, "SetPostHidden", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListReceivedPendingFriendRequests indicates an expected call of ListReceivedPendingFriendRequests.
-func (mr *MockHubMockRecorder) ListReceivedPendingFriendRequests(arg0, arg1 interface{}) *gomock.Call {
+// SetPostHidden indicates an expected call of SetPostHidden.
+func (mr *MockHubMockRecorder) SetPostHidden(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReceivedPendingFriendRequests", reflect.TypeOf((*MockHub)(nil).ListReceivedPendingFriendRequests), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostHidden", reflect.TypeOf((*MockHub)(nil).SetPostHidden), arg0, arg1)
 }
 
-// ListSentPendingFriendRequests mocks base method.
-func (m *MockHub) ListSentPendingFriendRequests(arg0 context.Context, arg1 pgtype.UUID) ([]db.ListSentPendingFriendRequestsRow, error) {
+// SetPostSection mocks base method.
+func (m *MockHub) SetPostSection(arg0 context.Context, arg1 db.SetPostSectionParams) (db.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSentPendingFriendRequests", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListSentPendingFriendRequestsRow)
+	ret := m.ctrl.Call(m, "SetPostSection", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSentPendingFriendRequests indicates an expected call of ListSentPendingFriendRequests.
-func (mr *MockHubMockRecorder) ListSentPendingFriendRequests(arg0, arg1 interface{}) *gomock.Call {
+// SetPostSection indicates an expected call of SetPostSection.
+func (mr *MockHubMockRecorder) SetPostSection(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSentPendingFriendRequests", reflect.TypeOf((*MockHub)(nil).ListSentPendingFriendRequests), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostSection", reflect.TypeOf((*MockHub)(nil).SetPostSection), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockHub) ListUsers(arg0 context.Context) ([]db.User, error) {
+// SetReactionTx mocks base method.
+func (m *MockHub) SetReactionTx(arg0 context.Context, arg1, arg2 pgtype.UUID, arg3 string) (db.ReactionTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "SetReactionTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(db.ReactionTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockHubMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
+// SetReactionTx indicates an expected call of SetReactionTx.
+func (mr *MockHubMockRecorder) SetReactionTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockHub)(nil).ListUsers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReactionTx", reflect.TypeOf((*MockHub)(nil).SetReactionTx), arg0, arg1, arg2, arg3)
 }
 
-// ListWalls mocks base method.
-func (m *MockHub) ListWalls(arg0 context.Context) ([]db.Wall, error) {
+// SetUserAutoArchiveDays mocks base method.
+func (m *MockHub) SetUserAutoArchiveDays(arg0 context.Context, arg1 db.SetUserAutoArchiveDaysParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWalls", arg0)
-	ret0, _ := ret[0].([]db.Wall)
+	ret := m.ctrl.Call(m, "SetUserAutoArchiveDays", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWalls indicates an expected call of ListWalls.
-func (mr *MockHubMockRecorder) ListWalls(arg0 interface{}) *gomock.Call {
+// SetUserAutoArchiveDays indicates an expected call of SetUserAutoArchiveDays.
+func (mr *MockHubMockRecorder) SetUserAutoArchiveDays(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalls", reflect.TypeOf((*MockHub)(nil).ListWalls), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAutoArchiveDays", reflect.TypeOf((*MockHub)(nil).SetUserAutoArchiveDays), arg0, arg1)
 }
 
-// ListWallsByUser mocks base method.
-func (m *MockHub) ListWallsByUser(arg0 context.Context, arg1 pgtype.UUID) ([]db.Wall, error) {
+// SetUserSuspension mocks base method.
+func (m *MockHub) SetUserSuspension(arg0 context.Context, arg1 db.SetUserSuspensionParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWallsByUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.Wall)
+	ret := m.ctrl.Call(m, "SetUserSuspension", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWallsByUser indicates an expected call of ListWallsByUser.
-func (mr *MockHubMockRecorder) ListWallsByUser(arg0, arg1 interface{}) *gomock.Call {
+// SetUserSuspension indicates an expected call of SetUserSuspension.
+func (mr *MockHubMockRecorder) SetUserSuspension(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallsByUser", reflect.TypeOf((*MockHub)(nil).ListWallsByUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserSuspension", reflect.TypeOf((*MockHub)(nil).SetUserSuspension), arg0, arg1)
 }
 
-// MarkAllNotificationsAsRead mocks base method.
-func (m *MockHub) MarkAllNotificationsAsRead(arg0 context.Context, arg1 pgtype.UUID) error {
+// SetWallAllowAnonymous mocks base method.
+func (m *MockHub) SetWallAllowAnonymous(arg0 context.Context, arg1 db.SetWallAllowAnonymousParams) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAllNotificationsAsRead", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetWallAllowAnonymous", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MarkAllNotificationsAsRead indicates an expected call of MarkAllNotificationsAsRead.
-func (mr *MockHubMockRecorder) MarkAllNotificationsAsRead(arg0, arg1 interface{}) *gomock.Call {
+// SetWallAllowAnonymous indicates an expected call of SetWallAllowAnonymous.
+func (mr *MockHubMockRecorder) SetWallAllowAnonymous(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsAsRead", reflect.TypeOf((*MockHub)(nil).MarkAllNotificationsAsRead), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallAllowAnonymous", reflect.TypeOf((*MockHub)(nil).SetWallAllowAnonymous), arg0, arg1)
 }
 
-// MarkNotificationAsRead mocks base method.
-func (m *MockHub) MarkNotificationAsRead(arg0 context.Context, arg1 pgtype.UUID) error {
+// SetWallHidden mocks base method.
+func (m *MockHub) SetWallHidden(arg0 context.Context, arg1 db.SetWallHiddenParams) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationAsRead", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetWallHidden", arg0, arg1)
+	ret0, _ := ret[0].(db.Wall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MarkNotificationAsRead indicates an expected call of MarkNotificationAsRead.
-func (mr *MockHubMockRecorder) MarkNotificationAsRead(arg0, arg1 interface{}) *gomock.Call {
+// SetWallHidden indicates an expected call of SetWallHidden.
+func (mr *MockHubMockRecorder) SetWallHidden(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationAsRead", reflect.TypeOf((*MockHub)(nil).MarkNotificationAsRead), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallHidden", reflect.TypeOf((*MockHub)(nil).SetWallHidden), arg0, arg1)
 }
 
-// PinUnpinWall mocks base method.
-func (m *MockHub) PinUnpinWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+// SetWallModeration mocks base method.
+func (m *MockHub) SetWallModeration(arg0 context.Context, arg1 db.SetWallModerationParams) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PinUnpinWall", arg0, arg1)
+	ret := m.ctrl.Call(m, "SetWallModeration", arg0, arg1)
 	ret0, _ := ret[0].(db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PinUnpinWall indicates an expected call of PinUnpinWall.
-func (mr *MockHubMockRecorder) PinUnpinWall(arg0, arg1 interface{}) *gomock.Call {
+// SetWallModeration indicates an expected call of SetWallModeration.
+func (mr *MockHubMockRecorder) SetWallModeration(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinUnpinWall", reflect.TypeOf((*MockHub)(nil).PinUnpinWall), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallModeration", reflect.TypeOf((*MockHub)(nil).SetWallModeration), arg0, arg1)
 }
 
-// PrivatizeWall mocks base method.
-func (m *MockHub) PrivatizeWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+// SetWallPinOrder mocks base method.
+func (m *MockHub) SetWallPinOrder(arg0 context.Context, arg1 db.SetWallPinOrderParams) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PrivatizeWall", arg0, arg1)
+	ret := m.ctrl.Call(m, "SetWallPinOrder", arg0, arg1)
 	ret0, _ := ret[0].(db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PrivatizeWall indicates an expected call of PrivatizeWall.
-func (mr *MockHubMockRecorder) PrivatizeWall(arg0, arg1 interface{}) *gomock.Call {
+// SetWallPinOrder indicates an expected call of SetWallPinOrder.
+func (mr *MockHubMockRecorder) SetWallPinOrder(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivatizeWall", reflect.TypeOf((*MockHub)(nil).PrivatizeWall), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallPinOrder", reflect.TypeOf((*MockHub)(nil).SetWallPinOrder), arg0, arg1)
 }
 
-// PublicizeWall mocks base method.
-func (m *MockHub) PublicizeWall(arg0 context.Context, arg1 pgtype.UUID) (db.Wall, error) {
+// SetWallPinsTx mocks base method.
+func (m *MockHub) SetWallPinsTx(arg0 context.Context, arg1 pgtype.UUID, arg2 []pgtype.UUID) ([]db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PublicizeWall", arg0, arg1)
-	ret0, _ := ret[0].(db.Wall)
+	ret := m.ctrl.Call(m, "SetWallPinsTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PublicizeWall indicates an expected call of PublicizeWall.
-func (mr *MockHubMockRecorder) PublicizeWall(arg0, arg1 interface{}) *gomock.Call {
+// SetWallPinsTx indicates an expected call of SetWallPinsTx.
+func (mr *MockHubMockRecorder) SetWallPinsTx(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicizeWall", reflect.TypeOf((*MockHub)(nil).PublicizeWall), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallPinsTx", reflect.TypeOf((*MockHub)(nil).SetWallPinsTx), arg0, arg1, arg2)
 }
 
-// RefreshMaterializedViews mocks base method.
-func (m *MockHub) RefreshMaterializedViews(arg0 context.Context) error {
+// SetWallSectionPosition mocks base method.
+func (m *MockHub) SetWallSectionPosition(arg0 context.Context, arg1 db.SetWallSectionPositionParams) (db.WallSection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshMaterializedViews", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetWallSectionPosition", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RefreshMaterializedViews indicates an expected call of RefreshMaterializedViews.
-func (mr *MockHubMockRecorder) RefreshMaterializedViews(arg0 interface{}) *gomock.Call {
+// SetWallSectionPosition indicates an expected call of SetWallSectionPosition.
+func (mr *MockHubMockRecorder) SetWallSectionPosition(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshMaterializedViews", reflect.TypeOf((*MockHub)(nil).RefreshMaterializedViews), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallSectionPosition", reflect.TypeOf((*MockHub)(nil).SetWallSectionPosition), arg0, arg1)
 }
 
-// RejectFriendship mocks base method.
-func (m *MockHub) RejectFriendship(arg0 context.Context, arg1 pgtype.UUID) error {
+// SetWallSubscriptionMuted mocks base method.
+func (m *MockHub) SetWallSubscriptionMuted(arg0 context.Context, arg1 db.SetWallSubscriptionMutedParams) (db.WallSubscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RejectFriendship", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetWallSubscriptionMuted", arg0, arg1)
+	ret0, _ := ret[0].(db.WallSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RejectFriendship indicates an expected call of RejectFriendship.
-func (mr *MockHubMockRecorder) RejectFriendship(arg0, arg1 interface{}) *gomock.Call {
+// SetWallSubscriptionMuted indicates an expected call of SetWallSubscriptionMuted.
+func (mr *MockHubMockRecorder) SetWallSubscriptionMuted(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFriendship", reflect.TypeOf((*MockHub)(nil).RejectFriendship), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallSubscriptionMuted", reflect.TypeOf((*MockHub)(nil).SetWallSubscriptionMuted), arg0, arg1)
 }
 
-// RemoveLikesCount mocks base method.
-func (m *MockHub) RemoveLikesCount(arg0 context.Context, arg1 pgtype.UUID) (db.Post, error) {
+// SetWallTagsTx mocks base method.
+func (m *MockHub) SetWallTagsTx(arg0 context.Context, arg1 pgtype.UUID, arg2 []string) ([]db.Tag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveLikesCount", arg0, arg1)
-	ret0, _ := ret[0].(db.Post)
+	ret := m.ctrl.Call(m, "SetWallTagsTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.Tag)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RemoveLikesCount indicates an expected call of RemoveLikesCount.
-func (mr *MockHubMockRecorder) RemoveLikesCount(arg0, arg1 interface{}) *gomock.Call {
+// SetWallTagsTx indicates an expected call of SetWallTagsTx.
+func (mr *MockHubMockRecorder) SetWallTagsTx(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLikesCount", reflect.TypeOf((*MockHub)(nil).RemoveLikesCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallTagsTx", reflect.TypeOf((*MockHub)(nil).SetWallTagsTx), arg0, arg1, arg2)
 }
 
-// SearchUsersILike mocks base method.
-func (m *MockHub) SearchUsersILike(arg0 context.Context, arg1 pgtype.Text) ([]db.SearchUsersILikeRow, error) {
+// SetWallVisibilityTx mocks base method.
+func (m *MockHub) SetWallVisibilityTx(arg0 context.Context, arg1, arg2 pgtype.UUID, arg3 bool) (db.Wall, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsersILike", arg0, arg1)
-	ret0, _ := ret[0].([]db.SearchUsersILikeRow)
+	ret := m.ctrl.Call(m, "SetWallVisibilityTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(db.Wall)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchUsersILike indicates an expected call of SearchUsersILike.
-func (mr *MockHubMockRecorder) SearchUsersILike(arg0, arg1 interface{}) *gomock.Call {
+// SetWallVisibilityTx indicates an expected call of SetWallVisibilityTx.
+func (mr *MockHubMockRecorder) SetWallVisibilityTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersILike", reflect.TypeOf((*MockHub)(nil).SearchUsersILike), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWallVisibilityTx", reflect.TypeOf((*MockHub)(nil).SetWallVisibilityTx), arg0, arg1, arg2, arg3)
 }
 
-// SearchUsersTrigram mocks base method.
-func (m *MockHub) SearchUsersTrigram(arg0 context.Context, arg1 string) ([]db.SearchUsersTrigramRow, error) {
+// SoftDeleteComment mocks base method.
+func (m *MockHub) SoftDeleteComment(arg0 context.Context, arg1 pgtype.UUID) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsersTrigram", arg0, arg1)
-	ret0, _ := ret[0].([]db.SearchUsersTrigramRow)
+	ret := m.ctrl.Call(m, "SoftDeleteComment", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchUsersTrigram indicates an expected call of SearchUsersTrigram.
-func (mr *MockHubMockRecorder) SearchUsersTrigram(arg0, arg1 interface{}) *gomock.Call {
+// SoftDeleteComment indicates an expected call of SoftDeleteComment.
+func (mr *MockHubMockRecorder) SoftDeleteComment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersTrigram", reflect.TypeOf((*MockHub)(nil).SearchUsersTrigram), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteComment", reflect.TypeOf((*MockHub)(nil).SoftDeleteComment), arg0, arg1)
 }
 
 // UnarchiveWall mocks base method.
@@ -1144,6 +3167,20 @@ func (mr *MockHubMockRecorder) UnblockUserTx(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUserTx", reflect.TypeOf((*MockHub)(nil).UnblockUserTx), arg0, arg1, arg2)
 }
 
+// UnfollowWallTx mocks base method.
+func (m *MockHub) UnfollowWallTx(arg0 context.Context, arg1, arg2 pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowWallTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowWallTx indicates an expected call of UnfollowWallTx.
+func (mr *MockHubMockRecorder) UnfollowWallTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowWallTx", reflect.TypeOf((*MockHub)(nil).UnfollowWallTx), arg0, arg1, arg2)
+}
+
 // UnhighlightPost mocks base method.
 func (m *MockHub) UnhighlightPost(arg0 context.Context, arg1 pgtype.UUID) (db.Post, error) {
 	m.ctrl.T.Helper()
@@ -1159,6 +3196,21 @@ func (mr *MockHubMockRecorder) UnhighlightPost(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhighlightPost", reflect.TypeOf((*MockHub)(nil).UnhighlightPost), arg0, arg1)
 }
 
+// UpdateCommentBody mocks base method.
+func (m *MockHub) UpdateCommentBody(arg0 context.Context, arg1 db.UpdateCommentBodyParams) (db.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCommentBody", arg0, arg1)
+	ret0, _ := ret[0].(db.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCommentBody indicates an expected call of UpdateCommentBody.
+func (mr *MockHubMockRecorder) UpdateCommentBody(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCommentBody", reflect.TypeOf((*MockHub)(nil).UpdateCommentBody), arg0, arg1)
+}
+
 // UpdateFriendship mocks base method.
 func (m *MockHub) UpdateFriendship(arg0 context.Context, arg1 db.UpdateFriendshipParams) (db.Friendship, error) {
 	m.ctrl.T.Helper()
@@ -1189,6 +3241,36 @@ func (mr *MockHubMockRecorder) UpdatePost(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockHub)(nil).UpdatePost), arg0, arg1)
 }
 
+// UpdatePostLayout mocks base method.
+func (m *MockHub) UpdatePostLayout(arg0 context.Context, arg1 db.UpdatePostLayoutParams) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostLayout", arg0, arg1)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePostLayout indicates an expected call of UpdatePostLayout.
+func (mr *MockHubMockRecorder) UpdatePostLayout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostLayout", reflect.TypeOf((*MockHub)(nil).UpdatePostLayout), arg0, arg1)
+}
+
+// UpdatePostTx mocks base method.
+func (m *MockHub) UpdatePostTx(arg0 context.Context, arg1 db.UpdatePostParams, arg2 pgtype.UUID, arg3 string) (db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePostTx indicates an expected call of UpdatePostTx.
+func (mr *MockHubMockRecorder) UpdatePostTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostTx", reflect.TypeOf((*MockHub)(nil).UpdatePostTx), arg0, arg1, arg2, arg3)
+}
+
 // UpdateProfile mocks base method.
 func (m *MockHub) UpdateProfile(arg0 context.Context, arg1 db.UpdateProfileParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1248,3 +3330,63 @@ func (mr *MockHubMockRecorder) UpdateWall(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWall", reflect.TypeOf((*MockHub)(nil).UpdateWall), arg0, arg1)
 }
+
+// UpdateWallLayoutTx mocks base method.
+func (m *MockHub) UpdateWallLayoutTx(arg0 context.Context, arg1 pgtype.UUID, arg2 []db.UpdatePostLayoutParams) ([]db.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWallLayoutTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWallLayoutTx indicates an expected call of UpdateWallLayoutTx.
+func (mr *MockHubMockRecorder) UpdateWallLayoutTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWallLayoutTx", reflect.TypeOf((*MockHub)(nil).UpdateWallLayoutTx), arg0, arg1, arg2)
+}
+
+// UpdateWallTx mocks base method.
+func (m *MockHub) UpdateWallTx(arg0 context.Context, arg1 db.UpdateWallTxParams) (db.UpdateWallTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWallTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateWallTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWallTx indicates an expected call of UpdateWallTx.
+func (mr *MockHubMockRecorder) UpdateWallTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWallTx", reflect.TypeOf((*MockHub)(nil).UpdateWallTx), arg0, arg1)
+}
+
+// UpsertLinkPreview mocks base method.
+func (m *MockHub) UpsertLinkPreview(arg0 context.Context, arg1 db.UpsertLinkPreviewParams) (db.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLinkPreview", arg0, arg1)
+	ret0, _ := ret[0].(db.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertLinkPreview indicates an expected call of UpsertLinkPreview.
+func (mr *MockHubMockRecorder) UpsertLinkPreview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLinkPreview", reflect.TypeOf((*MockHub)(nil).UpsertLinkPreview), arg0, arg1)
+}
+
+// UpsertTag mocks base method.
+func (m *MockHub) UpsertTag(arg0 context.Context, arg1 string) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTag indicates an expected call of UpsertTag.
+func (mr *MockHubMockRecorder) UpsertTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTag", reflect.TypeOf((*MockHub)(nil).UpsertTag), arg0, arg1)
+}
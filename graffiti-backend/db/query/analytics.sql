@@ -0,0 +1,35 @@
+-- name: RecordWallViews :exec
+INSERT INTO wall_views (wall_id, viewer_id, view_date)
+SELECT v.wall_id, v.viewer_id, v.view_date
+FROM unnest(@wall_ids::uuid[], @viewer_ids::uuid[], @view_dates::date[]) AS v(wall_id, viewer_id, view_date)
+JOIN walls w ON w.id = v.wall_id
+JOIN users u ON u.id = v.viewer_id
+WHERE w.user_id <> v.viewer_id
+ON CONFLICT DO NOTHING;
+
+-- name: ListWallViewsByDay :many
+SELECT view_date, COUNT(*)::bigint AS views FROM wall_views
+WHERE wall_id = $1 AND view_date >= $2
+GROUP BY view_date
+ORDER BY view_date;
+
+-- name: CountUniqueWallVisitors :one
+SELECT COUNT(DISTINCT viewer_id)::bigint FROM wall_views
+WHERE wall_id = $1 AND view_date >= $2;
+
+-- name: ListTopWallContributors :many
+-- Anonymous posts aren't credited to anyone, or they'd give their authors away
+SELECT u.id, u.username, u.fullname, u.profile_picture,
+COUNT(p.id)::bigint AS post_count,
+COALESCE(SUM(p.likes_count), 0)::bigint AS likes_received
+FROM posts p
+JOIN users u ON p.author = u.id
+WHERE p.wall_id = $1 AND p.is_deleted = false AND p.status = 'approved' AND p.is_hidden = false AND p.is_anonymous = false
+GROUP BY u.id, u.username, u.fullname, u.profile_picture
+ORDER BY post_count DESC, likes_received DESC
+LIMIT $2;
+
+-- name: ListPostLikesByWall :many
+SELECT id, post_type, media_url, caption, likes_count, created_at FROM posts
+WHERE wall_id = $1 AND is_deleted = false AND status = 'approved' AND is_hidden = false
+ORDER BY likes_count DESC, created_at DESC;
@@ -0,0 +1,41 @@
+-- name: SetUserAutoArchiveDays :one
+UPDATE users
+SET auto_archive_days = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListAutoArchiveCandidates :many
+SELECT w.*, u.auto_archive_days, a.last_activity_at
+FROM walls w
+JOIN users u ON u.id = w.user_id
+CROSS JOIN LATERAL (
+    SELECT GREATEST(w.created_at, w.unarchived_at, (
+        SELECT MAX(p.created_at) FROM posts p
+        WHERE p.wall_id = w.id AND p.is_deleted = false
+    ))::timestamp AS last_activity_at
+) a
+WHERE u.auto_archive_days IS NOT NULL
+AND w.is_archived = false
+AND w.is_deleted = false
+AND a.last_activity_at < now() - make_interval(days => u.auto_archive_days - @warning_days::int)
+ORDER BY a.last_activity_at;
+
+-- name: ListInactiveWallsByUser :many
+SELECT w.*, a.last_activity_at
+FROM walls w
+CROSS JOIN LATERAL (
+    SELECT GREATEST(w.created_at, w.unarchived_at, (
+        SELECT MAX(p.created_at) FROM posts p
+        WHERE p.wall_id = w.id AND p.is_deleted = false
+    ))::timestamp AS last_activity_at
+) a
+WHERE w.user_id = $1
+AND w.is_archived = false
+AND w.is_deleted = false
+AND a.last_activity_at < @inactive_since::timestamp
+ORDER BY a.last_activity_at;
+
+-- name: MarkWallArchiveWarned :exec
+UPDATE walls
+SET archive_warned_at = now()
+WHERE id = $1;
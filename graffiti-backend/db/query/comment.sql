@@ -0,0 +1,64 @@
+-- name: CreateComment :one
+INSERT INTO comments (
+    post_id,
+    author,
+    parent_id,
+    body
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetComment :one
+SELECT * FROM comments
+WHERE id = $1 LIMIT 1;
+
+-- name: ListCommentsByPost :many
+-- Top-level comments, oldest first. A deleted comment is only listed while it
+-- still has replies, and comments between the viewer and users they blocked or
+-- were blocked by are left out.
+SELECT c.*, u.username, u.fullname, u.profile_picture,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.is_deleted = false)::bigint AS reply_count
+FROM comments c
+JOIN users u ON c.author = u.id
+WHERE c.post_id = sqlc.arg(post_id) AND c.parent_id IS NULL
+    AND (c.is_deleted = false OR EXISTS (
+        SELECT 1 FROM comments r WHERE r.parent_id = c.id AND r.is_deleted = false
+    ))
+    AND NOT EXISTS (
+        SELECT 1 FROM friendships f
+        WHERE f.status = 'blocked'
+            AND ((f.from_user = sqlc.arg(viewer_id) AND f.to_user = c.author)
+                OR (f.from_user = c.author AND f.to_user = sqlc.arg(viewer_id)))
+    )
+ORDER BY c.created_at, c.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListCommentReplies :many
+SELECT c.*, u.username, u.fullname, u.profile_picture
+FROM comments c
+JOIN users u ON c.author = u.id
+WHERE c.parent_id = sqlc.arg(parent_id) AND c.is_deleted = false
+    AND NOT EXISTS (
+        SELECT 1 FROM friendships f
+        WHERE f.status = 'blocked'
+            AND ((f.from_user = sqlc.arg(viewer_id) AND f.to_user = c.author)
+                OR (f.from_user = c.author AND f.to_user = sqlc.arg(viewer_id)))
+    )
+ORDER BY c.created_at, c.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: UpdateCommentBody :one
+UPDATE comments
+SET body = $2, edited_at = now()
+WHERE id = $1 AND is_deleted = false
+RETURNING *;
+
+-- name: SoftDeleteComment :execrows
+UPDATE comments
+SET is_deleted = true, deleted_at = now()
+WHERE id = $1 AND is_deleted = false;
+
+-- name: AddPostCommentsCount :exec
+UPDATE posts
+SET comments_count = GREATEST(comments_count + $2, 0)
+WHERE id = $1;
@@ -0,0 +1,57 @@
+-- name: CreateWallExport :one
+INSERT INTO wall_exports (
+  wall_id,
+  user_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetWallExport :one
+SELECT * FROM wall_exports
+WHERE id = $1 LIMIT 1;
+
+-- name: MarkWallExportProcessing :exec
+UPDATE wall_exports
+SET status = 'processing'
+WHERE id = $1;
+
+-- name: CompleteWallExport :one
+UPDATE wall_exports
+SET
+    status = 'completed',
+    archive_url = $2,
+    image_url = $3,
+    completed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: FailWallExport :exec
+UPDATE wall_exports
+SET
+    status = 'failed',
+    error = $2,
+    completed_at = now()
+WHERE id = $1;
+
+-- name: ExpireWallExports :exec
+-- Gives up on the user's exports still being built from before the cutoff,
+-- which are taken to have died with the server
+UPDATE wall_exports
+SET
+    status = 'failed',
+    error = 'export timed out',
+    completed_at = now()
+WHERE user_id = $1 AND status IN ('pending', 'processing') AND created_at <= $2;
+
+-- name: ListPrunableWallExports :many
+-- Exports made before the cutoff, along with any export of a wall that's about
+-- to be purged, since purging it would drop the rows but leave the files
+SELECT e.* FROM wall_exports e
+JOIN walls w ON w.id = e.wall_id
+WHERE e.created_at < $1 OR (w.is_deleted = true AND w.deleted_at < $2)
+ORDER BY e.created_at
+LIMIT $3;
+
+-- name: DeleteWallExports :execrows
+DELETE FROM wall_exports
+WHERE id = ANY(@export_ids::uuid[]);
@@ -1,9 +1,10 @@
 -- name: CreateLike :one
 INSERT INTO likes(
  post_id,
- user_id
+ user_id,
+ reaction
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 ) RETURNING *;
 
 -- name: GetLike :one
@@ -32,4 +33,19 @@ WHERE post_id = $1;
 DELETE FROM likes
 WHERE post_id = $1 AND user_id = $2;
 
-
+-- name: SetLikeReaction :one
+UPDATE likes
+SET reaction = $3, liked_at = now()
+WHERE post_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: AddPostReactionCount :one
+-- Moves one emoji's count on a post by delta, dropping it once it reaches zero
+UPDATE posts
+SET reaction_counts = CASE
+    WHEN COALESCE((reaction_counts ->> sqlc.arg(reaction)::text)::int, 0) + sqlc.arg(delta)::int > 0
+        THEN jsonb_set(reaction_counts, ARRAY[sqlc.arg(reaction)::text], to_jsonb(COALESCE((reaction_counts ->> sqlc.arg(reaction)::text)::int, 0) + sqlc.arg(delta)::int))
+    ELSE reaction_counts - sqlc.arg(reaction)::text
+END
+WHERE id = sqlc.arg(id)
+RETURNING *;
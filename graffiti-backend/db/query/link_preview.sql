@@ -0,0 +1,27 @@
+-- name: GetLinkPreview :one
+SELECT * FROM link_previews
+WHERE url = $1 LIMIT 1;
+
+-- name: ListLinkPreviewsByURL :many
+SELECT * FROM link_previews
+WHERE url = ANY(sqlc.arg(urls)::text[]);
+
+-- name: UpsertLinkPreview :one
+INSERT INTO link_previews (
+    url,
+    title,
+    description,
+    thumbnail_url,
+    provider,
+    embed_html
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (url) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    thumbnail_url = EXCLUDED.thumbnail_url,
+    provider = EXCLUDED.provider,
+    embed_html = EXCLUDED.embed_html,
+    fetched_at = now()
+RETURNING *;
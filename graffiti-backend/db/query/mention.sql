@@ -0,0 +1,47 @@
+-- name: CreateMention :one
+INSERT INTO mentions (
+    user_id,
+    mentioned_by,
+    post_id,
+    comment_id,
+    start_offset,
+    end_offset
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: DeletePostMentions :many
+DELETE FROM mentions
+WHERE post_id = $1
+RETURNING user_id;
+
+-- name: DeleteCommentMentions :many
+DELETE FROM mentions
+WHERE comment_id = $1
+RETURNING user_id;
+
+-- name: ListMentionsByPosts :many
+SELECT m.*, u.username
+FROM mentions m
+JOIN users u ON m.user_id = u.id
+WHERE m.post_id = ANY(sqlc.arg(post_ids)::uuid[])
+ORDER BY m.post_id, m.start_offset;
+
+-- name: ListMentionsByComments :many
+SELECT m.*, u.username
+FROM mentions m
+JOIN users u ON m.user_id = u.id
+WHERE m.comment_id = ANY(sqlc.arg(comment_ids)::uuid[])
+ORDER BY m.comment_id, m.start_offset;
+
+-- name: ListMentionableUsers :many
+-- Users with the given usernames, leaving out those who blocked the author
+SELECT u.id, u.username
+FROM users u
+WHERE u.username = ANY(sqlc.arg(usernames)::text[])
+    AND NOT EXISTS (
+        SELECT 1 FROM friendships f
+        WHERE f.from_user = u.id
+            AND f.to_user = sqlc.arg(author_id)
+            AND f.status = 'blocked'
+    );
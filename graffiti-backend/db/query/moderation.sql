@@ -0,0 +1,41 @@
+-- name: SetWallModeration :one
+UPDATE walls
+SET moderation_enabled = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: AddWallModerator :one
+INSERT INTO wall_moderators (
+  wall_id,
+  user_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: RemoveWallModerator :exec
+DELETE FROM wall_moderators
+WHERE wall_id = $1 AND user_id = $2;
+
+-- name: IsWallModerator :one
+SELECT EXISTS (
+  SELECT 1 FROM wall_moderators
+  WHERE wall_id = $1 AND user_id = $2
+);
+
+-- name: ListWallModerators :many
+SELECT m.wall_id, m.user_id, m.created_at, u.username, u.fullname, u.profile_picture FROM wall_moderators m
+JOIN users u ON m.user_id = u.id
+WHERE m.wall_id = $1
+ORDER BY m.created_at;
+
+-- name: ListPendingPostsByWall :many
+SELECT p.*, u.username, u.profile_picture, u.fullname FROM posts p
+JOIN users u ON p.author = u.id
+WHERE p.wall_id = $1 AND p.status = 'pending' AND p.is_deleted = false
+ORDER BY p.created_at;
+
+-- name: ModeratePost :one
+UPDATE posts
+SET status = $2
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
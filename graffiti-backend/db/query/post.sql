@@ -3,9 +3,19 @@ INSERT INTO posts(
  wall_id,
  author,
  media_url,
- post_type
+ post_type,
+ pos_x,
+ pos_y,
+ rotation,
+ scale,
+ z_index,
+ status,
+ section_id,
+ caption,
+ publish_at,
+ is_anonymous
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 ) RETURNING *;
 
 -- name: GetPost :one
@@ -14,37 +24,60 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListPosts :many
 SELECT * FROM posts
+WHERE status = 'approved' AND is_hidden = false
 ORDER BY id;
 
 -- name: ListPostsByWall :many
 SELECT * FROM posts
-WHERE wall_id = $1
-ORDER BY id DESC;
+WHERE wall_id = $1 AND is_hidden = false
+ORDER BY z_index, created_at;
 
 -- name: ListPostsByWallWithAuthorsDetails :many
 SELECT p.*, u.username, u.profile_picture, u.fullname FROM posts p
 JOIN users u ON p.author = u.id
 WHERE p.wall_id = $1 and p.is_deleted = false
-ORDER BY p.created_at DESC;
+AND ((p.status = 'approved' AND p.is_hidden = false) OR p.author = $2)
+AND (sqlc.narg(section_id)::uuid IS NULL OR p.section_id = sqlc.narg(section_id))
+ORDER BY p.z_index, p.created_at;
 
 -- name: GetHighlightedPosts :many
 SELECT * FROM posts
-WHERE is_highlighted = true
+WHERE is_highlighted = true AND status = 'approved' AND is_hidden = false
 ORDER BY id;
 
 -- name: GetHighlightedPostsByWall :many
 SELECT * FROM posts
-WHERE wall_id = $1 AND is_highlighted = true
+WHERE wall_id = $1 AND is_highlighted = true AND status = 'approved' AND is_hidden = false
 ORDER BY id;
 
 -- name: UpdatePost :one
 UPDATE posts
   set
     media_url = COALESCE($2, media_url),
-    post_type = COALESCE($3, post_type)
+    post_type = COALESCE($3, post_type),
+    caption = COALESCE($4, caption),
+    edited_at = CASE
+      WHEN COALESCE($2, media_url) IS DISTINCT FROM media_url
+        OR COALESCE($3, post_type) IS DISTINCT FROM post_type
+        OR COALESCE($4, caption) IS DISTINCT FROM caption
+      THEN now()
+      ELSE edited_at
+    END
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdatePostLayout :one
+UPDATE posts
+  set
+    pos_x = $2,
+    pos_y = $3,
+    rotation = $4,
+    scale = $5,
+    z_index = $6,
+    layout_version = layout_version + 1
+WHERE id = $1 AND layout_version = $7
+RETURNING *;
+
 -- name: HighlightPost :one
 UPDATE posts
   set is_highlighted = true
@@ -72,5 +105,43 @@ RETURNING *;
 
 -- name: DeletePost :exec
 UPDATE posts
-  set is_deleted = true
+  set is_deleted = true,
+  deleted_at = COALESCE(deleted_at, now())
 WHERE id = $1;
+
+-- name: RestorePost :one
+UPDATE posts
+  set is_deleted = false,
+  deleted_at = NULL
+WHERE id = $1 AND is_deleted = true
+RETURNING *;
+
+-- name: ListDeletedPostsByUser :many
+SELECT p.*, w.title AS wall_title FROM posts p
+JOIN walls w ON p.wall_id = w.id
+WHERE (p.author = $1 OR w.user_id = $1)
+AND p.is_deleted = true
+AND p.deleted_at >= $2
+AND w.is_deleted = false
+ORDER BY p.deleted_at DESC;
+
+-- name: DeletePostsByWall :exec
+UPDATE posts
+  set is_deleted = true,
+  deleted_at = $2
+WHERE wall_id = $1 AND is_deleted = false;
+
+-- name: RestorePostsByWall :exec
+UPDATE posts
+  set is_deleted = false,
+  deleted_at = NULL
+WHERE wall_id = $1 AND is_deleted = true AND deleted_at = $2;
+
+-- name: ListClonablePostsByWall :many
+SELECT * FROM posts
+WHERE wall_id = $1
+AND author = $2
+AND is_deleted = false
+AND status = 'approved'
+AND is_hidden = false
+ORDER BY z_index, created_at;
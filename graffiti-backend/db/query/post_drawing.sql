@@ -0,0 +1,13 @@
+-- name: CreatePostDrawing :one
+INSERT INTO post_drawings (
+    post_id,
+    width,
+    height,
+    strokes
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetPostDrawing :one
+SELECT * FROM post_drawings
+WHERE post_id = $1;
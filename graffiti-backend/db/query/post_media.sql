@@ -0,0 +1,16 @@
+-- name: CreatePostMedia :one
+INSERT INTO post_media (
+    post_id,
+    position,
+    media_url,
+    width,
+    height,
+    alt_text
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListPostMediaByPosts :many
+SELECT * FROM post_media
+WHERE post_id = ANY(@post_ids::uuid[])
+ORDER BY post_id, position;
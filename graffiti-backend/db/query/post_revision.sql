@@ -0,0 +1,46 @@
+-- name: GetPostForUpdate :one
+SELECT * FROM posts
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: CreatePostRevision :one
+INSERT INTO post_revisions (
+    post_id,
+    editor_id,
+    media_url,
+    post_type,
+    caption
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListPostRevisions :many
+SELECT r.*, u.username AS editor_username, u.fullname AS editor_fullname, u.profile_picture AS editor_profile_picture
+FROM post_revisions r
+LEFT JOIN users u ON u.id = r.editor_id
+WHERE r.post_id = $1
+ORDER BY r.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListPrunablePostRevisions :many
+SELECT * FROM post_revisions
+WHERE created_at < $1
+ORDER BY created_at
+LIMIT $2;
+
+-- name: ListPostRevisionMediaByPosts :many
+SELECT DISTINCT media_url::text FROM post_revisions
+WHERE post_id = ANY(@post_ids::uuid[]) AND post_type = 'media' AND media_url IS NOT NULL;
+
+-- name: ListMediaURLsInUse :many
+-- Media URLs out of the given ones that a post, or a revision that isn't
+-- being pruned, still points to
+SELECT media_url::text FROM posts
+WHERE media_url = ANY(@media_urls::text[])
+UNION
+SELECT media_url::text FROM post_revisions
+WHERE media_url = ANY(@media_urls::text[]) AND NOT (id = ANY(@revision_ids::uuid[]));
+
+-- name: DeletePostRevisions :execrows
+DELETE FROM post_revisions
+WHERE id = ANY(@revision_ids::uuid[]);
@@ -0,0 +1,27 @@
+-- name: ListPurgeableWalls :many
+SELECT * FROM walls
+WHERE is_deleted = true AND deleted_at < $1
+ORDER BY deleted_at
+LIMIT $2;
+
+-- name: ListPurgeablePosts :many
+SELECT * FROM posts
+WHERE (is_deleted = true AND deleted_at < $1)
+OR wall_id IN (
+  SELECT id FROM walls
+  WHERE is_deleted = true AND deleted_at < $1
+)
+ORDER BY created_at
+LIMIT $2;
+
+-- name: DeleteLikesByPosts :execrows
+DELETE FROM likes
+WHERE post_id = ANY(@post_ids::uuid[]);
+
+-- name: HardDeletePosts :execrows
+DELETE FROM posts
+WHERE id = ANY(@post_ids::uuid[]);
+
+-- name: HardDeleteWalls :execrows
+DELETE FROM walls
+WHERE id = ANY(@wall_ids::uuid[]);
@@ -0,0 +1,68 @@
+-- name: CreateReport :one
+INSERT INTO reports (
+    reporter_id,
+    target_type,
+    target_id,
+    reason,
+    detail
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetReport :one
+SELECT * FROM reports
+WHERE id = $1 LIMIT 1;
+
+-- name: CountOpenReportsForTarget :one
+SELECT COUNT(*) FROM reports
+WHERE target_type = $1 AND target_id = $2 AND status = 'open';
+
+-- name: ListReports :many
+SELECT r.*, u.username AS reporter_username, (
+    SELECT COUNT(*) FROM reports o
+    WHERE o.target_type = r.target_type AND o.target_id = r.target_id AND o.status = 'open'
+)::bigint AS open_reports
+FROM reports r
+JOIN users u ON u.id = r.reporter_id
+WHERE (sqlc.narg(status)::report_status IS NULL OR r.status = sqlc.narg(status))
+AND (sqlc.narg(target_type)::report_target_type IS NULL OR r.target_type = sqlc.narg(target_type))
+ORDER BY r.created_at
+LIMIT $1 OFFSET $2;
+
+-- name: ResolveReportsByTarget :many
+UPDATE reports
+SET
+    status = $3,
+    resolved_by = $4,
+    resolution_note = $5,
+    resolved_at = now()
+WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+RETURNING *;
+
+-- name: HidePost :execrows
+UPDATE posts
+SET is_hidden = true
+WHERE id = $1 AND is_hidden = false;
+
+-- name: HideWall :execrows
+UPDATE walls
+SET is_hidden = true
+WHERE id = $1 AND is_hidden = false;
+
+-- name: SetPostHidden :one
+UPDATE posts
+SET is_hidden = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetWallHidden :one
+UPDATE walls
+SET is_hidden = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetUserSuspension :one
+UPDATE users
+SET suspended_until = $2
+WHERE id = $1
+RETURNING *;
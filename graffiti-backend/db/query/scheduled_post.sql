@@ -0,0 +1,28 @@
+-- name: ListDueScheduledPosts :many
+-- Posts on a deleted wall wait until it's restored
+SELECT p.* FROM posts p
+JOIN walls w ON p.wall_id = w.id
+WHERE p.status = 'scheduled' AND p.is_deleted = false AND p.publish_at <= $1
+AND w.is_deleted = false
+ORDER BY p.publish_at
+LIMIT $2;
+
+-- name: PublishScheduledPost :one
+-- Publishing counts as posting, so the post is dated from now and edits
+-- made while it was scheduled don't mark it as edited
+UPDATE posts
+SET status = $2, created_at = now(), edited_at = NULL
+WHERE id = $1 AND status = 'scheduled'
+RETURNING *;
+
+-- name: ListScheduledPostsByAuthor :many
+SELECT * FROM posts
+WHERE author = $1 AND status = 'scheduled' AND is_deleted = false
+ORDER BY publish_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: ReschedulePost :one
+UPDATE posts
+SET publish_at = $2
+WHERE id = $1 AND status = 'scheduled'
+RETURNING *;
@@ -0,0 +1,57 @@
+-- name: CreateWallSection :one
+INSERT INTO wall_sections (
+    wall_id,
+    name,
+    position,
+    is_default
+) VALUES (
+    $1, $2, (SELECT COALESCE(MAX(s.position) + 1, 0) FROM wall_sections s WHERE s.wall_id = $1), $3
+) RETURNING *;
+
+-- name: GetWallSection :one
+SELECT * FROM wall_sections
+WHERE id = $1 AND wall_id = $2 LIMIT 1;
+
+-- name: GetDefaultWallSection :one
+SELECT * FROM wall_sections
+WHERE wall_id = $1 AND is_default = true LIMIT 1;
+
+-- name: ListWallSections :many
+SELECT s.*, COUNT(p.id) AS post_count
+FROM wall_sections s
+LEFT JOIN posts p ON p.section_id = s.id AND p.is_deleted = false AND p.status = 'approved'
+WHERE s.wall_id = $1
+GROUP BY s.id
+ORDER BY s.position, s.created_at;
+
+-- name: RenameWallSection :one
+UPDATE wall_sections
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetWallSectionPosition :one
+UPDATE wall_sections
+SET position = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWallSection :exec
+DELETE FROM wall_sections
+WHERE id = $1;
+
+-- name: MoveSectionPosts :execrows
+UPDATE posts
+SET section_id = @to_section_id
+WHERE section_id = @from_section_id;
+
+-- name: AssignUnsectionedPosts :exec
+UPDATE posts
+SET section_id = $2
+WHERE wall_id = $1 AND section_id IS NULL;
+
+-- name: SetPostSection :one
+UPDATE posts
+SET section_id = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,37 @@
+-- name: CreateWallSubscription :one
+INSERT INTO wall_subscriptions (wall_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: GetWallSubscription :one
+SELECT * FROM wall_subscriptions
+WHERE wall_id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: DeleteWallSubscription :execrows
+DELETE FROM wall_subscriptions
+WHERE wall_id = $1 AND user_id = $2;
+
+-- name: SetWallSubscriptionMuted :one
+UPDATE wall_subscriptions
+SET muted = $3
+WHERE wall_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: AddWallFollowerCount :exec
+UPDATE walls
+SET follower_count = GREATEST(follower_count + $2, 0)
+WHERE id = $1;
+
+-- name: ListFollowedWalls :many
+SELECT w.*, s.muted FROM walls w
+JOIN wall_subscriptions s ON s.wall_id = w.id
+WHERE s.user_id = $1
+AND w.is_deleted = false
+AND w.is_public = true
+AND w.is_hidden = false
+ORDER BY s.created_at DESC;
+
+-- name: ListWallFollowersToNotify :many
+SELECT user_id FROM wall_subscriptions
+WHERE wall_id = $1 AND muted = false AND user_id <> $2;
@@ -0,0 +1,48 @@
+-- name: UpsertTag :one
+INSERT INTO tags (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: AddWallTag :exec
+INSERT INTO wall_tags (wall_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteWallTags :many
+DELETE FROM wall_tags
+WHERE wall_id = $1
+RETURNING tag_id;
+
+-- name: ListTagsByWall :many
+SELECT t.* FROM tags t
+JOIN wall_tags wt ON wt.tag_id = t.id
+WHERE wt.wall_id = $1
+ORDER BY t.name;
+
+-- name: RefreshTagUsageCounts :exec
+UPDATE tags t
+SET usage_count = (
+    SELECT count(*) FROM wall_tags wt
+    WHERE wt.tag_id = t.id
+)
+WHERE t.id = ANY(@tag_ids::uuid[]);
+
+-- name: ListPublicWallsByTag :many
+SELECT w.* FROM walls w
+JOIN wall_tags wt ON wt.wall_id = w.id
+JOIN tags t ON t.id = wt.tag_id
+WHERE t.name = $1
+AND w.is_public = true
+AND w.is_hidden = false
+AND w.is_deleted = false
+AND w.is_archived = false
+ORDER BY w.popularity_score DESC, w.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: SearchTags :many
+SELECT * FROM tags
+WHERE name LIKE sqlc.arg(prefix)::text || '%'
+AND usage_count > 0
+ORDER BY usage_count DESC, name ASC
+LIMIT sqlc.arg(max_results);
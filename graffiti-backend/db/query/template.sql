@@ -0,0 +1,7 @@
+-- name: ListWallTemplates :many
+SELECT * FROM wall_templates
+ORDER BY sort_order, title;
+
+-- name: GetWallTemplate :one
+SELECT * FROM wall_templates
+WHERE id = $1 LIMIT 1;
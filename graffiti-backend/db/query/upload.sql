@@ -0,0 +1,20 @@
+-- name: CreateUpload :one
+INSERT INTO uploads (
+    key,
+    user_id,
+    content_type,
+    file_size
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetUpload :one
+SELECT * FROM uploads
+WHERE key = $1 LIMIT 1;
+
+-- name: ClaimUpload :one
+-- Claims an upload for a post. Returns no rows when it was already used.
+UPDATE uploads
+SET post_id = $2, claimed_at = now()
+WHERE key = $1 AND claimed_at IS NULL
+RETURNING *;
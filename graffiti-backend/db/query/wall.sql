@@ -32,7 +32,7 @@ SELECT * FROM walls
 WHERE user_id = $1
 AND is_deleted = false
 AND is_archived = false
-ORDER BY created_at DESC;
+ORDER BY pin_order ASC NULLS LAST, created_at DESC;
 
 -- name: UpdateWall :one
 UPDATE walls
@@ -45,19 +45,42 @@ WHERE id = $1
 RETURNING *;
 
 -- name: DeleteWall :exec
+-- Like archiving, deleting a wall unpins it
 UPDATE walls
-    set is_deleted = true
+    set is_deleted = true,
+    deleted_at = COALESCE(deleted_at, now()),
+    is_pinned = false,
+    pin_order = NULL
 WHERE id = $1;
 
+-- name: RestoreWall :one
+UPDATE walls
+    set is_deleted = false,
+    deleted_at = NULL
+WHERE id = $1 AND is_deleted = true
+RETURNING *;
+
+-- name: ListDeletedWallsByUser :many
+SELECT * FROM walls
+WHERE user_id = $1
+AND is_deleted = true
+AND deleted_at >= $2
+ORDER BY deleted_at DESC;
+
+
 -- name: ArchiveWall :exec
+-- Archived walls lose their pin, so unarchiving can't take a user past the limit
 UPDATE walls
-    set is_archived = true
+    set is_archived = true,
+    is_pinned = false,
+    pin_order = NULL
 WHERE id = $1
 RETURNING *;
 
 -- name: UnarchiveWall :exec
 UPDATE walls
-    set is_archived = false
+    set is_archived = false,
+    unarchived_at = now()
 WHERE id = $1
 RETURNING *;
 
@@ -83,6 +106,37 @@ RETURNING *;
 
 -- name: PinUnpinWall :one
 UPDATE walls
-    set is_pinned = not is_pinned
+    set is_pinned = not is_pinned,
+    pin_order = CASE WHEN is_pinned THEN NULL ELSE (
+        SELECT COALESCE(MAX(w.pin_order), 0) + 1 FROM walls w
+        WHERE w.user_id = walls.user_id
+    ) END
+WHERE id = $1
+RETURNING *;
+
+-- name: CountPinnedWalls :one
+SELECT count(*) FROM walls
+WHERE user_id = $1
+AND is_pinned = true
+AND is_deleted = false
+AND is_archived = false;
+
+-- name: ClearWallPins :exec
+UPDATE walls
+    set is_pinned = false,
+    pin_order = NULL
+WHERE user_id = $1
+AND (is_pinned = true OR pin_order IS NOT NULL);
+
+-- name: SetWallPinOrder :one
+UPDATE walls
+    set is_pinned = true,
+    pin_order = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetWallAllowAnonymous :one
+UPDATE walls
+SET allow_anonymous = $2
 WHERE id = $1
 RETURNING *;
@@ -0,0 +1,40 @@
+-- name: GetWallForUpdate :one
+SELECT * FROM walls
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: CreateWallRevision :one
+INSERT INTO wall_revisions (
+    wall_id,
+    actor_id,
+    title,
+    description,
+    background_image,
+    is_public,
+    changed_fields
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetWallRevision :one
+SELECT * FROM wall_revisions
+WHERE id = $1 AND wall_id = $2 LIMIT 1;
+
+-- name: ListWallRevisions :many
+SELECT r.*, u.username AS actor_username, u.fullname AS actor_fullname, u.profile_picture AS actor_profile_picture
+FROM wall_revisions r
+LEFT JOIN users u ON u.id = r.actor_id
+WHERE r.wall_id = $1
+ORDER BY r.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: RevertWallToRevision :one
+UPDATE walls w
+SET
+    title = r.title,
+    description = r.description,
+    background_image = r.background_image,
+    is_public = r.is_public
+FROM wall_revisions r
+WHERE r.id = @revision_id AND r.wall_id = w.id AND w.id = @wall_id
+RETURNING w.*;
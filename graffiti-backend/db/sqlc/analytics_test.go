@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func TestRecordWallViews(t *testing.T) {
+	wall := createRandomWall(t)
+	viewer := createRandomUser(t)
+
+	// Views can still be buffered for a user deleted before the flush
+	deleted := createRandomUser(t)
+	require.NoError(t, testHub.DeleteUser(context.Background(), deleted.ID))
+
+	now := time.Now().UTC()
+	today := pgtype.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+
+	arg := RecordWallViewsParams{
+		// The owner's own view, the duplicate and the deleted user's view are all dropped
+		WallIds:   []pgtype.UUID{wall.ID, wall.ID, wall.ID, wall.ID},
+		ViewerIds: []pgtype.UUID{viewer.ID, viewer.ID, wall.UserID, deleted.ID},
+		ViewDates: []pgtype.Date{today, today, today, today},
+	}
+	err := testHub.RecordWallViews(context.Background(), arg)
+	require.NoError(t, err)
+
+	// Flushing the same views again is a no-op
+	err = testHub.RecordWallViews(context.Background(), arg)
+	require.NoError(t, err)
+
+	views, err := testHub.ListWallViewsByDay(context.Background(), ListWallViewsByDayParams{
+		WallID:   wall.ID,
+		ViewDate: today,
+	})
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	require.Equal(t, int64(1), views[0].Views)
+
+	unique, err := testHub.CountUniqueWallVisitors(context.Background(), CountUniqueWallVisitorsParams{
+		WallID:   wall.ID,
+		ViewDate: today,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), unique)
+}
+
+func TestListTopWallContributors(t *testing.T) {
+	post := createRandomPost(t)
+
+	contributors, err := testHub.ListTopWallContributors(context.Background(), ListTopWallContributorsParams{
+		WallID: post.WallID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, contributors, 1)
+	require.Equal(t, post.Author, contributors[0].ID)
+	require.Equal(t, int64(1), contributors[0].PostCount)
+
+	posts, err := testHub.ListPostLikesByWall(context.Background(), post.WallID)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, post.ID, posts[0].ID)
+}
+
+func TestListTopWallContributorsSkipsAnonymous(t *testing.T) {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+
+	post, err := testHub.CreatePost(context.Background(), CreatePostParams{
+		WallID:      wall.ID,
+		Author:      user.ID,
+		PostType:    NullPostType{PostType: PostTypeText, Valid: true},
+		Scale:       1,
+		Status:      PostStatusApproved,
+		Caption:     pgtype.Text{String: util.RandomString(20), Valid: true},
+		IsAnonymous: true,
+	})
+	require.NoError(t, err)
+	require.True(t, post.IsAnonymous)
+
+	contributors, err := testHub.ListTopWallContributors(context.Background(), ListTopWallContributorsParams{
+		WallID: wall.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, contributors)
+}
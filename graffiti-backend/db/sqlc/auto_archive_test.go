@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUserAutoArchiveDays(t *testing.T) {
+	user := createRandomUser(t)
+	require.False(t, user.AutoArchiveDays.Valid)
+
+	updated, err := testHub.SetUserAutoArchiveDays(context.Background(), SetUserAutoArchiveDaysParams{
+		ID:              user.ID,
+		AutoArchiveDays: pgtype.Int4{Int32: 30, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(30), updated.AutoArchiveDays.Int32)
+
+	updated, err = testHub.SetUserAutoArchiveDays(context.Background(), SetUserAutoArchiveDaysParams{ID: user.ID})
+	require.NoError(t, err)
+	require.False(t, updated.AutoArchiveDays.Valid)
+}
+
+func TestListAutoArchiveCandidates(t *testing.T) {
+	wall := createRandomWall(t)
+
+	_, err := testHub.SetUserAutoArchiveDays(context.Background(), SetUserAutoArchiveDaysParams{
+		ID:              wall.UserID,
+		AutoArchiveDays: pgtype.Int4{Int32: 7, Valid: true},
+	})
+	require.NoError(t, err)
+
+	findWall := func(warningDays int32) *ListAutoArchiveCandidatesRow {
+		rows, err := testHub.ListAutoArchiveCandidates(context.Background(), warningDays)
+		require.NoError(t, err)
+		for i := range rows {
+			if rows[i].ID == wall.ID {
+				return &rows[i]
+			}
+		}
+		return nil
+	}
+
+	// A new wall is nowhere near its inactivity limit
+	require.Nil(t, findWall(3))
+
+	// With the warning window as long as the limit, any inactivity counts
+	row := findWall(7)
+	require.NotNil(t, row)
+	require.Equal(t, int32(7), row.AutoArchiveDays.Int32)
+	require.False(t, row.ArchiveWarnedAt.Valid)
+	require.WithinDuration(t, wall.CreatedAt.Time, row.LastActivityAt.Time, time.Second)
+
+	err = testHub.MarkWallArchiveWarned(context.Background(), wall.ID)
+	require.NoError(t, err)
+
+	row = findWall(7)
+	require.NotNil(t, row)
+	require.True(t, row.ArchiveWarnedAt.Valid)
+
+	// Archived walls are no longer candidates, and unarchiving resets the activity
+	err = testHub.ArchiveWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Nil(t, findWall(7))
+
+	err = testHub.UnarchiveWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+
+	unarchived, err := testHub.GetWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.True(t, unarchived.UnarchivedAt.Valid)
+
+	row = findWall(7)
+	require.NotNil(t, row)
+	require.WithinDuration(t, unarchived.UnarchivedAt.Time, row.LastActivityAt.Time, time.Second)
+}
+
+func TestListInactiveWallsByUser(t *testing.T) {
+	wall := createRandomWall(t)
+
+	past := pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true}
+	walls, err := testHub.ListInactiveWallsByUser(context.Background(), ListInactiveWallsByUserParams{
+		UserID:        wall.UserID,
+		InactiveSince: past,
+	})
+	require.NoError(t, err)
+	require.Empty(t, walls)
+
+	future := pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true}
+	walls, err = testHub.ListInactiveWallsByUser(context.Background(), ListInactiveWallsByUserParams{
+		UserID:        wall.UserID,
+		InactiveSince: future,
+	})
+	require.NoError(t, err)
+	require.Len(t, walls, 1)
+	require.Equal(t, wall.ID, walls[0].ID)
+}
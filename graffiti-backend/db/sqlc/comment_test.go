@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func createRandomComment(t *testing.T, post Post, author User, parentID pgtype.UUID) Comment {
+	arg := CreateCommentParams{
+		PostID:   post.ID,
+		Author:   author.ID,
+		ParentID: parentID,
+		Body:     util.RandomString(20),
+	}
+
+	comment, err := testHub.CreateCommentTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.PostID, comment.PostID)
+	require.Equal(t, arg.Author, comment.Author)
+	require.Equal(t, arg.ParentID, comment.ParentID)
+	require.Equal(t, arg.Body, comment.Body)
+	require.False(t, comment.IsDeleted)
+	require.False(t, comment.EditedAt.Valid)
+	return comment
+}
+
+func TestCreateCommentTx(t *testing.T) {
+	post := createRandomPost(t)
+	user := createRandomUser(t)
+
+	comment := createRandomComment(t, post, user, pgtype.UUID{})
+	createRandomComment(t, post, user, comment.ID)
+
+	updated, err := testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), updated.CommentsCount)
+}
+
+func TestUpdateCommentBody(t *testing.T) {
+	comment := createRandomComment(t, createRandomPost(t), createRandomUser(t), pgtype.UUID{})
+
+	updated, err := testHub.UpdateCommentBody(context.Background(), UpdateCommentBodyParams{
+		ID:   comment.ID,
+		Body: "Edited",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Edited", updated.Body)
+	require.True(t, updated.EditedAt.Valid)
+}
+
+func TestDeleteCommentTx(t *testing.T) {
+	post := createRandomPost(t)
+	user := createRandomUser(t)
+
+	parent := createRandomComment(t, post, user, pgtype.UUID{})
+	reply := createRandomComment(t, post, user, parent.ID)
+	lonely := createRandomComment(t, post, user, pgtype.UUID{})
+
+	require.NoError(t, testHub.DeleteCommentTx(context.Background(), parent.ID, post.ID))
+	require.NoError(t, testHub.DeleteCommentTx(context.Background(), lonely.ID, post.ID))
+	require.ErrorIs(t, testHub.DeleteCommentTx(context.Background(), lonely.ID, post.ID), ErrRecordNotFound)
+
+	updated, err := testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), updated.CommentsCount)
+
+	// The deleted parent stays listed for its reply, the deleted comment without replies doesn't
+	comments, err := testHub.ListCommentsByPost(context.Background(), ListCommentsByPostParams{
+		PostID:   post.ID,
+		ViewerID: user.ID,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, parent.ID, comments[0].ID)
+	require.True(t, comments[0].IsDeleted)
+	require.Equal(t, int64(1), comments[0].ReplyCount)
+
+	replies, err := testHub.ListCommentReplies(context.Background(), ListCommentRepliesParams{
+		ParentID: parent.ID,
+		ViewerID: user.ID,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+	require.Equal(t, reply.ID, replies[0].ID)
+}
+
+func TestListCommentsByPostHidesBlockedUsers(t *testing.T) {
+	post := createRandomPost(t)
+	viewer := createRandomUser(t)
+	blocked := createRandomUser(t)
+
+	createRandomComment(t, post, viewer, pgtype.UUID{})
+	createRandomComment(t, post, blocked, pgtype.UUID{})
+
+	_, err := testHub.CreateFriendship(context.Background(), CreateFriendshipParams{
+		FromUser: viewer.ID,
+		ToUser:   blocked.ID,
+		Status:   NullStatus{Status: StatusBlocked, Valid: true},
+	})
+	require.NoError(t, err)
+
+	comments, err := testHub.ListCommentsByPost(context.Background(), ListCommentsByPostParams{
+		PostID:   post.ID,
+		ViewerID: viewer.ID,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, viewer.ID, comments[0].Author)
+}
@@ -14,6 +14,27 @@ const (
 
 var ErrRecordNotFound = pgx.ErrNoRows
 
+// ErrLayoutConflict is returned when a post layout was changed by someone else
+var ErrLayoutConflict = errors.New("layout has been modified by another request")
+
+// ErrPostNotInWall is returned when a post doesn't belong to the wall being edited
+var ErrPostNotInWall = errors.New("post does not belong to this wall")
+
+// ErrPostNotPending is returned when moderating a post that was already reviewed
+var ErrPostNotPending = errors.New("post is not pending review")
+
+// ErrWallNotPinnable is returned when pinning a wall that is not the user's own active wall
+var ErrWallNotPinnable = errors.New("wall cannot be pinned")
+
+// ErrDefaultSection is returned when deleting the section other posts fall back to
+var ErrDefaultSection = errors.New("the default section cannot be deleted")
+
+// ErrSectionOrderMismatch is returned when a new section order doesn't list every section of the wall once
+var ErrSectionOrderMismatch = errors.New("section order must list every section of the wall exactly once")
+
+// ErrUploadClaimed is returned when a post uses an upload that another post already claimed
+var ErrUploadClaimed = errors.New("upload has already been used by another post")
+
 var ErrUniqueViolation = &pgconn.PgError{
 	Code: UniqueViolation,
 }
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWallExport(t *testing.T) WallExport {
+	wall := createRandomWall(t)
+
+	arg := CreateWallExportParams{
+		WallID: wall.ID,
+		UserID: wall.UserID,
+	}
+
+	wallExport, err := testHub.CreateWallExport(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, wallExport)
+
+	require.Equal(t, arg.WallID, wallExport.WallID)
+	require.Equal(t, arg.UserID, wallExport.UserID)
+	require.Equal(t, "pending", wallExport.Status)
+	require.False(t, wallExport.ArchiveUrl.Valid)
+	require.False(t, wallExport.CompletedAt.Valid)
+	require.NotZero(t, wallExport.CreatedAt)
+
+	return wallExport
+}
+
+func TestCreateWallExport(t *testing.T) {
+	createRandomWallExport(t)
+}
+
+func TestCompleteWallExport(t *testing.T) {
+	wallExport := createRandomWallExport(t)
+
+	err := testHub.MarkWallExportProcessing(context.Background(), wallExport.ID)
+	require.NoError(t, err)
+
+	arg := CompleteWallExportParams{
+		ID:         wallExport.ID,
+		ArchiveUrl: pgtype.Text{String: "https://example.com/exports/archive.zip", Valid: true},
+		ImageUrl:   pgtype.Text{String: "https://example.com/exports/collage.png", Valid: true},
+	}
+
+	completed, err := testHub.CompleteWallExport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "completed", completed.Status)
+	require.Equal(t, arg.ArchiveUrl.String, completed.ArchiveUrl.String)
+	require.Equal(t, arg.ImageUrl.String, completed.ImageUrl.String)
+	require.True(t, completed.CompletedAt.Valid)
+}
+
+func TestFailWallExport(t *testing.T) {
+	wallExport := createRandomWallExport(t)
+
+	err := testHub.FailWallExport(context.Background(), FailWallExportParams{
+		ID:    wallExport.ID,
+		Error: pgtype.Text{String: "boom", Valid: true},
+	})
+	require.NoError(t, err)
+
+	failed, err := testHub.GetWallExport(context.Background(), wallExport.ID)
+	require.NoError(t, err)
+	require.Equal(t, "failed", failed.Status)
+	require.Equal(t, "boom", failed.Error.String)
+}
+
+func TestPruneWallExports(t *testing.T) {
+	wallExport := createRandomWallExport(t)
+
+	prunable := func(createdAt, deletedAt time.Time) bool {
+		exports, err := testHub.ListPrunableWallExports(context.Background(), ListPrunableWallExportsParams{
+			CreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true},
+			DeletedAt: pgtype.Timestamp{Time: deletedAt, Valid: true},
+			Limit:     1000,
+		})
+		require.NoError(t, err)
+		for _, e := range exports {
+			if e.ID == wallExport.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	hourAgo := time.Now().Add(-time.Hour)
+	require.False(t, prunable(hourAgo, hourAgo))
+	require.True(t, prunable(time.Now().Add(time.Minute), hourAgo))
+
+	// A recent export goes too once its wall is due to be purged
+	err := testHub.DeleteWall(context.Background(), wallExport.WallID)
+	require.NoError(t, err)
+	require.True(t, prunable(hourAgo, time.Now().Add(time.Minute)))
+
+	n, err := testHub.DeleteWallExports(context.Background(), []pgtype.UUID{wallExport.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	_, err = testHub.GetWallExport(context.Background(), wallExport.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestOneActiveWallExportPerUser(t *testing.T) {
+	wallExport := createRandomWallExport(t)
+	arg := CreateWallExportParams{
+		WallID: wallExport.WallID,
+		UserID: wallExport.UserID,
+	}
+
+	_, err := testHub.CreateWallExport(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	// A recent export isn't given up on
+	err = testHub.ExpireWallExports(context.Background(), ExpireWallExportsParams{
+		UserID:    wallExport.UserID,
+		CreatedAt: pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = testHub.CreateWallExport(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	err = testHub.ExpireWallExports(context.Background(), ExpireWallExportsParams{
+		UserID:    wallExport.UserID,
+		CreatedAt: pgtype.Timestamp{Time: time.Now().Add(time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+
+	expired, err := testHub.GetWallExport(context.Background(), wallExport.ID)
+	require.NoError(t, err)
+	require.Equal(t, "failed", expired.Status)
+
+	_, err = testHub.CreateWallExport(context.Background(), arg)
+	require.NoError(t, err)
+}
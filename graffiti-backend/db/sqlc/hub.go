@@ -16,6 +16,8 @@ type Hub interface {
 	CreateFriendRequestTx(ctx context.Context, fromUser, toUser pgtype.UUID) (Friendship, error)
 	CreateLikeTx(ctx context.Context, postID, userID pgtype.UUID) error
 	CreateOrDeleteLikeTx(ctx context.Context, postID, userID pgtype.UUID) (liked bool, err error)
+	SetReactionTx(ctx context.Context, postID, userID pgtype.UUID, reaction string) (ReactionTxResult, error)
+	RemoveReactionTx(ctx context.Context, postID, userID pgtype.UUID) (ReactionTxResult, error)
 	AcceptFriendRequestTx(ctx context.Context, friendshipID pgtype.UUID) error
 	BlockUserTx(ctx context.Context, fromUser, toUser pgtype.UUID) error
 	UnblockUserTx(ctx context.Context, fromUser, toUser pgtype.UUID) error
@@ -25,6 +27,30 @@ type Hub interface {
 	GetSentFriendRequestsTx(ctx context.Context, userID pgtype.UUID) ([]Friendship, error)
 	IsUserBlockedTx(ctx context.Context, fromUser, toUser pgtype.UUID) (bool, error)
 	RefreshMaterializedViews(ctx context.Context) error
+	UpdateWallLayoutTx(ctx context.Context, wallID pgtype.UUID, layouts []UpdatePostLayoutParams) ([]Post, error)
+	ModeratePostsTx(ctx context.Context, wallID pgtype.UUID, postIDs []pgtype.UUID, status PostStatus) ([]Post, error)
+	PurgeDeletedTx(ctx context.Context, wallIDs, postIDs []pgtype.UUID) (PurgeTxResult, error)
+	DeleteWallTx(ctx context.Context, wallID pgtype.UUID) error
+	RestoreWallTx(ctx context.Context, wallID pgtype.UUID) (Wall, error)
+	FollowWallTx(ctx context.Context, wallID, userID pgtype.UUID) (WallSubscription, error)
+	UnfollowWallTx(ctx context.Context, wallID, userID pgtype.UUID) error
+	SetWallTagsTx(ctx context.Context, wallID pgtype.UUID, names []string) ([]Tag, error)
+	SetWallPinsTx(ctx context.Context, userID pgtype.UUID, wallIDs []pgtype.UUID) ([]Wall, error)
+	CreateWallTx(ctx context.Context, arg CreateWallTxParams) (CreateWallTxResult, error)
+	UpdateWallTx(ctx context.Context, arg UpdateWallTxParams) (UpdateWallTxResult, error)
+	SetWallVisibilityTx(ctx context.Context, wallID, actorID pgtype.UUID, public bool) (Wall, error)
+	RevertWallTx(ctx context.Context, wallID, revisionID, actorID pgtype.UUID) (Wall, error)
+	CreateWallSectionTx(ctx context.Context, wallID pgtype.UUID, name string) (WallSection, error)
+	ReorderWallSectionsTx(ctx context.Context, wallID pgtype.UUID, sectionIDs []pgtype.UUID) ([]WallSection, error)
+	DeleteWallSectionTx(ctx context.Context, wallID, sectionID pgtype.UUID) (DeleteWallSectionTxResult, error)
+	CreateCommentTx(ctx context.Context, arg CreateCommentParams) (Comment, error)
+	DeleteCommentTx(ctx context.Context, commentID, postID pgtype.UUID) error
+	ReplaceMentionsTx(ctx context.Context, arg ReplaceMentionsTxParams) (ReplaceMentionsTxResult, error)
+	CreatePostTx(ctx context.Context, arg CreatePostParams, uploadKey string) (Post, error)
+	CreateAlbumPostTx(ctx context.Context, arg CreateAlbumPostTxParams) (CreateAlbumPostTxResult, error)
+	CreateDrawingPostTx(ctx context.Context, arg CreateDrawingPostTxParams) (Post, error)
+	UpdatePostTx(ctx context.Context, arg UpdatePostParams, editorID pgtype.UUID, uploadKey string) (Post, error)
+	CreateReportTx(ctx context.Context, arg CreateReportParams, hideThreshold int64) (CreateReportTxResult, error)
 }
 
 // SQLHub provides all functions to execute db SQL queries and transactions
@@ -102,39 +128,25 @@ func (hub *SQLHub) CreateFriendRequestTx(ctx context.Context, fromUser, toUser p
 
 func (hub *SQLHub) CreateLikeTx(ctx context.Context, postID, userID pgtype.UUID) error {
 	err := hub.execTx(ctx, func(q *Queries) error {
-		arg := CreateLikeParams{
-			PostID: postID,
-			UserID: userID,
-		}
-		_, err := q.CreateLike(ctx, arg)
-		if err != nil {
-			return err
-		}
-
-		_, err = q.AddLikesCount(ctx, postID)
-
+		_, err := addReaction(ctx, q, postID, userID, LikeReaction)
 		return err
 	})
 
 	return err
 }
 
+// CreateOrDeleteLikeTx toggles a thumbs-up reaction. A user who reacted with
+// something else has their reaction changed to a thumbs-up.
 func (hub *SQLHub) CreateOrDeleteLikeTx(ctx context.Context, postID, userID pgtype.UUID) (liked bool, err error) {
 	err = hub.execTx(ctx, func(q *Queries) error {
-		_, err := q.GetLike(ctx, GetLikeParams{
+		like, err := q.GetLike(ctx, GetLikeParams{
 			PostID: postID,
 			UserID: userID,
 		})
 
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
-				if _, err := q.CreateLike(ctx, CreateLikeParams{
-					PostID: postID,
-					UserID: userID,
-				}); err != nil {
-					return err
-				}
-				if _, err := q.AddLikesCount(ctx, postID); err != nil {
+				if _, err := addReaction(ctx, q, postID, userID, LikeReaction); err != nil {
 					return err
 				}
 				liked = true
@@ -143,13 +155,15 @@ func (hub *SQLHub) CreateOrDeleteLikeTx(ctx context.Context, postID, userID pgty
 			return err
 		}
 
-		if err := q.DeleteLike(ctx, DeleteLikeParams{
-			PostID: postID,
-			UserID: userID,
-		}); err != nil {
-			return err
+		if like.Reaction != LikeReaction {
+			if _, err := changeReaction(ctx, q, like, LikeReaction); err != nil {
+				return err
+			}
+			liked = true
+			return nil
 		}
-		if _, err := q.RemoveLikesCount(ctx, postID); err != nil {
+
+		if _, err := removeReaction(ctx, q, like); err != nil {
 			return err
 		}
 		liked = false
@@ -160,6 +174,146 @@ func (hub *SQLHub) CreateOrDeleteLikeTx(ctx context.Context, postID, userID pgty
 	return liked, err
 }
 
+// LikeReaction is the reaction a like stands for. A post's likes_count only
+// counts these, its reaction_counts has every emoji.
+const LikeReaction = "👍"
+
+// ReactionTxResult is a post after one of its reactions changed
+type ReactionTxResult struct {
+	Post Post
+	// Reaction is the user's reaction now, empty once removed
+	Reaction string
+	// Previous is the reaction the user had before, empty if none
+	Previous string
+}
+
+// SetReactionTx sets a user's reaction on a post, replacing the one they had
+func (hub *SQLHub) SetReactionTx(ctx context.Context, postID, userID pgtype.UUID, reaction string) (ReactionTxResult, error) {
+	result := ReactionTxResult{Reaction: reaction}
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		like, err := q.GetLike(ctx, GetLikeParams{
+			PostID: postID,
+			UserID: userID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			result.Post, err = addReaction(ctx, q, postID, userID, reaction)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Previous = like.Reaction
+		if like.Reaction == reaction {
+			result.Post, err = q.GetPost(ctx, postID)
+			return err
+		}
+
+		result.Post, err = changeReaction(ctx, q, like, reaction)
+		return err
+	})
+
+	return result, err
+}
+
+// RemoveReactionTx removes a user's reaction from a post
+func (hub *SQLHub) RemoveReactionTx(ctx context.Context, postID, userID pgtype.UUID) (ReactionTxResult, error) {
+	var result ReactionTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		like, err := q.GetLike(ctx, GetLikeParams{
+			PostID: postID,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Previous = like.Reaction
+		result.Post, err = removeReaction(ctx, q, like)
+		return err
+	})
+
+	return result, err
+}
+
+// addReaction records a user's first reaction on a post and bumps its counts
+func addReaction(ctx context.Context, q *Queries, postID, userID pgtype.UUID, reaction string) (Post, error) {
+	if _, err := q.CreateLike(ctx, CreateLikeParams{
+		PostID:   postID,
+		UserID:   userID,
+		Reaction: reaction,
+	}); err != nil {
+		return Post{}, err
+	}
+	if reaction == LikeReaction {
+		if _, err := q.AddLikesCount(ctx, postID); err != nil {
+			return Post{}, err
+		}
+	}
+
+	return q.AddPostReactionCount(ctx, AddPostReactionCountParams{
+		Reaction: reaction,
+		Delta:    1,
+		ID:       postID,
+	})
+}
+
+// changeReaction swaps a user's reaction, moving one count between the two emojis
+func changeReaction(ctx context.Context, q *Queries, like Like, reaction string) (Post, error) {
+	if _, err := q.SetLikeReaction(ctx, SetLikeReactionParams{
+		PostID:   like.PostID,
+		UserID:   like.UserID,
+		Reaction: reaction,
+	}); err != nil {
+		return Post{}, err
+	}
+	if like.Reaction == LikeReaction {
+		if _, err := q.RemoveLikesCount(ctx, like.PostID); err != nil {
+			return Post{}, err
+		}
+	} else if reaction == LikeReaction {
+		if _, err := q.AddLikesCount(ctx, like.PostID); err != nil {
+			return Post{}, err
+		}
+	}
+	if _, err := q.AddPostReactionCount(ctx, AddPostReactionCountParams{
+		Reaction: like.Reaction,
+		Delta:    -1,
+		ID:       like.PostID,
+	}); err != nil {
+		return Post{}, err
+	}
+
+	return q.AddPostReactionCount(ctx, AddPostReactionCountParams{
+		Reaction: reaction,
+		Delta:    1,
+		ID:       like.PostID,
+	})
+}
+
+// removeReaction deletes a user's reaction and lowers the post's counts
+func removeReaction(ctx context.Context, q *Queries, like Like) (Post, error) {
+	if err := q.DeleteLike(ctx, DeleteLikeParams{
+		PostID: like.PostID,
+		UserID: like.UserID,
+	}); err != nil {
+		return Post{}, err
+	}
+	if like.Reaction == LikeReaction {
+		if _, err := q.RemoveLikesCount(ctx, like.PostID); err != nil {
+			return Post{}, err
+		}
+	}
+
+	return q.AddPostReactionCount(ctx, AddPostReactionCountParams{
+		Reaction: like.Reaction,
+		Delta:    -1,
+		ID:       like.PostID,
+	})
+}
+
 func (hub *SQLHub) AcceptFriendRequestTx(ctx context.Context, friendshipID pgtype.UUID) error {
 	return hub.execTx(ctx, func(q *Queries) error {
 		friendship, err := q.GetFriendship(ctx, friendshipID)
@@ -351,6 +505,932 @@ func (hub *SQLHub) IsUserBlockedTx(ctx context.Context, fromUser, toUser pgtype.
 	return isBlocked, err
 }
 
+// UpdateWallLayoutTx applies a batch of post layouts on a wall.
+// Every post must belong to the wall and still be at the given layout version,
+// otherwise nothing is applied.
+func (hub *SQLHub) UpdateWallLayoutTx(ctx context.Context, wallID pgtype.UUID, layouts []UpdatePostLayoutParams) ([]Post, error) {
+	posts := make([]Post, 0, len(layouts))
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		for _, layout := range layouts {
+			post, err := q.GetPost(ctx, layout.ID)
+			if err != nil {
+				return err
+			}
+			if post.WallID != wallID || post.IsDeleted.Bool {
+				return fmt.Errorf("%w: post %s", ErrPostNotInWall, layout.ID.String())
+			}
+
+			post, err = q.UpdatePostLayout(ctx, layout)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return fmt.Errorf("%w: post %s", ErrLayoutConflict, layout.ID.String())
+				}
+				return err
+			}
+			posts = append(posts, post)
+		}
+		return nil
+	})
+
+	return posts, err
+}
+
+// ModeratePostsTx approves or rejects a batch of pending posts on a wall.
+// If any post is not pending on that wall, none of them are changed.
+func (hub *SQLHub) ModeratePostsTx(ctx context.Context, wallID pgtype.UUID, postIDs []pgtype.UUID, status PostStatus) ([]Post, error) {
+	posts := make([]Post, 0, len(postIDs))
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		for _, postID := range postIDs {
+			post, err := q.GetPost(ctx, postID)
+			if err != nil {
+				return err
+			}
+			if post.WallID != wallID || post.IsDeleted.Bool {
+				return fmt.Errorf("%w: post %s", ErrPostNotInWall, postID.String())
+			}
+
+			post, err = q.ModeratePost(ctx, ModeratePostParams{
+				ID:     postID,
+				Status: status,
+			})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return fmt.Errorf("%w: post %s", ErrPostNotPending, postID.String())
+				}
+				return err
+			}
+			posts = append(posts, post)
+		}
+		return nil
+	})
+
+	return posts, err
+}
+
+// PurgeTxResult counts the rows removed by PurgeDeletedTx
+type PurgeTxResult struct {
+	Walls int64
+	Posts int64
+	Likes int64
+}
+
+// PurgeDeletedTx permanently removes walls and posts together with their likes.
+// Posts go before walls so nothing is left pointing at a missing wall.
+func (hub *SQLHub) PurgeDeletedTx(ctx context.Context, wallIDs, postIDs []pgtype.UUID) (PurgeTxResult, error) {
+	var result PurgeTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		if len(postIDs) > 0 {
+			result.Likes, err = q.DeleteLikesByPosts(ctx, postIDs)
+			if err != nil {
+				return err
+			}
+
+			result.Posts, err = q.HardDeletePosts(ctx, postIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(wallIDs) > 0 {
+			result.Walls, err = q.HardDeleteWalls(ctx, wallIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// DeleteWallTx soft-deletes a wall and its posts with the same deleted_at,
+// so RestoreWallTx can tell them apart from posts that were deleted earlier.
+func (hub *SQLHub) DeleteWallTx(ctx context.Context, wallID pgtype.UUID) error {
+	return hub.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteWall(ctx, wallID); err != nil {
+			return err
+		}
+
+		wall, err := q.GetWall(ctx, wallID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeletePostsByWall(ctx, DeletePostsByWallParams{
+			WallID:    wallID,
+			DeletedAt: wall.DeletedAt,
+		})
+	})
+}
+
+// RestoreWallTx brings back a soft-deleted wall along with the posts that were deleted with it
+func (hub *SQLHub) RestoreWallTx(ctx context.Context, wallID pgtype.UUID) (Wall, error) {
+	var restored Wall
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		wall, err := q.GetWall(ctx, wallID)
+		if err != nil {
+			return err
+		}
+
+		restored, err = q.RestoreWall(ctx, wallID)
+		if err != nil {
+			return err
+		}
+
+		return q.RestorePostsByWall(ctx, RestorePostsByWallParams{
+			WallID:    wallID,
+			DeletedAt: wall.DeletedAt,
+		})
+	})
+
+	return restored, err
+}
+
+// FollowWallTx subscribes a user to a wall and bumps its follower count.
+// Following a wall twice returns the existing subscription.
+func (hub *SQLHub) FollowWallTx(ctx context.Context, wallID, userID pgtype.UUID) (WallSubscription, error) {
+	var subscription WallSubscription
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		subscription, err = q.CreateWallSubscription(ctx, CreateWallSubscriptionParams{
+			WallID: wallID,
+			UserID: userID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			subscription, err = q.GetWallSubscription(ctx, GetWallSubscriptionParams{
+				WallID: wallID,
+				UserID: userID,
+			})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		return q.AddWallFollowerCount(ctx, AddWallFollowerCountParams{
+			ID:            wallID,
+			FollowerCount: 1,
+		})
+	})
+
+	return subscription, err
+}
+
+// UnfollowWallTx removes a user's subscription to a wall and lowers its follower count
+func (hub *SQLHub) UnfollowWallTx(ctx context.Context, wallID, userID pgtype.UUID) error {
+	return hub.execTx(ctx, func(q *Queries) error {
+		rows, err := q.DeleteWallSubscription(ctx, DeleteWallSubscriptionParams{
+			WallID: wallID,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrRecordNotFound
+		}
+
+		return q.AddWallFollowerCount(ctx, AddWallFollowerCountParams{
+			ID:            wallID,
+			FollowerCount: -1,
+		})
+	})
+}
+
+// SetWallTagsTx replaces the tags on a wall, creating any tags that don't exist yet.
+// Usage counts are recomputed for both the old and the new tags.
+func (hub *SQLHub) SetWallTagsTx(ctx context.Context, wallID pgtype.UUID, names []string) ([]Tag, error) {
+	var tags []Tag
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		tags, err = setWallTags(ctx, q, wallID, names)
+		return err
+	})
+
+	return tags, err
+}
+
+func setWallTags(ctx context.Context, q *Queries, wallID pgtype.UUID, names []string) ([]Tag, error) {
+	tagIDs, err := q.DeleteWallTags(ctx, wallID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		tag, err := q.UpsertTag(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = q.AddWallTag(ctx, AddWallTagParams{
+			WallID: wallID,
+			TagID:  tag.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	if len(tagIDs) > 0 {
+		if err := q.RefreshTagUsageCounts(ctx, tagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return q.ListTagsByWall(ctx, wallID)
+}
+
+// CreateWallTxParams describes a new wall together with everything it starts with
+type CreateWallTxParams struct {
+	Wall              CreateTestWallParams
+	ModerationEnabled bool
+	Tags              []string
+	// Sections are recreated on the new wall in order. A post whose SectionID
+	// is one of their IDs is placed in that section's copy.
+	Sections []WallSection
+	// Posts are created on the new wall; their WallID is ignored
+	Posts []CreatePostParams
+	// PostMedia and PostDrawings hold the album items and drawing strokes of
+	// the post at the same index in Posts; their PostID is ignored
+	PostMedia    map[int][]CreatePostMediaParams
+	PostDrawings map[int]CreatePostDrawingParams
+}
+
+// CreateWallTxResult is the wall created by CreateWallTx and its contents
+type CreateWallTxResult struct {
+	Wall  Wall
+	Tags  []Tag
+	Posts []Post
+}
+
+// CreateWallTx creates a wall with its moderation setting, tags, sections and posts in one go,
+// as used when starting from a template or cloning another wall.
+func (hub *SQLHub) CreateWallTx(ctx context.Context, arg CreateWallTxParams) (CreateWallTxResult, error) {
+	var result CreateWallTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Wall, err = q.CreateTestWall(ctx, arg.Wall)
+		if err != nil {
+			return err
+		}
+
+		if arg.ModerationEnabled {
+			result.Wall, err = q.SetWallModeration(ctx, SetWallModerationParams{
+				ID:                result.Wall.ID,
+				ModerationEnabled: pgtype.Bool{Bool: true, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(arg.Tags) > 0 {
+			result.Tags, err = setWallTags(ctx, q, result.Wall.ID, arg.Tags)
+			if err != nil {
+				return err
+			}
+		}
+
+		sectionIDs := make(map[pgtype.UUID]pgtype.UUID, len(arg.Sections))
+		for _, section := range arg.Sections {
+			created, err := q.CreateWallSection(ctx, CreateWallSectionParams{
+				WallID:    result.Wall.ID,
+				Name:      section.Name,
+				IsDefault: section.IsDefault,
+			})
+			if err != nil {
+				return err
+			}
+			sectionIDs[section.ID] = created.ID
+		}
+
+		result.Posts = make([]Post, 0, len(arg.Posts))
+		for i, postArg := range arg.Posts {
+			postArg.WallID = result.Wall.ID
+			postArg.SectionID = sectionIDs[postArg.SectionID]
+
+			post, err := q.CreatePost(ctx, postArg)
+			if err != nil {
+				return err
+			}
+			result.Posts = append(result.Posts, post)
+
+			for position, media := range arg.PostMedia[i] {
+				media.PostID = post.ID
+				media.Position = int32(position)
+				if _, err := q.CreatePostMedia(ctx, media); err != nil {
+					return err
+				}
+			}
+
+			if drawing, ok := arg.PostDrawings[i]; ok {
+				drawing.PostID = post.ID
+				if _, err := q.CreatePostDrawing(ctx, drawing); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// SetWallPinsTx replaces a user's pinned walls with wallIDs, pinned in that order.
+// Every wall must belong to the user and be neither archived nor deleted,
+// otherwise the existing pins are left untouched.
+func (hub *SQLHub) SetWallPinsTx(ctx context.Context, userID pgtype.UUID, wallIDs []pgtype.UUID) ([]Wall, error) {
+	walls := make([]Wall, 0, len(wallIDs))
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		if err := q.ClearWallPins(ctx, userID); err != nil {
+			return err
+		}
+
+		for i, wallID := range wallIDs {
+			wall, err := q.GetWall(ctx, wallID)
+			if err != nil {
+				return err
+			}
+			if wall.UserID != userID || wall.IsDeleted.Bool || wall.IsArchived.Bool {
+				return fmt.Errorf("%w: wall %s", ErrWallNotPinnable, wallID.String())
+			}
+
+			wall, err = q.SetWallPinOrder(ctx, SetWallPinOrderParams{
+				ID:       wallID,
+				PinOrder: pgtype.Int4{Int32: int32(i + 1), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			walls = append(walls, wall)
+		}
+		return nil
+	})
+
+	return walls, err
+}
+
+// UpdateWallTxParams describes an edit to a wall by ActorID
+type UpdateWallTxParams struct {
+	Wall    UpdateWallParams
+	ActorID pgtype.UUID
+	// Tags replace the wall's tags unless nil; an empty list clears them
+	Tags *[]string
+}
+
+// UpdateWallTxResult is the wall edited by UpdateWallTx, with its tags if they were replaced
+type UpdateWallTxResult struct {
+	Wall Wall
+	Tags []Tag
+}
+
+// UpdateWallTx edits a wall and its tags, and records its previous state as a revision
+func (hub *SQLHub) UpdateWallTx(ctx context.Context, arg UpdateWallTxParams) (UpdateWallTxResult, error) {
+	var result UpdateWallTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Wall, err = reviseWall(ctx, q, arg.Wall.ID, arg.ActorID, func() (Wall, error) {
+			return q.UpdateWall(ctx, arg.Wall)
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.Tags != nil {
+			result.Tags, err = setWallTags(ctx, q, arg.Wall.ID, *arg.Tags)
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// SetWallVisibilityTx makes a wall public or private and records its previous state as a revision
+func (hub *SQLHub) SetWallVisibilityTx(ctx context.Context, wallID, actorID pgtype.UUID, public bool) (Wall, error) {
+	var wall Wall
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		wall, err = reviseWall(ctx, q, wallID, actorID, func() (Wall, error) {
+			if public {
+				return q.PublicizeWall(ctx, wallID)
+			}
+			return q.PrivatizeWall(ctx, wallID)
+		})
+		return err
+	})
+
+	return wall, err
+}
+
+// RevertWallTx puts a wall back the way it was before a revision.
+// The revert is itself recorded, so it can be undone the same way.
+func (hub *SQLHub) RevertWallTx(ctx context.Context, wallID, revisionID, actorID pgtype.UUID) (Wall, error) {
+	var wall Wall
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		if _, err := q.GetWallRevision(ctx, GetWallRevisionParams{ID: revisionID, WallID: wallID}); err != nil {
+			return err
+		}
+
+		var err error
+		wall, err = reviseWall(ctx, q, wallID, actorID, func() (Wall, error) {
+			return q.RevertWallToRevision(ctx, RevertWallToRevisionParams{
+				RevisionID: revisionID,
+				WallID:     wallID,
+			})
+		})
+		return err
+	})
+
+	return wall, err
+}
+
+// reviseWall locks a wall, applies update and, if any field kept in the wall's
+// history changed, records how the wall looked before the update.
+func reviseWall(ctx context.Context, q *Queries, wallID, actorID pgtype.UUID, update func() (Wall, error)) (Wall, error) {
+	before, err := q.GetWallForUpdate(ctx, wallID)
+	if err != nil {
+		return Wall{}, err
+	}
+
+	after, err := update()
+	if err != nil {
+		return Wall{}, err
+	}
+
+	changed := wallChangedFields(before, after)
+	if len(changed) == 0 {
+		return after, nil
+	}
+
+	_, err = q.CreateWallRevision(ctx, CreateWallRevisionParams{
+		WallID:          wallID,
+		ActorID:         actorID,
+		Title:           before.Title,
+		Description:     before.Description,
+		BackgroundImage: before.BackgroundImage,
+		IsPublic:        before.IsPublic,
+		ChangedFields:   changed,
+	})
+	return after, err
+}
+
+// wallChangedFields lists the columns kept in wall_revisions that differ between two versions of a wall
+func wallChangedFields(before, after Wall) []string {
+	var fields []string
+	if before.Title != after.Title {
+		fields = append(fields, "title")
+	}
+	if before.Description != after.Description {
+		fields = append(fields, "description")
+	}
+	if before.BackgroundImage != after.BackgroundImage {
+		fields = append(fields, "background_image")
+	}
+	if before.IsPublic != after.IsPublic {
+		fields = append(fields, "is_public")
+	}
+	return fields
+}
+
+// DefaultWallSectionName is the name given to the section created with a wall's first section
+const DefaultWallSectionName = "General"
+
+// CreateWallSectionTx adds a section at the end of a wall.
+// The wall's first section also creates the default section, which takes every existing post.
+func (hub *SQLHub) CreateWallSectionTx(ctx context.Context, wallID pgtype.UUID, name string) (WallSection, error) {
+	var section WallSection
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		// Lock the wall so two first sections can't both create a default
+		if _, err := q.GetWallForUpdate(ctx, wallID); err != nil {
+			return err
+		}
+
+		_, err := q.GetDefaultWallSection(ctx, wallID)
+		if errors.Is(err, ErrRecordNotFound) {
+			defaultSection, err := q.CreateWallSection(ctx, CreateWallSectionParams{
+				WallID:    wallID,
+				Name:      DefaultWallSectionName,
+				IsDefault: true,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = q.AssignUnsectionedPosts(ctx, AssignUnsectionedPostsParams{
+				WallID:    wallID,
+				SectionID: defaultSection.ID,
+			})
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		section, err = q.CreateWallSection(ctx, CreateWallSectionParams{
+			WallID: wallID,
+			Name:   name,
+		})
+		return err
+	})
+
+	return section, err
+}
+
+// ReorderWallSectionsTx puts a wall's sections in the order of sectionIDs,
+// which must list every section of the wall exactly once.
+func (hub *SQLHub) ReorderWallSectionsTx(ctx context.Context, wallID pgtype.UUID, sectionIDs []pgtype.UUID) ([]WallSection, error) {
+	sections := make([]WallSection, 0, len(sectionIDs))
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		existing, err := q.ListWallSections(ctx, wallID)
+		if err != nil {
+			return err
+		}
+
+		remaining := make(map[pgtype.UUID]bool, len(existing))
+		for _, section := range existing {
+			remaining[section.ID] = true
+		}
+		if len(sectionIDs) != len(existing) {
+			return ErrSectionOrderMismatch
+		}
+
+		for i, sectionID := range sectionIDs {
+			if !remaining[sectionID] {
+				return ErrSectionOrderMismatch
+			}
+			delete(remaining, sectionID)
+
+			section, err := q.SetWallSectionPosition(ctx, SetWallSectionPositionParams{
+				ID:       sectionID,
+				Position: int32(i),
+			})
+			if err != nil {
+				return err
+			}
+			sections = append(sections, section)
+		}
+		return nil
+	})
+
+	return sections, err
+}
+
+// DeleteWallSectionTxResult is what DeleteWallSectionTx did with the section's posts
+type DeleteWallSectionTxResult struct {
+	DefaultSection WallSection
+	PostsMoved     int64
+}
+
+// DeleteWallSectionTx removes a section and moves its posts to the wall's default section
+func (hub *SQLHub) DeleteWallSectionTx(ctx context.Context, wallID, sectionID pgtype.UUID) (DeleteWallSectionTxResult, error) {
+	var result DeleteWallSectionTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		section, err := q.GetWallSection(ctx, GetWallSectionParams{ID: sectionID, WallID: wallID})
+		if err != nil {
+			return err
+		}
+		if section.IsDefault {
+			return ErrDefaultSection
+		}
+
+		result.DefaultSection, err = q.GetDefaultWallSection(ctx, wallID)
+		if err != nil {
+			return err
+		}
+
+		result.PostsMoved, err = q.MoveSectionPosts(ctx, MoveSectionPostsParams{
+			ToSectionID:   result.DefaultSection.ID,
+			FromSectionID: sectionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteWallSection(ctx, sectionID)
+	})
+
+	return result, err
+}
+
+// CreateCommentTx adds a comment or reply to a post and bumps its comment count
+func (hub *SQLHub) CreateCommentTx(ctx context.Context, arg CreateCommentParams) (Comment, error) {
+	var comment Comment
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		comment, err = q.CreateComment(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.AddPostCommentsCount(ctx, AddPostCommentsCountParams{
+			ID:            arg.PostID,
+			CommentsCount: 1,
+		})
+	})
+
+	return comment, err
+}
+
+// DeleteCommentTx soft deletes a comment and lowers its post's comment count.
+// Replies are kept, so a deleted comment stays in place as their parent.
+func (hub *SQLHub) DeleteCommentTx(ctx context.Context, commentID, postID pgtype.UUID) error {
+	return hub.execTx(ctx, func(q *Queries) error {
+		rows, err := q.SoftDeleteComment(ctx, commentID)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrRecordNotFound
+		}
+
+		return q.AddPostCommentsCount(ctx, AddPostCommentsCountParams{
+			ID:            postID,
+			CommentsCount: -1,
+		})
+	})
+}
+
+// ReplaceMentionsTxParams are the mentions in a caption or comment. Exactly one
+// of PostID and CommentID is set.
+type ReplaceMentionsTxParams struct {
+	MentionedBy pgtype.UUID
+	PostID      pgtype.UUID
+	CommentID   pgtype.UUID
+	Mentions    []CreateMentionParams
+}
+
+// ReplaceMentionsTxResult holds the saved mentions and the users that weren't
+// mentioned in the text before, who are the ones to notify
+type ReplaceMentionsTxResult struct {
+	Mentions []Mention
+	Added    []pgtype.UUID
+}
+
+// ReplaceMentionsTx swaps the mentions stored for a post or comment for a new set
+func (hub *SQLHub) ReplaceMentionsTx(ctx context.Context, arg ReplaceMentionsTxParams) (ReplaceMentionsTxResult, error) {
+	var result ReplaceMentionsTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var previous []pgtype.UUID
+		var err error
+		if arg.PostID.Valid {
+			previous, err = q.DeletePostMentions(ctx, arg.PostID)
+		} else {
+			previous, err = q.DeleteCommentMentions(ctx, arg.CommentID)
+		}
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[pgtype.UUID]bool, len(previous))
+		for _, userID := range previous {
+			seen[userID] = true
+		}
+
+		for _, mention := range arg.Mentions {
+			mention.MentionedBy = arg.MentionedBy
+			mention.PostID = arg.PostID
+			mention.CommentID = arg.CommentID
+
+			created, err := q.CreateMention(ctx, mention)
+			if err != nil {
+				return err
+			}
+			result.Mentions = append(result.Mentions, created)
+
+			if !seen[created.UserID] {
+				seen[created.UserID] = true
+				result.Added = append(result.Added, created.UserID)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// CreatePostTx creates a post and claims the upload its media came from, so
+// the same upload can't back a second post. An empty uploadKey claims nothing.
+func (hub *SQLHub) CreatePostTx(ctx context.Context, arg CreatePostParams, uploadKey string) (Post, error) {
+	var post Post
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		post, err = q.CreatePost(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return claimPostUpload(ctx, q, uploadKey, post.ID)
+	})
+
+	return post, err
+}
+
+// CreateAlbumPostTxParams holds an album post, its media in display order and
+// the upload keys those media came from
+type CreateAlbumPostTxParams struct {
+	Post       CreatePostParams
+	Media      []CreatePostMediaParams
+	UploadKeys []string
+}
+
+// CreateAlbumPostTxResult holds a new album post and its media in order
+type CreateAlbumPostTxResult struct {
+	Post  Post
+	Media []PostMedium
+}
+
+// CreateAlbumPostTx creates an album post with its media, numbered in the
+// order given, and claims every upload the media came from
+func (hub *SQLHub) CreateAlbumPostTx(ctx context.Context, arg CreateAlbumPostTxParams) (CreateAlbumPostTxResult, error) {
+	var result CreateAlbumPostTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Post, err = q.CreatePost(ctx, arg.Post)
+		if err != nil {
+			return err
+		}
+
+		result.Media = make([]PostMedium, 0, len(arg.Media))
+		for i, media := range arg.Media {
+			media.PostID = result.Post.ID
+			media.Position = int32(i)
+
+			medium, err := q.CreatePostMedia(ctx, media)
+			if err != nil {
+				return err
+			}
+			result.Media = append(result.Media, medium)
+		}
+
+		for _, key := range arg.UploadKeys {
+			if err := claimPostUpload(ctx, q, key, result.Post.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// CreateDrawingPostTxParams holds a drawing post, whose media_url is its
+// rendered preview, and the strokes it was drawn with
+type CreateDrawingPostTxParams struct {
+	Post    CreatePostParams
+	Width   int32
+	Height  int32
+	Strokes []byte
+}
+
+// CreateDrawingPostTx creates a drawing post along with its strokes
+func (hub *SQLHub) CreateDrawingPostTx(ctx context.Context, arg CreateDrawingPostTxParams) (Post, error) {
+	var post Post
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		post, err = q.CreatePost(ctx, arg.Post)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePostDrawing(ctx, CreatePostDrawingParams{
+			PostID:  post.ID,
+			Width:   arg.Width,
+			Height:  arg.Height,
+			Strokes: arg.Strokes,
+		})
+		return err
+	})
+
+	return post, err
+}
+
+// claimPostUpload ties an upload to a post, failing with ErrUploadClaimed if
+// another post got to it first
+func claimPostUpload(ctx context.Context, q *Queries, key string, postID pgtype.UUID) error {
+	if key == "" {
+		return nil
+	}
+
+	_, err := q.ClaimUpload(ctx, ClaimUploadParams{Key: key, PostID: postID})
+	if errors.Is(err, ErrRecordNotFound) {
+		return ErrUploadClaimed
+	}
+	return err
+}
+
+// UpdatePostTx edits a post and, if its content changed, records how the post
+// looked before the edit as a revision. A non-empty uploadKey is claimed for
+// the post's new media.
+func (hub *SQLHub) UpdatePostTx(ctx context.Context, arg UpdatePostParams, editorID pgtype.UUID, uploadKey string) (Post, error) {
+	var post Post
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetPostForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		post, err = q.UpdatePost(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if err := claimPostUpload(ctx, q, uploadKey, post.ID); err != nil {
+			return err
+		}
+
+		if before.MediaUrl == post.MediaUrl && before.PostType == post.PostType && before.Caption == post.Caption {
+			return nil
+		}
+
+		_, err = q.CreatePostRevision(ctx, CreatePostRevisionParams{
+			PostID:   post.ID,
+			EditorID: editorID,
+			MediaUrl: before.MediaUrl,
+			PostType: before.PostType,
+			Caption:  before.Caption,
+		})
+		return err
+	})
+
+	return post, err
+}
+
+// CreateReportTxResult holds a new report and whether it got its post or wall hidden
+type CreateReportTxResult struct {
+	Report Report
+	Hidden bool
+}
+
+// CreateReportTx files a report and hides the reported post or wall once it
+// has hideThreshold open reports. Users are never hidden automatically, and a
+// threshold of zero turns automatic hiding off.
+func (hub *SQLHub) CreateReportTx(ctx context.Context, arg CreateReportParams, hideThreshold int64) (CreateReportTxResult, error) {
+	var result CreateReportTxResult
+
+	err := hub.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Report, err = q.CreateReport(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if hideThreshold <= 0 || arg.TargetType == ReportTargetTypeUser {
+			return nil
+		}
+
+		count, err := q.CountOpenReportsForTarget(ctx, CountOpenReportsForTargetParams{
+			TargetType: arg.TargetType,
+			TargetID:   arg.TargetID,
+		})
+		if err != nil {
+			return err
+		}
+		if count < hideThreshold {
+			return nil
+		}
+
+		var hidden int64
+		if arg.TargetType == ReportTargetTypePost {
+			hidden, err = q.HidePost(ctx, arg.TargetID)
+		} else {
+			hidden, err = q.HideWall(ctx, arg.TargetID)
+		}
+		result.Hidden = hidden > 0
+		return err
+	})
+
+	return result, err
+}
+
 func (h *SQLHub) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 	return h.db.Exec(ctx, sql, args...)
 }
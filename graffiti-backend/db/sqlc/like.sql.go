@@ -11,28 +11,82 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addPostReactionCount = `-- name: AddPostReactionCount :one
+UPDATE posts
+SET reaction_counts = CASE
+    WHEN COALESCE((reaction_counts ->> $1::text)::int, 0) + $2::int > 0
+        THEN jsonb_set(reaction_counts, ARRAY[$1::text], to_jsonb(COALESCE((reaction_counts ->> $1::text)::int, 0) + $2::int))
+    ELSE reaction_counts - $1::text
+END
+WHERE id = $3
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
+`
+
+type AddPostReactionCountParams struct {
+	Reaction string
+	Delta    int32
+	ID       pgtype.UUID
+}
+
+// Moves one emoji's count on a post by delta, dropping it once it reaches zero
+func (q *Queries) AddPostReactionCount(ctx context.Context, arg AddPostReactionCountParams) (Post, error) {
+	row := q.db.QueryRow(ctx, addPostReactionCount, arg.Reaction, arg.Delta, arg.ID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.WallID,
+		&i.Author,
+		&i.MediaUrl,
+		&i.PostType,
+		&i.IsHighlighted,
+		&i.LikesCount,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
+	)
+	return i, err
+}
+
 const createLike = `-- name: CreateLike :one
 INSERT INTO likes(
  post_id,
- user_id
+ user_id,
+ reaction
 ) VALUES (
-  $1, $2
-) RETURNING id, post_id, user_id, liked_at
+  $1, $2, $3
+) RETURNING id, post_id, user_id, liked_at, reaction
 `
 
 type CreateLikeParams struct {
-	PostID pgtype.UUID
-	UserID pgtype.UUID
+	PostID   pgtype.UUID
+	UserID   pgtype.UUID
+	Reaction string
 }
 
 func (q *Queries) CreateLike(ctx context.Context, arg CreateLikeParams) (Like, error) {
-	row := q.db.QueryRow(ctx, createLike, arg.PostID, arg.UserID)
+	row := q.db.QueryRow(ctx, createLike, arg.PostID, arg.UserID, arg.Reaction)
 	var i Like
 	err := row.Scan(
 		&i.ID,
 		&i.PostID,
 		&i.UserID,
 		&i.LikedAt,
+		&i.Reaction,
 	)
 	return i, err
 }
@@ -53,7 +107,7 @@ func (q *Queries) DeleteLike(ctx context.Context, arg DeleteLikeParams) error {
 }
 
 const getLike = `-- name: GetLike :one
-SELECT id, post_id, user_id, liked_at FROM likes
+SELECT id, post_id, user_id, liked_at, reaction FROM likes
 WHERE post_id = $1 AND user_id = $2 LIMIT 1
 `
 
@@ -70,6 +124,7 @@ func (q *Queries) GetLike(ctx context.Context, arg GetLikeParams) (Like, error)
 		&i.PostID,
 		&i.UserID,
 		&i.LikedAt,
+		&i.Reaction,
 	)
 	return i, err
 }
@@ -87,7 +142,7 @@ func (q *Queries) GetNumberOfLikesByPost(ctx context.Context, postID pgtype.UUID
 }
 
 const listLikes = `-- name: ListLikes :many
-SELECT id, post_id, user_id, liked_at FROM likes
+SELECT id, post_id, user_id, liked_at, reaction FROM likes
 ORDER BY liked_at DESC
 `
 
@@ -105,6 +160,7 @@ func (q *Queries) ListLikes(ctx context.Context) ([]Like, error) {
 			&i.PostID,
 			&i.UserID,
 			&i.LikedAt,
+			&i.Reaction,
 		); err != nil {
 			return nil, err
 		}
@@ -117,7 +173,7 @@ func (q *Queries) ListLikes(ctx context.Context) ([]Like, error) {
 }
 
 const listLikesByPost = `-- name: ListLikesByPost :many
-SELECT id, post_id, user_id, liked_at FROM likes
+SELECT id, post_id, user_id, liked_at, reaction FROM likes
 WHERE post_id = $1
 ORDER BY liked_at DESC
 `
@@ -136,6 +192,7 @@ func (q *Queries) ListLikesByPost(ctx context.Context, postID pgtype.UUID) ([]Li
 			&i.PostID,
 			&i.UserID,
 			&i.LikedAt,
+			&i.Reaction,
 		); err != nil {
 			return nil, err
 		}
@@ -148,7 +205,7 @@ func (q *Queries) ListLikesByPost(ctx context.Context, postID pgtype.UUID) ([]Li
 }
 
 const listLikesByUser = `-- name: ListLikesByUser :many
-SELECT id, post_id, user_id, liked_at FROM likes
+SELECT id, post_id, user_id, liked_at, reaction FROM likes
 WHERE user_id = $1
 ORDER BY liked_at DESC
 `
@@ -167,6 +224,7 @@ func (q *Queries) ListLikesByUser(ctx context.Context, userID pgtype.UUID) ([]Li
 			&i.PostID,
 			&i.UserID,
 			&i.LikedAt,
+			&i.Reaction,
 		); err != nil {
 			return nil, err
 		}
@@ -177,3 +235,29 @@ func (q *Queries) ListLikesByUser(ctx context.Context, userID pgtype.UUID) ([]Li
 	}
 	return items, nil
 }
+
+const setLikeReaction = `-- name: SetLikeReaction :one
+UPDATE likes
+SET reaction = $3, liked_at = now()
+WHERE post_id = $1 AND user_id = $2
+RETURNING id, post_id, user_id, liked_at, reaction
+`
+
+type SetLikeReactionParams struct {
+	PostID   pgtype.UUID
+	UserID   pgtype.UUID
+	Reaction string
+}
+
+func (q *Queries) SetLikeReaction(ctx context.Context, arg SetLikeReactionParams) (Like, error) {
+	row := q.db.QueryRow(ctx, setLikeReaction, arg.PostID, arg.UserID, arg.Reaction)
+	var i Like
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.UserID,
+		&i.LikedAt,
+		&i.Reaction,
+	)
+	return i, err
+}
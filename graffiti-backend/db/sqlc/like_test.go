@@ -13,8 +13,9 @@ func createRandomLike(t *testing.T) Like {
 	post := createRandomPost(t)
 
 	arg := CreateLikeParams{
-		PostID: post.ID,
-		UserID: user.ID,
+		PostID:   post.ID,
+		UserID:   user.ID,
+		Reaction: LikeReaction,
 	}
 
 	like, err := testHub.CreateLike(context.Background(), arg)
@@ -110,6 +111,7 @@ func TestListLikesByPost(t *testing.T) {
         arg := CreateLikeParams{
             PostID: post.ID,
             UserID: createRandomUser(t).ID,
+            Reaction: LikeReaction,
         }
         like, err := testHub.CreateLike(context.Background(), arg)
         require.NoError(t, err, "Error occurred while creating the like")
@@ -120,6 +122,7 @@ func TestListLikesByPost(t *testing.T) {
     otherLikeArg := CreateLikeParams{
         PostID: otherPost.ID,
         UserID: createRandomUser(t).ID,
+        Reaction: LikeReaction,
     }
     _, err := testHub.CreateLike(context.Background(), otherLikeArg)
     require.NoError(t, err, "Error occurred while creating the random like for the other post")
@@ -264,4 +267,63 @@ func TestGetNumberOfLikesByPost(t *testing.T) {
 	require.NoError(t, err, "Error occurred while deleting postA")
 	err = testHub.DeletePost(context.Background(), postB.ID)
 	require.NoError(t, err, "Error occurred while deleting postB")
-}
\ No newline at end of file
+}
+func TestSetAndRemoveReactionTx(t *testing.T) {
+	user := createRandomUser(t)
+	post := createRandomPost(t)
+
+	result, err := testHub.SetReactionTx(context.Background(), post.ID, user.ID, "🎉")
+	require.NoError(t, err)
+	require.Empty(t, result.Previous)
+	// Only thumbs-up reactions count as likes
+	require.Equal(t, int32(0), result.Post.LikesCount.Int32)
+	require.JSONEq(t, `{"🎉": 1}`, string(result.Post.ReactionCounts))
+
+	// Changing the reaction moves the count without adding a second one
+	result, err = testHub.SetReactionTx(context.Background(), post.ID, user.ID, LikeReaction)
+	require.NoError(t, err)
+	require.Equal(t, "🎉", result.Previous)
+	require.Equal(t, int32(1), result.Post.LikesCount.Int32)
+	require.JSONEq(t, `{"👍": 1}`, string(result.Post.ReactionCounts))
+
+	result, err = testHub.SetReactionTx(context.Background(), post.ID, user.ID, "❤️")
+	require.NoError(t, err)
+	require.Equal(t, LikeReaction, result.Previous)
+	require.Equal(t, int32(0), result.Post.LikesCount.Int32)
+	require.JSONEq(t, `{"❤️": 1}`, string(result.Post.ReactionCounts))
+
+	result, err = testHub.RemoveReactionTx(context.Background(), post.ID, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "❤️", result.Previous)
+	require.Equal(t, int32(0), result.Post.LikesCount.Int32)
+	require.JSONEq(t, `{}`, string(result.Post.ReactionCounts))
+
+	_, err = testHub.RemoveReactionTx(context.Background(), post.ID, user.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestCreateOrDeleteLikeTxReaction(t *testing.T) {
+	user := createRandomUser(t)
+	post := createRandomPost(t)
+
+	_, err := testHub.SetReactionTx(context.Background(), post.ID, user.ID, "🎉")
+	require.NoError(t, err)
+
+	// Liking a post the user reacted to turns the reaction into a thumbs-up
+	liked, err := testHub.CreateOrDeleteLikeTx(context.Background(), post.ID, user.ID)
+	require.NoError(t, err)
+	require.True(t, liked)
+
+	like, err := testHub.GetLike(context.Background(), GetLikeParams{PostID: post.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, LikeReaction, like.Reaction)
+
+	liked, err = testHub.CreateOrDeleteLikeTx(context.Background(), post.ID, user.ID)
+	require.NoError(t, err)
+	require.False(t, liked)
+
+	updated, err := testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(0), updated.LikesCount.Int32)
+	require.JSONEq(t, `{}`, string(updated.ReactionCounts))
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func TestUpsertLinkPreview(t *testing.T) {
+	url := fmt.Sprintf("https://example.com/%s", util.RandomString(12))
+
+	created, err := testHub.UpsertLinkPreview(context.Background(), UpsertLinkPreviewParams{
+		Url:      url,
+		Title:    "First title",
+		Provider: "example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "First title", created.Title)
+	require.NotZero(t, created.FetchedAt.Time)
+
+	// Fetching the same link again replaces the cached preview
+	updated, err := testHub.UpsertLinkPreview(context.Background(), UpsertLinkPreviewParams{
+		Url:          url,
+		Title:        "Second title",
+		ThumbnailUrl: "https://example.com/cover.jpg",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Second title", updated.Title)
+	require.Empty(t, updated.Provider)
+	require.False(t, updated.FetchedAt.Time.Before(created.FetchedAt.Time))
+
+	got, err := testHub.GetLinkPreview(context.Background(), url)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+
+	previews, err := testHub.ListLinkPreviewsByURL(context.Background(), []string{url, url + "/missing"})
+	require.NoError(t, err)
+	require.Len(t, previews, 1)
+	require.Equal(t, url, previews[0].Url)
+}
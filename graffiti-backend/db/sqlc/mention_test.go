@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceMentionsTx(t *testing.T) {
+	post := createRandomPost(t)
+	first := createRandomUser(t)
+	second := createRandomUser(t)
+
+	result, err := testHub.ReplaceMentionsTx(context.Background(), ReplaceMentionsTxParams{
+		MentionedBy: post.Author,
+		PostID:      post.ID,
+		Mentions: []CreateMentionParams{
+			{UserID: first.ID, StartOffset: 0, EndOffset: 5},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Mentions, 1)
+	require.Equal(t, post.ID, result.Mentions[0].PostID)
+	require.Equal(t, post.Author, result.Mentions[0].MentionedBy)
+	require.Equal(t, []pgtype.UUID{first.ID}, result.Added)
+
+	// Only the user who wasn't mentioned before counts as added
+	result, err = testHub.ReplaceMentionsTx(context.Background(), ReplaceMentionsTxParams{
+		MentionedBy: post.Author,
+		PostID:      post.ID,
+		Mentions: []CreateMentionParams{
+			{UserID: first.ID, StartOffset: 0, EndOffset: 5},
+			{UserID: second.ID, StartOffset: 6, EndOffset: 11},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Mentions, 2)
+	require.Equal(t, []pgtype.UUID{second.ID}, result.Added)
+
+	mentions, err := testHub.ListMentionsByPosts(context.Background(), []pgtype.UUID{post.ID})
+	require.NoError(t, err)
+	require.Len(t, mentions, 2)
+	require.Equal(t, first.Username, mentions[0].Username)
+	require.Equal(t, second.Username, mentions[1].Username)
+
+	// An empty set clears the post's mentions
+	result, err = testHub.ReplaceMentionsTx(context.Background(), ReplaceMentionsTxParams{
+		MentionedBy: post.Author,
+		PostID:      post.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Mentions)
+	require.Empty(t, result.Added)
+
+	mentions, err = testHub.ListMentionsByPosts(context.Background(), []pgtype.UUID{post.ID})
+	require.NoError(t, err)
+	require.Empty(t, mentions)
+}
+
+func TestListMentionableUsers(t *testing.T) {
+	author := createRandomUser(t)
+	friend := createRandomUser(t)
+	blocker := createRandomUser(t)
+
+	_, err := testHub.CreateFriendship(context.Background(), CreateFriendshipParams{
+		FromUser: blocker.ID,
+		ToUser:   author.ID,
+		Status:   NullStatus{Status: StatusBlocked, Valid: true},
+	})
+	require.NoError(t, err)
+
+	users, err := testHub.ListMentionableUsers(context.Background(), ListMentionableUsersParams{
+		Usernames: []string{friend.Username, blocker.Username, "no-such-user"},
+		AuthorID:  author.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, friend.ID, users[0].ID)
+}
@@ -11,11 +11,58 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type PostStatus string
+
+const (
+	PostStatusPending   PostStatus = "pending"
+	PostStatusApproved  PostStatus = "approved"
+	PostStatusRejected  PostStatus = "rejected"
+	PostStatusScheduled PostStatus = "scheduled"
+)
+
+func (e *PostStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PostStatus(s)
+	case string:
+		*e = PostStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PostStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPostStatus struct {
+	PostStatus PostStatus
+	Valid      bool // Valid is true if PostStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPostStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PostStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PostStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPostStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PostStatus), nil
+}
+
 type PostType string
 
 const (
 	PostTypeMedia     PostType = "media"
 	PostTypeEmbedLink PostType = "embed_link"
+	PostTypeText      PostType = "text"
+	PostTypeAlbum     PostType = "album"
+	PostTypeDrawing   PostType = "drawing"
 )
 
 func (e *PostType) Scan(src interface{}) error {
@@ -53,6 +100,92 @@ func (ns NullPostType) Value() (driver.Value, error) {
 	return string(ns.PostType), nil
 }
 
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+func (e *ReportStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportStatus(s)
+	case string:
+		*e = ReportStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReportStatus struct {
+	ReportStatus ReportStatus
+	Valid        bool // Valid is true if ReportStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReportStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReportStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReportStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReportStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReportStatus), nil
+}
+
+type ReportTargetType string
+
+const (
+	ReportTargetTypePost ReportTargetType = "post"
+	ReportTargetTypeWall ReportTargetType = "wall"
+	ReportTargetTypeUser ReportTargetType = "user"
+)
+
+func (e *ReportTargetType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportTargetType(s)
+	case string:
+		*e = ReportTargetType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportTargetType: %T", src)
+	}
+	return nil
+}
+
+type NullReportTargetType struct {
+	ReportTargetType ReportTargetType
+	Valid            bool // Valid is true if ReportTargetType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReportTargetType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReportTargetType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReportTargetType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReportTargetType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReportTargetType), nil
+}
+
 type Status string
 
 const (
@@ -101,6 +234,18 @@ type AcceptedFriendshipsMv struct {
 	FriendID pgtype.UUID
 }
 
+type Comment struct {
+	ID        pgtype.UUID
+	PostID    pgtype.UUID
+	Author    pgtype.UUID
+	ParentID  pgtype.UUID
+	Body      string
+	IsDeleted bool
+	DeletedAt pgtype.Timestamp
+	EditedAt  pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+}
+
 type Friendship struct {
 	ID        pgtype.UUID
 	FromUser  pgtype.UUID
@@ -111,10 +256,32 @@ type Friendship struct {
 }
 
 type Like struct {
-	ID      pgtype.UUID
-	PostID  pgtype.UUID
-	UserID  pgtype.UUID
-	LikedAt pgtype.Timestamp
+	ID       pgtype.UUID
+	PostID   pgtype.UUID
+	UserID   pgtype.UUID
+	LikedAt  pgtype.Timestamp
+	Reaction string
+}
+
+type LinkPreview struct {
+	Url          string
+	Title        string
+	Description  string
+	ThumbnailUrl string
+	Provider     string
+	EmbedHtml    string
+	FetchedAt    pgtype.Timestamp
+}
+
+type Mention struct {
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	MentionedBy pgtype.UUID
+	PostID      pgtype.UUID
+	CommentID   pgtype.UUID
+	StartOffset int32
+	EndOffset   int32
+	CreatedAt   pgtype.Timestamp
 }
 
 type Notification struct {
@@ -129,15 +296,91 @@ type Notification struct {
 }
 
 type Post struct {
-	ID            pgtype.UUID
-	WallID        pgtype.UUID
-	Author        pgtype.UUID
-	MediaUrl      pgtype.Text
-	PostType      NullPostType
-	IsHighlighted pgtype.Bool
-	LikesCount    pgtype.Int4
-	IsDeleted     pgtype.Bool
-	CreatedAt     pgtype.Timestamp
+	ID             pgtype.UUID
+	WallID         pgtype.UUID
+	Author         pgtype.UUID
+	MediaUrl       pgtype.Text
+	PostType       NullPostType
+	IsHighlighted  pgtype.Bool
+	LikesCount     pgtype.Int4
+	IsDeleted      pgtype.Bool
+	CreatedAt      pgtype.Timestamp
+	PosX           float64
+	PosY           float64
+	Rotation       float64
+	Scale          float64
+	ZIndex         int32
+	LayoutVersion  int32
+	Status         PostStatus
+	DeletedAt      pgtype.Timestamp
+	SectionID      pgtype.UUID
+	Caption        pgtype.Text
+	CommentsCount  int32
+	ReactionCounts []byte
+	EditedAt       pgtype.Timestamp
+	IsHidden       bool
+	PublishAt      pgtype.Timestamp
+	IsAnonymous    bool
+}
+
+type PostDrawing struct {
+	PostID    pgtype.UUID
+	Width     int32
+	Height    int32
+	Strokes   []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type PostMedium struct {
+	ID        pgtype.UUID
+	PostID    pgtype.UUID
+	Position  int32
+	MediaUrl  string
+	Width     int32
+	Height    int32
+	AltText   string
+	CreatedAt pgtype.Timestamp
+}
+
+type PostRevision struct {
+	ID        pgtype.UUID
+	PostID    pgtype.UUID
+	EditorID  pgtype.UUID
+	MediaUrl  pgtype.Text
+	PostType  NullPostType
+	Caption   pgtype.Text
+	CreatedAt pgtype.Timestamp
+}
+
+type Report struct {
+	ID             pgtype.UUID
+	ReporterID     pgtype.UUID
+	TargetType     ReportTargetType
+	TargetID       pgtype.UUID
+	Reason         string
+	Detail         string
+	Status         ReportStatus
+	ResolvedBy     pgtype.UUID
+	ResolutionNote string
+	ResolvedAt     pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+}
+
+type Tag struct {
+	ID         pgtype.UUID
+	Name       string
+	UsageCount int32
+	CreatedAt  pgtype.Timestamp
+}
+
+type Upload struct {
+	Key         string
+	UserID      pgtype.UUID
+	ContentType string
+	FileSize    int64
+	PostID      pgtype.UUID
+	ClaimedAt   pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
 }
 
 type User struct {
@@ -153,19 +396,100 @@ type User struct {
 	OnboardingAt    pgtype.Timestamp
 	CreatedAt       pgtype.Timestamp
 	UpdatedAt       pgtype.Timestamp
+	AutoArchiveDays pgtype.Int4
+	Role            string
+	SuspendedUntil  pgtype.Timestamp
 }
 
 type Wall struct {
+	ID                pgtype.UUID
+	UserID            pgtype.UUID
+	Title             string
+	Description       pgtype.Text
+	BackgroundImage   pgtype.Text
+	IsPublic          pgtype.Bool
+	IsArchived        pgtype.Bool
+	IsDeleted         pgtype.Bool
+	PopularityScore   pgtype.Float8
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+	IsPinned          pgtype.Bool
+	ModerationEnabled pgtype.Bool
+	DeletedAt         pgtype.Timestamp
+	FollowerCount     int32
+	PinOrder          pgtype.Int4
+	ArchiveWarnedAt   pgtype.Timestamp
+	UnarchivedAt      pgtype.Timestamp
+	IsHidden          bool
+	AllowAnonymous    bool
+}
+
+type WallExport struct {
+	ID          pgtype.UUID
+	WallID      pgtype.UUID
+	UserID      pgtype.UUID
+	Status      string
+	ArchiveUrl  pgtype.Text
+	ImageUrl    pgtype.Text
+	Error       pgtype.Text
+	CreatedAt   pgtype.Timestamp
+	CompletedAt pgtype.Timestamp
+}
+
+type WallModerator struct {
+	WallID    pgtype.UUID
+	UserID    pgtype.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type WallRevision struct {
 	ID              pgtype.UUID
-	UserID          pgtype.UUID
+	WallID          pgtype.UUID
+	ActorID         pgtype.UUID
 	Title           string
 	Description     pgtype.Text
 	BackgroundImage pgtype.Text
 	IsPublic        pgtype.Bool
-	IsArchived      pgtype.Bool
-	IsDeleted       pgtype.Bool
-	PopularityScore pgtype.Float8
+	ChangedFields   []string
 	CreatedAt       pgtype.Timestamp
-	UpdatedAt       pgtype.Timestamp
-	IsPinned        pgtype.Bool
+}
+
+type WallSection struct {
+	ID        pgtype.UUID
+	WallID    pgtype.UUID
+	Name      string
+	Position  int32
+	IsDefault bool
+	CreatedAt pgtype.Timestamp
+}
+
+type WallSubscription struct {
+	WallID    pgtype.UUID
+	UserID    pgtype.UUID
+	Muted     bool
+	CreatedAt pgtype.Timestamp
+}
+
+type WallTag struct {
+	WallID pgtype.UUID
+	TagID  pgtype.UUID
+}
+
+type WallTemplate struct {
+	ID                pgtype.UUID
+	Slug              string
+	Title             string
+	Description       pgtype.Text
+	BackgroundKey     pgtype.Text
+	ModerationEnabled bool
+	Tags              []string
+	SortOrder         int32
+	CreatedAt         pgtype.Timestamp
+}
+
+type WallView struct {
+	WallID    pgtype.UUID
+	ViewerID  pgtype.UUID
+	ViewDate  pgtype.Date
+	CreatedAt pgtype.Timestamp
 }
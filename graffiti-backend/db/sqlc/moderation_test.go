@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func createPendingPost(t *testing.T, wall Wall) Post {
+	user := createRandomUser(t)
+
+	post, err := testHub.CreatePost(context.Background(), CreatePostParams{
+		WallID:   wall.ID,
+		Author:   user.ID,
+		MediaUrl: pgtype.Text{String: "https://example.com/media/" + util.RandomString(10) + ".jpg", Valid: true},
+		PostType: NullPostType{PostType: "media", Valid: true},
+		Scale:    1,
+		Status:   PostStatusPending,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PostStatusPending, post.Status)
+
+	return post
+}
+
+func TestSetWallModeration(t *testing.T) {
+	wall := createRandomWall(t)
+	require.False(t, wall.ModerationEnabled.Bool)
+
+	updated, err := testHub.SetWallModeration(context.Background(), SetWallModerationParams{
+		ID:                wall.ID,
+		ModerationEnabled: pgtype.Bool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, updated.ModerationEnabled.Bool)
+}
+
+func TestWallModerators(t *testing.T) {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+
+	arg := AddWallModeratorParams{
+		WallID: wall.ID,
+		UserID: user.ID,
+	}
+
+	_, err := testHub.AddWallModerator(context.Background(), arg)
+	require.NoError(t, err)
+
+	isModerator, err := testHub.IsWallModerator(context.Background(), IsWallModeratorParams(arg))
+	require.NoError(t, err)
+	require.True(t, isModerator)
+
+	moderators, err := testHub.ListWallModerators(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Len(t, moderators, 1)
+	require.Equal(t, user.Username, moderators[0].Username)
+
+	err = testHub.RemoveWallModerator(context.Background(), RemoveWallModeratorParams(arg))
+	require.NoError(t, err)
+
+	isModerator, err = testHub.IsWallModerator(context.Background(), IsWallModeratorParams(arg))
+	require.NoError(t, err)
+	require.False(t, isModerator)
+}
+
+func TestPendingPostsVisibility(t *testing.T) {
+	wall := createRandomWall(t)
+	pending := createPendingPost(t, wall)
+
+	queue, err := testHub.ListPendingPostsByWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	require.Equal(t, pending.ID, queue[0].ID)
+
+	// Hidden from other viewers but visible to its author
+	posts, err := testHub.ListPostsByWallWithAuthorsDetails(context.Background(), ListPostsByWallWithAuthorsDetailsParams{
+		WallID: wall.ID,
+		Author: wall.UserID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, posts)
+
+	posts, err = testHub.ListPostsByWallWithAuthorsDetails(context.Background(), ListPostsByWallWithAuthorsDetailsParams{
+		WallID: wall.ID,
+		Author: pending.Author,
+	})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+}
+
+func TestModeratePostsTx(t *testing.T) {
+	wall := createRandomWall(t)
+	post1 := createPendingPost(t, wall)
+	post2 := createPendingPost(t, wall)
+
+	posts, err := testHub.ModeratePostsTx(context.Background(), wall.ID, []pgtype.UUID{post1.ID, post2.ID}, PostStatusApproved)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	for _, post := range posts {
+		require.Equal(t, PostStatusApproved, post.Status)
+	}
+
+	// Already reviewed posts can't be moderated again
+	post3 := createPendingPost(t, wall)
+	_, err = testHub.ModeratePostsTx(context.Background(), wall.ID, []pgtype.UUID{post3.ID, post1.ID}, PostStatusRejected)
+	require.ErrorIs(t, err, ErrPostNotPending)
+
+	got, err := testHub.GetPost(context.Background(), post3.ID)
+	require.NoError(t, err)
+	require.Equal(t, PostStatusPending, got.Status)
+}
+
+func TestListPostsByWallWithoutAuthorSkipsPending(t *testing.T) {
+	wall := createRandomWall(t)
+	pending := createPendingPost(t, wall)
+
+	// With no author to show their own posts to, only approved ones are listed
+	posts, err := testHub.ListPostsByWallWithAuthorsDetails(context.Background(), ListPostsByWallWithAuthorsDetailsParams{
+		WallID: wall.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, posts)
+
+	posts, err = testHub.ListPostsByWallWithAuthorsDetails(context.Background(), ListPostsByWallWithAuthorsDetailsParams{
+		WallID: wall.ID,
+		Author: pending.Author,
+	})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+}
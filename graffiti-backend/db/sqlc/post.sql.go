@@ -15,7 +15,7 @@ const addLikesCount = `-- name: AddLikesCount :one
 UPDATE posts
   set likes_count = likes_count + 1
 WHERE id = $1
-RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
 `
 
 func (q *Queries) AddLikesCount(ctx context.Context, id pgtype.UUID) (Post, error) {
@@ -31,6 +31,22 @@ func (q *Queries) AddLikesCount(ctx context.Context, id pgtype.UUID) (Post, erro
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
@@ -40,17 +56,37 @@ INSERT INTO posts(
  wall_id,
  author,
  media_url,
- post_type
+ post_type,
+ pos_x,
+ pos_y,
+ rotation,
+ scale,
+ z_index,
+ status,
+ section_id,
+ caption,
+ publish_at,
+ is_anonymous
 ) VALUES (
-  $1, $2, $3, $4
-) RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
 `
 
 type CreatePostParams struct {
-	WallID   pgtype.UUID
-	Author   pgtype.UUID
-	MediaUrl pgtype.Text
-	PostType NullPostType
+	WallID      pgtype.UUID
+	Author      pgtype.UUID
+	MediaUrl    pgtype.Text
+	PostType    NullPostType
+	PosX        float64
+	PosY        float64
+	Rotation    float64
+	Scale       float64
+	ZIndex      int32
+	Status      PostStatus
+	SectionID   pgtype.UUID
+	Caption     pgtype.Text
+	PublishAt   pgtype.Timestamp
+	IsAnonymous bool
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
@@ -59,6 +95,16 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.Author,
 		arg.MediaUrl,
 		arg.PostType,
+		arg.PosX,
+		arg.PosY,
+		arg.Rotation,
+		arg.Scale,
+		arg.ZIndex,
+		arg.Status,
+		arg.SectionID,
+		arg.Caption,
+		arg.PublishAt,
+		arg.IsAnonymous,
 	)
 	var i Post
 	err := row.Scan(
@@ -71,13 +117,30 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
 
 const deletePost = `-- name: DeletePost :exec
 UPDATE posts
-  set is_deleted = true
+  set is_deleted = true,
+  deleted_at = COALESCE(deleted_at, now())
 WHERE id = $1
 `
 
@@ -86,9 +149,26 @@ func (q *Queries) DeletePost(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deletePostsByWall = `-- name: DeletePostsByWall :exec
+UPDATE posts
+  set is_deleted = true,
+  deleted_at = $2
+WHERE wall_id = $1 AND is_deleted = false;
+`
+
+type DeletePostsByWallParams struct {
+	WallID    pgtype.UUID
+	DeletedAt pgtype.Timestamp
+}
+
+func (q *Queries) DeletePostsByWall(ctx context.Context, arg DeletePostsByWallParams) error {
+	_, err := q.db.Exec(ctx, deletePostsByWall, arg.WallID, arg.DeletedAt)
+	return err
+}
+
 const getHighlightedPosts = `-- name: GetHighlightedPosts :many
-SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at FROM posts
-WHERE is_highlighted = true
+SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous FROM posts
+WHERE is_highlighted = true AND status = 'approved' AND is_hidden = false
 ORDER BY id
 `
 
@@ -111,6 +191,22 @@ func (q *Queries) GetHighlightedPosts(ctx context.Context) ([]Post, error) {
 			&i.LikesCount,
 			&i.IsDeleted,
 			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -123,8 +219,8 @@ func (q *Queries) GetHighlightedPosts(ctx context.Context) ([]Post, error) {
 }
 
 const getHighlightedPostsByWall = `-- name: GetHighlightedPostsByWall :many
-SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at FROM posts
-WHERE wall_id = $1 AND is_highlighted = true
+SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous FROM posts
+WHERE wall_id = $1 AND is_highlighted = true AND status = 'approved' AND is_hidden = false
 ORDER BY id
 `
 
@@ -147,6 +243,22 @@ func (q *Queries) GetHighlightedPostsByWall(ctx context.Context, wallID pgtype.U
 			&i.LikesCount,
 			&i.IsDeleted,
 			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -159,7 +271,7 @@ func (q *Queries) GetHighlightedPostsByWall(ctx context.Context, wallID pgtype.U
 }
 
 const getPost = `-- name: GetPost :one
-SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at FROM posts
+SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous FROM posts
 WHERE id = $1 LIMIT 1
 `
 
@@ -176,6 +288,22 @@ func (q *Queries) GetPost(ctx context.Context, id pgtype.UUID) (Post, error) {
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
@@ -184,7 +312,7 @@ const highlightPost = `-- name: HighlightPost :one
 UPDATE posts
   set is_highlighted = true
 WHERE id = $1
-RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
 `
 
 func (q *Queries) HighlightPost(ctx context.Context, id pgtype.UUID) (Post, error) {
@@ -200,12 +328,181 @@ func (q *Queries) HighlightPost(ctx context.Context, id pgtype.UUID) (Post, erro
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
 
+const listClonablePostsByWall = `-- name: ListClonablePostsByWall :many
+SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous FROM posts
+WHERE wall_id = $1
+AND author = $2
+AND is_deleted = false
+AND status = 'approved'
+AND is_hidden = false
+ORDER BY z_index, created_at
+`
+
+type ListClonablePostsByWallParams struct {
+	WallID pgtype.UUID
+	Author pgtype.UUID
+}
+
+func (q *Queries) ListClonablePostsByWall(ctx context.Context, arg ListClonablePostsByWallParams) ([]Post, error) {
+	rows, err := q.db.Query(ctx, listClonablePostsByWall, arg.WallID, arg.Author)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.WallID,
+			&i.Author,
+			&i.MediaUrl,
+			&i.PostType,
+			&i.IsHighlighted,
+			&i.LikesCount,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedPostsByUser = `-- name: ListDeletedPostsByUser :many
+SELECT p.id, p.wall_id, p.author, p.media_url, p.post_type, p.is_highlighted, p.likes_count, p.is_deleted, p.created_at, p.pos_x, p.pos_y, p.rotation, p.scale, p.z_index, p.layout_version, p.status, p.deleted_at, p.section_id, p.caption, p.comments_count, p.reaction_counts, p.edited_at, p.is_hidden, p.publish_at, p.is_anonymous, w.title AS wall_title FROM posts p
+JOIN walls w ON p.wall_id = w.id
+WHERE (p.author = $1 OR w.user_id = $1)
+AND p.is_deleted = true
+AND p.deleted_at >= $2
+AND w.is_deleted = false
+ORDER BY p.deleted_at DESC;
+`
+
+type ListDeletedPostsByUserParams struct {
+	Author    pgtype.UUID
+	DeletedAt pgtype.Timestamp
+}
+
+type ListDeletedPostsByUserRow struct {
+	ID             pgtype.UUID
+	WallID         pgtype.UUID
+	Author         pgtype.UUID
+	MediaUrl       pgtype.Text
+	PostType       NullPostType
+	IsHighlighted  pgtype.Bool
+	LikesCount     pgtype.Int4
+	IsDeleted      pgtype.Bool
+	CreatedAt      pgtype.Timestamp
+	PosX           float64
+	PosY           float64
+	Rotation       float64
+	Scale          float64
+	ZIndex         int32
+	LayoutVersion  int32
+	Status         PostStatus
+	DeletedAt      pgtype.Timestamp
+	SectionID      pgtype.UUID
+	Caption        pgtype.Text
+	CommentsCount  int32
+	ReactionCounts []byte
+	EditedAt       pgtype.Timestamp
+	IsHidden       bool
+	PublishAt      pgtype.Timestamp
+	IsAnonymous    bool
+	WallTitle      string
+}
+
+func (q *Queries) ListDeletedPostsByUser(ctx context.Context, arg ListDeletedPostsByUserParams) ([]ListDeletedPostsByUserRow, error) {
+	rows, err := q.db.Query(ctx, listDeletedPostsByUser, arg.Author, arg.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedPostsByUserRow
+	for rows.Next() {
+		var i ListDeletedPostsByUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WallID,
+			&i.Author,
+			&i.MediaUrl,
+			&i.PostType,
+			&i.IsHighlighted,
+			&i.LikesCount,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
+			&i.WallTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPosts = `-- name: ListPosts :many
-SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at FROM posts
+SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous FROM posts
+WHERE status = 'approved' AND is_hidden = false
 ORDER BY id
 `
 
@@ -228,6 +525,22 @@ func (q *Queries) ListPosts(ctx context.Context) ([]Post, error) {
 			&i.LikesCount,
 			&i.IsDeleted,
 			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -240,9 +553,9 @@ func (q *Queries) ListPosts(ctx context.Context) ([]Post, error) {
 }
 
 const listPostsByWall = `-- name: ListPostsByWall :many
-SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at FROM posts
-WHERE wall_id = $1
-ORDER BY id DESC
+SELECT id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous FROM posts
+WHERE wall_id = $1 AND is_hidden = false
+ORDER BY z_index, created_at
 `
 
 func (q *Queries) ListPostsByWall(ctx context.Context, wallID pgtype.UUID) ([]Post, error) {
@@ -264,6 +577,22 @@ func (q *Queries) ListPostsByWall(ctx context.Context, wallID pgtype.UUID) ([]Po
 			&i.LikesCount,
 			&i.IsDeleted,
 			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -276,12 +605,20 @@ func (q *Queries) ListPostsByWall(ctx context.Context, wallID pgtype.UUID) ([]Po
 }
 
 const listPostsByWallWithAuthorsDetails = `-- name: ListPostsByWallWithAuthorsDetails :many
-SELECT p.id, p.wall_id, p.author, p.media_url, p.post_type, p.is_highlighted, p.likes_count, p.is_deleted, p.created_at, u.username, u.profile_picture, u.fullname FROM posts p
+SELECT p.id, p.wall_id, p.author, p.media_url, p.post_type, p.is_highlighted, p.likes_count, p.is_deleted, p.created_at, p.pos_x, p.pos_y, p.rotation, p.scale, p.z_index, p.layout_version, p.status, p.deleted_at, p.section_id, p.caption, p.comments_count, p.reaction_counts, p.edited_at, p.is_hidden, p.publish_at, p.is_anonymous, u.username, u.profile_picture, u.fullname FROM posts p
 JOIN users u ON p.author = u.id
 WHERE p.wall_id = $1 and p.is_deleted = false
-ORDER BY p.created_at DESC
+AND ((p.status = 'approved' AND p.is_hidden = false) OR p.author = $2)
+AND ($3::uuid IS NULL OR p.section_id = $3)
+ORDER BY p.z_index, p.created_at
 `
 
+type ListPostsByWallWithAuthorsDetailsParams struct {
+	WallID    pgtype.UUID
+	Author    pgtype.UUID
+	SectionID pgtype.UUID
+}
+
 type ListPostsByWallWithAuthorsDetailsRow struct {
 	ID             pgtype.UUID
 	WallID         pgtype.UUID
@@ -292,13 +629,29 @@ type ListPostsByWallWithAuthorsDetailsRow struct {
 	LikesCount     pgtype.Int4
 	IsDeleted      pgtype.Bool
 	CreatedAt      pgtype.Timestamp
+	PosX           float64
+	PosY           float64
+	Rotation       float64
+	Scale          float64
+	ZIndex         int32
+	LayoutVersion  int32
+	Status         PostStatus
+	DeletedAt      pgtype.Timestamp
+	SectionID      pgtype.UUID
+	Caption        pgtype.Text
+	CommentsCount  int32
+	ReactionCounts []byte
+	EditedAt       pgtype.Timestamp
+	IsHidden       bool
+	PublishAt      pgtype.Timestamp
+	IsAnonymous    bool
 	Username       string
 	ProfilePicture pgtype.Text
 	Fullname       pgtype.Text
 }
 
-func (q *Queries) ListPostsByWallWithAuthorsDetails(ctx context.Context, wallID pgtype.UUID) ([]ListPostsByWallWithAuthorsDetailsRow, error) {
-	rows, err := q.db.Query(ctx, listPostsByWallWithAuthorsDetails, wallID)
+func (q *Queries) ListPostsByWallWithAuthorsDetails(ctx context.Context, arg ListPostsByWallWithAuthorsDetailsParams) ([]ListPostsByWallWithAuthorsDetailsRow, error) {
+	rows, err := q.db.Query(ctx, listPostsByWallWithAuthorsDetails, arg.WallID, arg.Author, arg.SectionID)
 	if err != nil {
 		return nil, err
 	}
@@ -316,6 +669,22 @@ func (q *Queries) ListPostsByWallWithAuthorsDetails(ctx context.Context, wallID
 			&i.LikesCount,
 			&i.IsDeleted,
 			&i.CreatedAt,
+			&i.PosX,
+			&i.PosY,
+			&i.Rotation,
+			&i.Scale,
+			&i.ZIndex,
+			&i.LayoutVersion,
+			&i.Status,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.Caption,
+			&i.CommentsCount,
+			&i.ReactionCounts,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.PublishAt,
+			&i.IsAnonymous,
 			&i.Username,
 			&i.ProfilePicture,
 			&i.Fullname,
@@ -334,7 +703,7 @@ const removeLikesCount = `-- name: RemoveLikesCount :one
 UPDATE posts
   set likes_count = likes_count - 1
 WHERE id = $1
-RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
 `
 
 func (q *Queries) RemoveLikesCount(ctx context.Context, id pgtype.UUID) (Post, error) {
@@ -350,15 +719,89 @@ func (q *Queries) RemoveLikesCount(ctx context.Context, id pgtype.UUID) (Post, e
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
 
+const restorePost = `-- name: RestorePost :one
+UPDATE posts
+  set is_deleted = false,
+  deleted_at = NULL
+WHERE id = $1 AND is_deleted = true
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous;
+`
+
+func (q *Queries) RestorePost(ctx context.Context, id pgtype.UUID) (Post, error) {
+	row := q.db.QueryRow(ctx, restorePost, id)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.WallID,
+		&i.Author,
+		&i.MediaUrl,
+		&i.PostType,
+		&i.IsHighlighted,
+		&i.LikesCount,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
+	)
+	return i, err
+}
+
+const restorePostsByWall = `-- name: RestorePostsByWall :exec
+UPDATE posts
+  set is_deleted = false,
+  deleted_at = NULL
+WHERE wall_id = $1 AND is_deleted = true AND deleted_at = $2;
+`
+
+type RestorePostsByWallParams struct {
+	WallID    pgtype.UUID
+	DeletedAt pgtype.Timestamp
+}
+
+func (q *Queries) RestorePostsByWall(ctx context.Context, arg RestorePostsByWallParams) error {
+	_, err := q.db.Exec(ctx, restorePostsByWall, arg.WallID, arg.DeletedAt)
+	return err
+}
+
 const unhighlightPost = `-- name: UnhighlightPost :one
 UPDATE posts
   set is_highlighted = false
 WHERE id = $1
-RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
 `
 
 func (q *Queries) UnhighlightPost(ctx context.Context, id pgtype.UUID) (Post, error) {
@@ -374,6 +817,22 @@ func (q *Queries) UnhighlightPost(ctx context.Context, id pgtype.UUID) (Post, er
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
@@ -382,19 +841,97 @@ const updatePost = `-- name: UpdatePost :one
 UPDATE posts
   set
     media_url = COALESCE($2, media_url),
-    post_type = COALESCE($3, post_type)
+    post_type = COALESCE($3, post_type),
+    caption = COALESCE($4, caption),
+    edited_at = CASE
+      WHEN COALESCE($2, media_url) IS DISTINCT FROM media_url
+        OR COALESCE($3, post_type) IS DISTINCT FROM post_type
+        OR COALESCE($4, caption) IS DISTINCT FROM caption
+      THEN now()
+      ELSE edited_at
+    END
 WHERE id = $1
-RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
 `
 
 type UpdatePostParams struct {
 	ID       pgtype.UUID
 	MediaUrl pgtype.Text
 	PostType NullPostType
+	Caption  pgtype.Text
 }
 
 func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error) {
-	row := q.db.QueryRow(ctx, updatePost, arg.ID, arg.MediaUrl, arg.PostType)
+	row := q.db.QueryRow(ctx, updatePost,
+		arg.ID,
+		arg.MediaUrl,
+		arg.PostType,
+		arg.Caption,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.WallID,
+		&i.Author,
+		&i.MediaUrl,
+		&i.PostType,
+		&i.IsHighlighted,
+		&i.LikesCount,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
+	)
+	return i, err
+}
+
+const updatePostLayout = `-- name: UpdatePostLayout :one
+UPDATE posts
+  set
+    pos_x = $2,
+    pos_y = $3,
+    rotation = $4,
+    scale = $5,
+    z_index = $6,
+    layout_version = layout_version + 1
+WHERE id = $1 AND layout_version = $7
+RETURNING id, wall_id, author, media_url, post_type, is_highlighted, likes_count, is_deleted, created_at, pos_x, pos_y, rotation, scale, z_index, layout_version, status, deleted_at, section_id, caption, comments_count, reaction_counts, edited_at, is_hidden, publish_at, is_anonymous
+`
+
+type UpdatePostLayoutParams struct {
+	ID            pgtype.UUID
+	PosX          float64
+	PosY          float64
+	Rotation      float64
+	Scale         float64
+	ZIndex        int32
+	LayoutVersion int32
+}
+
+func (q *Queries) UpdatePostLayout(ctx context.Context, arg UpdatePostLayoutParams) (Post, error) {
+	row := q.db.QueryRow(ctx, updatePostLayout,
+		arg.ID,
+		arg.PosX,
+		arg.PosY,
+		arg.Rotation,
+		arg.Scale,
+		arg.ZIndex,
+		arg.LayoutVersion,
+	)
 	var i Post
 	err := row.Scan(
 		&i.ID,
@@ -406,6 +943,22 @@ func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, e
 		&i.LikesCount,
 		&i.IsDeleted,
 		&i.CreatedAt,
+		&i.PosX,
+		&i.PosY,
+		&i.Rotation,
+		&i.Scale,
+		&i.ZIndex,
+		&i.LayoutVersion,
+		&i.Status,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.Caption,
+		&i.CommentsCount,
+		&i.ReactionCounts,
+		&i.EditedAt,
+		&i.IsHidden,
+		&i.PublishAt,
+		&i.IsAnonymous,
 	)
 	return i, err
 }
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDrawingPostTx(t *testing.T) {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+
+	arg := CreateDrawingPostTxParams{
+		Post: CreatePostParams{
+			WallID:   wall.ID,
+			Author:   user.ID,
+			MediaUrl: pgtype.Text{String: "https://cdn.example.com/uploads/drawings/sketch.png", Valid: true},
+			PostType: NullPostType{PostType: PostTypeDrawing, Valid: true},
+			Scale:    1,
+			Status:   PostStatusApproved,
+		},
+		Width:   400,
+		Height:  300,
+		Strokes: []byte(`[{"points":[[10,10],[20,20]],"color":"#000000","size":3}]`),
+	}
+
+	post, err := testHub.CreateDrawingPostTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, PostTypeDrawing, post.PostType.PostType)
+
+	drawing, err := testHub.GetPostDrawing(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Width, drawing.Width)
+	require.Equal(t, arg.Height, drawing.Height)
+	require.JSONEq(t, string(arg.Strokes), string(drawing.Strokes))
+
+	// Strokes go with their post
+	_, err = testHub.GetPostDrawing(context.Background(), createRandomPost(t).ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
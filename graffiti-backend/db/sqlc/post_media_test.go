@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlbumPostTx(t *testing.T) {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+
+	var arg CreateAlbumPostTxParams
+	for i := 0; i < 3; i++ {
+		upload := createRandomUpload(t, user.ID)
+		arg.UploadKeys = append(arg.UploadKeys, upload.Key)
+		arg.Media = append(arg.Media, CreatePostMediaParams{
+			MediaUrl: "https://cdn.example.com/" + upload.Key,
+			Width:    1080,
+			Height:   1350,
+			AltText:  "A photo",
+		})
+	}
+	arg.Post = CreatePostParams{
+		WallID:   wall.ID,
+		Author:   user.ID,
+		MediaUrl: pgtype.Text{String: arg.Media[0].MediaUrl, Valid: true},
+		PostType: NullPostType{PostType: PostTypeAlbum, Valid: true},
+		Scale:    1,
+		Status:   PostStatusApproved,
+	}
+
+	result, err := testHub.CreateAlbumPostTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, PostTypeAlbum, result.Post.PostType.PostType)
+	require.Len(t, result.Media, 3)
+
+	media, err := testHub.ListPostMediaByPosts(context.Background(), []pgtype.UUID{result.Post.ID})
+	require.NoError(t, err)
+	require.Len(t, media, 3)
+	for i, medium := range media {
+		require.Equal(t, int32(i), medium.Position)
+		require.Equal(t, arg.Media[i].MediaUrl, medium.MediaUrl)
+
+		claimed, err := testHub.GetUpload(context.Background(), arg.UploadKeys[i])
+		require.NoError(t, err)
+		require.Equal(t, result.Post.ID, claimed.PostID)
+	}
+
+	// Reusing any of the uploads fails the whole album
+	_, err = testHub.CreateAlbumPostTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrUploadClaimed)
+
+	posts, err := testHub.ListPostsByWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatePostTx(t *testing.T) {
+	post := createRandomPost(t)
+	require.False(t, post.EditedAt.Valid)
+
+	updated, err := testHub.UpdatePostTx(context.Background(), UpdatePostParams{
+		ID:       post.ID,
+		MediaUrl: pgtype.Text{String: post.MediaUrl.String + "?v=2", Valid: true},
+		PostType: post.PostType,
+	}, post.Author, "")
+	require.NoError(t, err)
+	require.Equal(t, post.MediaUrl.String+"?v=2", updated.MediaUrl.String)
+	require.True(t, updated.EditedAt.Valid)
+
+	revisions, err := testHub.ListPostRevisions(context.Background(), ListPostRevisionsParams{PostID: post.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, post.MediaUrl, revisions[0].MediaUrl)
+	require.Equal(t, post.Author, revisions[0].EditorID)
+
+	// Saving without changes neither adds to the history nor moves edited_at
+	same, err := testHub.UpdatePostTx(context.Background(), UpdatePostParams{ID: post.ID}, post.Author, "")
+	require.NoError(t, err)
+	require.Equal(t, updated.EditedAt, same.EditedAt)
+
+	revisions, err = testHub.ListPostRevisions(context.Background(), ListPostRevisionsParams{PostID: post.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+}
+
+func TestPrunePostRevisions(t *testing.T) {
+	post := createRandomPost(t)
+
+	_, err := testHub.UpdatePostTx(context.Background(), UpdatePostParams{
+		ID:       post.ID,
+		MediaUrl: pgtype.Text{String: post.MediaUrl.String + "?v=2", Valid: true},
+	}, post.Author, "")
+	require.NoError(t, err)
+
+	revisions, err := testHub.ListPrunablePostRevisions(context.Background(), ListPrunablePostRevisionsParams{
+		CreatedAt: pgtype.Timestamp{Time: time.Now().Add(time.Minute), Valid: true},
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+
+	var ids []pgtype.UUID
+	for _, revision := range revisions {
+		ids = append(ids, revision.ID)
+	}
+	require.NotEmpty(t, ids)
+
+	// The old media isn't used by anything once its revision is pruned
+	inUse, err := testHub.ListMediaURLsInUse(context.Background(), ListMediaURLsInUseParams{
+		MediaUrls:   []string{post.MediaUrl.String, post.MediaUrl.String + "?v=2"},
+		RevisionIds: ids,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{post.MediaUrl.String + "?v=2"}, inUse)
+
+	media, err := testHub.ListPostRevisionMediaByPosts(context.Background(), []pgtype.UUID{post.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{post.MediaUrl.String}, media)
+
+	n, err := testHub.DeletePostRevisions(context.Background(), ids)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(ids)), n)
+}
@@ -21,6 +21,9 @@ func createRandomPost(t *testing.T) Post {
 			PostType: "media",
 			Valid:    true,
 		},
+		Scale:   1,
+		Status:  PostStatusApproved,
+		Caption: pgtype.Text{String: util.RandomString(20), Valid: true},
 	}
 
 	post, err := testHub.CreatePost(context.Background(), arg)
@@ -31,6 +34,7 @@ func createRandomPost(t *testing.T) Post {
 	require.Equal(t, arg.Author, post.Author)
 	require.Equal(t, arg.MediaUrl.String, post.MediaUrl.String)
 	require.Equal(t, arg.PostType.PostType, post.PostType.PostType)
+	require.Equal(t, arg.Caption, post.Caption)
 	require.False(t, post.IsHighlighted.Bool)
 	require.Equal(t, pgtype.Int4(pgtype.Int4{Int32:0, Valid:true}), post.LikesCount)
 	require.False(t, post.IsDeleted.Bool)
@@ -43,6 +47,29 @@ func TestCreatePost(t *testing.T) {
 	createRandomPost(t)
 }
 
+func TestCreateTextPost(t *testing.T) {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+
+	post, err := testHub.CreatePost(context.Background(), CreatePostParams{
+		WallID:   wall.ID,
+		Author:   user.ID,
+		PostType: NullPostType{PostType: PostTypeText, Valid: true},
+		Scale:    1,
+		Status:   PostStatusApproved,
+		Caption:  pgtype.Text{String: "Happy birthday!", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PostTypeText, post.PostType.PostType)
+	require.False(t, post.MediaUrl.Valid)
+	require.Equal(t, "Happy birthday!", post.Caption.String)
+
+	// Updating without a caption keeps the current one
+	updated, err := testHub.UpdatePost(context.Background(), UpdatePostParams{ID: post.ID})
+	require.NoError(t, err)
+	require.Equal(t, post.Caption, updated.Caption)
+}
+
 func TestGetPost(t *testing.T) {
 	post1 := createRandomPost(t)
 
@@ -214,6 +241,9 @@ func TestListPostsByWall(t *testing.T) {
 			Author:   user.ID,
 			MediaUrl: pgtype.Text{String: "https://example.com/media/" + util.RandomString(10) + ".jpg", Valid: true},
 			PostType: NullPostType{PostType: "media", Valid: true},
+			Scale:    1,
+			Status:   PostStatusApproved,
+			ZIndex:   int32(postCount - i),
 		}
 		_, err := testHub.CreatePost(context.Background(), arg)
 		require.NoError(t, err)
@@ -227,9 +257,83 @@ func TestListPostsByWall(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, postCount, len(wallPosts))
 
-	for _, post := range wallPosts {
+	for i, post := range wallPosts {
 		require.Equal(t, wall.ID, post.WallID)
+		// Posts come back bottom layer first
+		require.Equal(t, int32(i+1), post.ZIndex)
+	}
+}
+
+func TestUpdatePostLayout(t *testing.T) {
+	post := createRandomPost(t)
+	require.Equal(t, int32(1), post.LayoutVersion)
+
+	arg := UpdatePostLayoutParams{
+		ID:            post.ID,
+		PosX:          120,
+		PosY:          80.5,
+		Rotation:      -15,
+		Scale:         1.5,
+		ZIndex:        2,
+		LayoutVersion: post.LayoutVersion,
+	}
+
+	updated, err := testHub.UpdatePostLayout(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.PosX, updated.PosX)
+	require.Equal(t, arg.PosY, updated.PosY)
+	require.Equal(t, arg.Rotation, updated.Rotation)
+	require.Equal(t, arg.Scale, updated.Scale)
+	require.Equal(t, arg.ZIndex, updated.ZIndex)
+	require.Equal(t, post.LayoutVersion+1, updated.LayoutVersion)
+
+	// Reusing the old version must not overwrite the newer layout
+	_, err = testHub.UpdatePostLayout(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestUpdateWallLayoutTx(t *testing.T) {
+	post1 := createRandomPost(t)
+	user := createRandomUser(t)
+	post2, err := testHub.CreatePost(context.Background(), CreatePostParams{
+		WallID:   post1.WallID,
+		Author:   user.ID,
+		MediaUrl: pgtype.Text{String: "https://example.com/media/" + util.RandomString(10) + ".jpg", Valid: true},
+		PostType: NullPostType{PostType: "media", Valid: true},
+		Scale:    1,
+		Status:   PostStatusApproved,
+	})
+	require.NoError(t, err)
+
+	layouts := []UpdatePostLayoutParams{
+		{ID: post1.ID, PosX: 10, Scale: 1, ZIndex: 1, LayoutVersion: 1},
+		{ID: post2.ID, PosX: 20, Scale: 1, ZIndex: 0, LayoutVersion: 1},
+	}
+
+	posts, err := testHub.UpdateWallLayoutTx(context.Background(), post1.WallID, layouts)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+
+	// A stale version on the second post rolls back the first one too
+	layouts = []UpdatePostLayoutParams{
+		{ID: post1.ID, PosX: 50, Scale: 1, ZIndex: 1, LayoutVersion: 2},
+		{ID: post2.ID, PosX: 60, Scale: 1, ZIndex: 0, LayoutVersion: 1},
 	}
+
+	_, err = testHub.UpdateWallLayoutTx(context.Background(), post1.WallID, layouts)
+	require.ErrorIs(t, err, ErrLayoutConflict)
+
+	got, err := testHub.GetPost(context.Background(), post1.ID)
+	require.NoError(t, err)
+	require.Equal(t, float64(10), got.PosX)
+	require.Equal(t, int32(2), got.LayoutVersion)
+
+	// Posts from another wall are rejected
+	other := createRandomPost(t)
+	_, err = testHub.UpdateWallLayoutTx(context.Background(), post1.WallID, []UpdatePostLayoutParams{
+		{ID: other.ID, Scale: 1, LayoutVersion: 1},
+	})
+	require.ErrorIs(t, err, ErrPostNotInWall)
 }
 
 func TestGetHighlightedPosts(t *testing.T) {
@@ -269,6 +373,8 @@ func TestGetHighlightedPostsByWall(t *testing.T) {
 			Author:   user.ID,
 			MediaUrl: pgtype.Text{String: "https://example.com/embed_link/" + util.RandomString(10) + ".jpg", Valid: true},
 			PostType: NullPostType{PostType: "embed_link", Valid: true},
+			Scale:    1,
+			Status:   PostStatusApproved,
 		}
 		post, err := testHub.CreatePost(context.Background(), arg)
 		require.NoError(t, err)
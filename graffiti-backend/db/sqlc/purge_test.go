@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeDeletedTx(t *testing.T) {
+	like := createRandomLike(t)
+	post, err := testHub.GetPost(context.Background(), like.PostID)
+	require.NoError(t, err)
+
+	err = testHub.DeleteWall(context.Background(), post.WallID)
+	require.NoError(t, err)
+
+	// Nothing is purgeable until the retention window has passed
+	past := pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true}
+	walls, err := testHub.ListPurgeableWalls(context.Background(), ListPurgeableWallsParams{DeletedAt: past, Limit: 1000})
+	require.NoError(t, err)
+	for _, w := range walls {
+		require.NotEqual(t, post.WallID, w.ID)
+	}
+
+	future := pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true}
+	posts, err := testHub.ListPurgeablePosts(context.Background(), ListPurgeablePostsParams{DeletedAt: future, Limit: 1000})
+	require.NoError(t, err)
+	var found bool
+	for _, p := range posts {
+		if p.ID == post.ID {
+			found = true
+		}
+	}
+	require.True(t, found, "posts on a deleted wall should be purgeable")
+
+	result, err := testHub.PurgeDeletedTx(context.Background(), []pgtype.UUID{post.WallID}, []pgtype.UUID{post.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Walls)
+	require.Equal(t, int64(1), result.Posts)
+	require.Equal(t, int64(1), result.Likes)
+
+	_, err = testHub.GetPost(context.Background(), post.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	_, err = testHub.GetWall(context.Background(), post.WallID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
@@ -13,74 +13,192 @@ import (
 type Querier interface {
 	AcceptFriendship(ctx context.Context, id pgtype.UUID) (Friendship, error)
 	AddLikesCount(ctx context.Context, id pgtype.UUID) (Post, error)
+	AddPostCommentsCount(ctx context.Context, arg AddPostCommentsCountParams) error
+	AddPostReactionCount(ctx context.Context, arg AddPostReactionCountParams) (Post, error)
+	AddWallFollowerCount(ctx context.Context, arg AddWallFollowerCountParams) error
+	AddWallModerator(ctx context.Context, arg AddWallModeratorParams) (WallModerator, error)
+	AddWallTag(ctx context.Context, arg AddWallTagParams) error
 	ArchiveWall(ctx context.Context, id pgtype.UUID) error
+	AssignUnsectionedPosts(ctx context.Context, arg AssignUnsectionedPostsParams) error
 	BlockFriendship(ctx context.Context, id pgtype.UUID) (Friendship, error)
+	ClaimUpload(ctx context.Context, arg ClaimUploadParams) (Upload, error)
+	ClearWallPins(ctx context.Context, userID pgtype.UUID) error
+	CompleteWallExport(ctx context.Context, arg CompleteWallExportParams) (WallExport, error)
+	CountOpenReportsForTarget(ctx context.Context, arg CountOpenReportsForTargetParams) (int64, error)
+	CountPinnedWalls(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUniqueWallVisitors(ctx context.Context, arg CountUniqueWallVisitorsParams) (int64, error)
 	CountUnreadNotifications(ctx context.Context, recipientID pgtype.UUID) (int64, error)
+	CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error)
 	CreateFriendship(ctx context.Context, arg CreateFriendshipParams) (Friendship, error)
 	CreateLike(ctx context.Context, arg CreateLikeParams) (Like, error)
+	CreateMention(ctx context.Context, arg CreateMentionParams) (Mention, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
+	CreatePostDrawing(ctx context.Context, arg CreatePostDrawingParams) (PostDrawing, error)
+	CreatePostMedia(ctx context.Context, arg CreatePostMediaParams) (PostMedium, error)
+	CreatePostRevision(ctx context.Context, arg CreatePostRevisionParams) (PostRevision, error)
+	CreateReport(ctx context.Context, arg CreateReportParams) (Report, error)
 	CreateTestWall(ctx context.Context, arg CreateTestWallParams) (Wall, error)
+	CreateUpload(ctx context.Context, arg CreateUploadParams) (Upload, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateWall(ctx context.Context, arg CreateWallParams) (Wall, error)
+	CreateWallExport(ctx context.Context, arg CreateWallExportParams) (WallExport, error)
+	CreateWallRevision(ctx context.Context, arg CreateWallRevisionParams) (WallRevision, error)
+	CreateWallSection(ctx context.Context, arg CreateWallSectionParams) (WallSection, error)
+	CreateWallSubscription(ctx context.Context, arg CreateWallSubscriptionParams) (WallSubscription, error)
+	DeleteCommentMentions(ctx context.Context, commentID pgtype.UUID) ([]pgtype.UUID, error)
 	DeleteFriendship(ctx context.Context, id pgtype.UUID) error
 	DeleteLike(ctx context.Context, arg DeleteLikeParams) error
+	DeleteLikesByPosts(ctx context.Context, postIds []pgtype.UUID) (int64, error)
 	DeleteNotification(ctx context.Context, id pgtype.UUID) error
 	DeletePost(ctx context.Context, id pgtype.UUID) error
+	DeletePostMentions(ctx context.Context, postID pgtype.UUID) ([]pgtype.UUID, error)
+	DeletePostRevisions(ctx context.Context, revisionIds []pgtype.UUID) (int64, error)
+	DeletePostsByWall(ctx context.Context, arg DeletePostsByWallParams) error
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
 	DeleteWall(ctx context.Context, id pgtype.UUID) error
+	DeleteWallExports(ctx context.Context, exportIds []pgtype.UUID) (int64, error)
+	DeleteWallSection(ctx context.Context, id pgtype.UUID) error
+	DeleteWallSubscription(ctx context.Context, arg DeleteWallSubscriptionParams) (int64, error)
+	DeleteWallTags(ctx context.Context, wallID pgtype.UUID) ([]pgtype.UUID, error)
 	DiscoverFriendsByMutuals(ctx context.Context, userID pgtype.UUID) ([]DiscoverFriendsByMutualsRow, error)
+	ExpireWallExports(ctx context.Context, arg ExpireWallExportsParams) error
+	FailWallExport(ctx context.Context, arg FailWallExportParams) error
 	FinishOnboarding(ctx context.Context, id pgtype.UUID) error
 	GetArchivedWalls(ctx context.Context, userID pgtype.UUID) ([]Wall, error)
+	GetComment(ctx context.Context, id pgtype.UUID) (Comment, error)
+	GetDefaultWallSection(ctx context.Context, wallID pgtype.UUID) (WallSection, error)
 	GetFriendship(ctx context.Context, id pgtype.UUID) (Friendship, error)
 	GetHighlightedPosts(ctx context.Context) ([]Post, error)
 	GetHighlightedPostsByWall(ctx context.Context, wallID pgtype.UUID) ([]Post, error)
 	GetLike(ctx context.Context, arg GetLikeParams) (Like, error)
+	GetLinkPreview(ctx context.Context, url string) (LinkPreview, error)
 	GetNotificationsByUser(ctx context.Context, recipientID pgtype.UUID) ([]Notification, error)
 	GetNumberOfFriends(ctx context.Context, fromUser pgtype.UUID) (int64, error)
 	GetNumberOfLikesByPost(ctx context.Context, postID pgtype.UUID) (int64, error)
 	GetNumberOfMutualFriends(ctx context.Context, arg GetNumberOfMutualFriendsParams) (int64, error)
 	GetNumberOfPendingFriendRequests(ctx context.Context, toUser pgtype.UUID) (int64, error)
 	GetPost(ctx context.Context, id pgtype.UUID) (Post, error)
+	GetPostDrawing(ctx context.Context, postID pgtype.UUID) (PostDrawing, error)
+	GetPostForUpdate(ctx context.Context, id pgtype.UUID) (Post, error)
+	GetReport(ctx context.Context, id pgtype.UUID) (Report, error)
+	GetUpload(ctx context.Context, key string) (Upload, error)
 	GetUser(ctx context.Context, id pgtype.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 	GetWall(ctx context.Context, id pgtype.UUID) (Wall, error)
+	GetWallExport(ctx context.Context, id pgtype.UUID) (WallExport, error)
+	GetWallForUpdate(ctx context.Context, id pgtype.UUID) (Wall, error)
+	GetWallRevision(ctx context.Context, arg GetWallRevisionParams) (WallRevision, error)
+	GetWallSection(ctx context.Context, arg GetWallSectionParams) (WallSection, error)
+	GetWallSubscription(ctx context.Context, arg GetWallSubscriptionParams) (WallSubscription, error)
+	GetWallTemplate(ctx context.Context, id pgtype.UUID) (WallTemplate, error)
+	HardDeletePosts(ctx context.Context, postIds []pgtype.UUID) (int64, error)
+	HardDeleteWalls(ctx context.Context, wallIds []pgtype.UUID) (int64, error)
+	HidePost(ctx context.Context, id pgtype.UUID) (int64, error)
+	HideWall(ctx context.Context, id pgtype.UUID) (int64, error)
 	HighlightPost(ctx context.Context, id pgtype.UUID) (Post, error)
+	IsWallModerator(ctx context.Context, arg IsWallModeratorParams) (bool, error)
+	ListAutoArchiveCandidates(ctx context.Context, warningDays int32) ([]ListAutoArchiveCandidatesRow, error)
+	ListClonablePostsByWall(ctx context.Context, arg ListClonablePostsByWallParams) ([]Post, error)
+	ListCommentReplies(ctx context.Context, arg ListCommentRepliesParams) ([]ListCommentRepliesRow, error)
+	ListCommentsByPost(ctx context.Context, arg ListCommentsByPostParams) ([]ListCommentsByPostRow, error)
+	ListDeletedPostsByUser(ctx context.Context, arg ListDeletedPostsByUserParams) ([]ListDeletedPostsByUserRow, error)
+	ListDeletedWallsByUser(ctx context.Context, arg ListDeletedWallsByUserParams) ([]Wall, error)
+	ListDueScheduledPosts(ctx context.Context, arg ListDueScheduledPostsParams) ([]Post, error)
+	ListFollowedWalls(ctx context.Context, userID pgtype.UUID) ([]ListFollowedWallsRow, error)
 	ListFriendsDetailsByStatus(ctx context.Context, arg ListFriendsDetailsByStatusParams) ([]ListFriendsDetailsByStatusRow, error)
 	ListFriendshipByUserPairs(ctx context.Context, arg ListFriendshipByUserPairsParams) (Friendship, error)
 	ListFriendships(ctx context.Context) ([]Friendship, error)
 	ListFriendshipsByUserId(ctx context.Context, fromUser pgtype.UUID) ([]Friendship, error)
 	ListFriendshipsByUserIdAndStatus(ctx context.Context, arg ListFriendshipsByUserIdAndStatusParams) ([]Friendship, error)
+	ListInactiveWallsByUser(ctx context.Context, arg ListInactiveWallsByUserParams) ([]ListInactiveWallsByUserRow, error)
 	ListLikes(ctx context.Context) ([]Like, error)
 	ListLikesByPost(ctx context.Context, postID pgtype.UUID) ([]Like, error)
 	ListLikesByUser(ctx context.Context, userID pgtype.UUID) ([]Like, error)
+	ListLinkPreviewsByURL(ctx context.Context, urls []string) ([]LinkPreview, error)
+	ListMediaURLsInUse(ctx context.Context, arg ListMediaURLsInUseParams) ([]string, error)
+	ListMentionableUsers(ctx context.Context, arg ListMentionableUsersParams) ([]ListMentionableUsersRow, error)
+	ListMentionsByComments(ctx context.Context, commentIds []pgtype.UUID) ([]ListMentionsByCommentsRow, error)
+	ListMentionsByPosts(ctx context.Context, postIds []pgtype.UUID) ([]ListMentionsByPostsRow, error)
 	ListMutualFriends(ctx context.Context, arg ListMutualFriendsParams) ([]ListMutualFriendsRow, error)
+	ListPendingPostsByWall(ctx context.Context, wallID pgtype.UUID) ([]ListPendingPostsByWallRow, error)
+	ListPostLikesByWall(ctx context.Context, wallID pgtype.UUID) ([]ListPostLikesByWallRow, error)
+	ListPostMediaByPosts(ctx context.Context, postIds []pgtype.UUID) ([]PostMedium, error)
+	ListPostRevisionMediaByPosts(ctx context.Context, postIds []pgtype.UUID) ([]string, error)
+	ListPostRevisions(ctx context.Context, arg ListPostRevisionsParams) ([]ListPostRevisionsRow, error)
 	ListPosts(ctx context.Context) ([]Post, error)
 	ListPostsByWall(ctx context.Context, wallID pgtype.UUID) ([]Post, error)
-	ListPostsByWallWithAuthorsDetails(ctx context.Context, wallID pgtype.UUID) ([]ListPostsByWallWithAuthorsDetailsRow, error)
+	ListPostsByWallWithAuthorsDetails(ctx context.Context, arg ListPostsByWallWithAuthorsDetailsParams) ([]ListPostsByWallWithAuthorsDetailsRow, error)
+	ListPrunablePostRevisions(ctx context.Context, arg ListPrunablePostRevisionsParams) ([]PostRevision, error)
+	ListPrunableWallExports(ctx context.Context, arg ListPrunableWallExportsParams) ([]WallExport, error)
+	ListPublicWallsByTag(ctx context.Context, arg ListPublicWallsByTagParams) ([]Wall, error)
+	ListPurgeablePosts(ctx context.Context, arg ListPurgeablePostsParams) ([]Post, error)
+	ListPurgeableWalls(ctx context.Context, arg ListPurgeableWallsParams) ([]Wall, error)
 	ListReceivedPendingFriendRequests(ctx context.Context, toUser pgtype.UUID) ([]ListReceivedPendingFriendRequestsRow, error)
+	ListReports(ctx context.Context, arg ListReportsParams) ([]ListReportsRow, error)
+	ListScheduledPostsByAuthor(ctx context.Context, arg ListScheduledPostsByAuthorParams) ([]Post, error)
 	ListSentPendingFriendRequests(ctx context.Context, fromUser pgtype.UUID) ([]ListSentPendingFriendRequestsRow, error)
+	ListTagsByWall(ctx context.Context, wallID pgtype.UUID) ([]Tag, error)
+	ListTopWallContributors(ctx context.Context, arg ListTopWallContributorsParams) ([]ListTopWallContributorsRow, error)
 	ListUsers(ctx context.Context) ([]User, error)
+	ListWallFollowersToNotify(ctx context.Context, arg ListWallFollowersToNotifyParams) ([]pgtype.UUID, error)
+	ListWallModerators(ctx context.Context, wallID pgtype.UUID) ([]ListWallModeratorsRow, error)
+	ListWallRevisions(ctx context.Context, arg ListWallRevisionsParams) ([]ListWallRevisionsRow, error)
+	ListWallSections(ctx context.Context, wallID pgtype.UUID) ([]ListWallSectionsRow, error)
+	ListWallTemplates(ctx context.Context) ([]WallTemplate, error)
+	ListWallViewsByDay(ctx context.Context, arg ListWallViewsByDayParams) ([]ListWallViewsByDayRow, error)
 	ListWalls(ctx context.Context) ([]Wall, error)
 	ListWallsByUser(ctx context.Context, userID pgtype.UUID) ([]Wall, error)
 	MarkAllNotificationsAsRead(ctx context.Context, recipientID pgtype.UUID) error
 	MarkNotificationAsRead(ctx context.Context, id pgtype.UUID) error
+	MarkWallArchiveWarned(ctx context.Context, id pgtype.UUID) error
+	MarkWallExportProcessing(ctx context.Context, id pgtype.UUID) error
+	ModeratePost(ctx context.Context, arg ModeratePostParams) (Post, error)
+	MoveSectionPosts(ctx context.Context, arg MoveSectionPostsParams) (int64, error)
 	PinUnpinWall(ctx context.Context, id pgtype.UUID) (Wall, error)
 	PrivatizeWall(ctx context.Context, id pgtype.UUID) (Wall, error)
 	PublicizeWall(ctx context.Context, id pgtype.UUID) (Wall, error)
+	PublishScheduledPost(ctx context.Context, arg PublishScheduledPostParams) (Post, error)
+	RecordWallViews(ctx context.Context, arg RecordWallViewsParams) error
+	RefreshTagUsageCounts(ctx context.Context, tagIds []pgtype.UUID) error
 	RejectFriendship(ctx context.Context, id pgtype.UUID) error
 	RemoveLikesCount(ctx context.Context, id pgtype.UUID) (Post, error)
+	RemoveWallModerator(ctx context.Context, arg RemoveWallModeratorParams) error
+	RenameWallSection(ctx context.Context, arg RenameWallSectionParams) (WallSection, error)
+	ReschedulePost(ctx context.Context, arg ReschedulePostParams) (Post, error)
+	ResolveReportsByTarget(ctx context.Context, arg ResolveReportsByTargetParams) ([]Report, error)
+	RestorePost(ctx context.Context, id pgtype.UUID) (Post, error)
+	RestorePostsByWall(ctx context.Context, arg RestorePostsByWallParams) error
+	RestoreWall(ctx context.Context, id pgtype.UUID) (Wall, error)
+	RevertWallToRevision(ctx context.Context, arg RevertWallToRevisionParams) (Wall, error)
+	SearchTags(ctx context.Context, arg SearchTagsParams) ([]Tag, error)
 	SearchUsersILike(ctx context.Context, searchTerm pgtype.Text) ([]SearchUsersILikeRow, error)
 	SearchUsersTrigram(ctx context.Context, searchTerm string) ([]SearchUsersTrigramRow, error)
+	SetLikeReaction(ctx context.Context, arg SetLikeReactionParams) (Like, error)
+	SetPostHidden(ctx context.Context, arg SetPostHiddenParams) (Post, error)
+	SetPostSection(ctx context.Context, arg SetPostSectionParams) (Post, error)
+	SetUserAutoArchiveDays(ctx context.Context, arg SetUserAutoArchiveDaysParams) (User, error)
+	SetUserSuspension(ctx context.Context, arg SetUserSuspensionParams) (User, error)
+	SetWallAllowAnonymous(ctx context.Context, arg SetWallAllowAnonymousParams) (Wall, error)
+	SetWallHidden(ctx context.Context, arg SetWallHiddenParams) (Wall, error)
+	SetWallModeration(ctx context.Context, arg SetWallModerationParams) (Wall, error)
+	SetWallPinOrder(ctx context.Context, arg SetWallPinOrderParams) (Wall, error)
+	SetWallSectionPosition(ctx context.Context, arg SetWallSectionPositionParams) (WallSection, error)
+	SetWallSubscriptionMuted(ctx context.Context, arg SetWallSubscriptionMutedParams) (WallSubscription, error)
+	SoftDeleteComment(ctx context.Context, id pgtype.UUID) (int64, error)
 	UnarchiveWall(ctx context.Context, id pgtype.UUID) error
 	UnhighlightPost(ctx context.Context, id pgtype.UUID) (Post, error)
+	UpdateCommentBody(ctx context.Context, arg UpdateCommentBodyParams) (Comment, error)
 	UpdateFriendship(ctx context.Context, arg UpdateFriendshipParams) (Friendship, error)
 	UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error)
+	UpdatePostLayout(ctx context.Context, arg UpdatePostLayoutParams) (Post, error)
 	UpdateProfile(ctx context.Context, arg UpdateProfileParams) (User, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserNew(ctx context.Context, arg UpdateUserNewParams) (User, error)
 	UpdateWall(ctx context.Context, arg UpdateWallParams) (Wall, error)
+	UpsertLinkPreview(ctx context.Context, arg UpsertLinkPreviewParams) (LinkPreview, error)
+	UpsertTag(ctx context.Context, name string) (Tag, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReportTxHidesPastThreshold(t *testing.T) {
+	post := createRandomPost(t)
+
+	for i := 0; i < 2; i++ {
+		reporter := createRandomUser(t)
+		result, err := testHub.CreateReportTx(context.Background(), CreateReportParams{
+			ReporterID: reporter.ID,
+			TargetType: ReportTargetTypePost,
+			TargetID:   post.ID,
+			Reason:     "spam",
+		}, 2)
+		require.NoError(t, err)
+		require.Equal(t, ReportStatusOpen, result.Report.Status)
+		// Only the report that reaches the threshold hides the post
+		require.Equal(t, i == 1, result.Hidden)
+	}
+
+	hidden, err := testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.True(t, hidden.IsHidden)
+
+	count, err := testHub.CountOpenReportsForTarget(context.Background(), CountOpenReportsForTargetParams{
+		TargetType: ReportTargetTypePost,
+		TargetID:   post.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestCreateReportTxDuplicate(t *testing.T) {
+	wall := createRandomWall(t)
+	reporter := createRandomUser(t)
+
+	arg := CreateReportParams{
+		ReporterID: reporter.ID,
+		TargetType: ReportTargetTypeWall,
+		TargetID:   wall.ID,
+		Reason:     "harassment",
+	}
+
+	_, err := testHub.CreateReportTx(context.Background(), arg, 0)
+	require.NoError(t, err)
+
+	_, err = testHub.CreateReportTx(context.Background(), arg, 0)
+	require.Error(t, err)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestResolveReportsByTarget(t *testing.T) {
+	post := createRandomPost(t)
+	moderator := createRandomUser(t)
+
+	for i := 0; i < 2; i++ {
+		_, err := testHub.CreateReportTx(context.Background(), CreateReportParams{
+			ReporterID: createRandomUser(t).ID,
+			TargetType: ReportTargetTypePost,
+			TargetID:   post.ID,
+			Reason:     "other",
+		}, 0)
+		require.NoError(t, err)
+	}
+
+	resolved, err := testHub.ResolveReportsByTarget(context.Background(), ResolveReportsByTargetParams{
+		TargetType:     ReportTargetTypePost,
+		TargetID:       post.ID,
+		Status:         ReportStatusDismissed,
+		ResolvedBy:     moderator.ID,
+		ResolutionNote: "Not against the rules",
+	})
+	require.NoError(t, err)
+	require.Len(t, resolved, 2)
+	for _, report := range resolved {
+		require.Equal(t, ReportStatusDismissed, report.Status)
+		require.Equal(t, moderator.ID, report.ResolvedBy)
+		require.True(t, report.ResolvedAt.Valid)
+	}
+
+	// Nothing is left open, so the post can be reported again
+	count, err := testHub.CountOpenReportsForTarget(context.Background(), CountOpenReportsForTargetParams{
+		TargetType: ReportTargetTypePost,
+		TargetID:   post.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
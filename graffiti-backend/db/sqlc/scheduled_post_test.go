@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createScheduledPost(t *testing.T, publishAt time.Time) Post {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+
+	post, err := testHub.CreatePost(context.Background(), CreatePostParams{
+		WallID:    wall.ID,
+		Author:    user.ID,
+		PostType:  NullPostType{PostType: PostTypeText, Valid: true},
+		Caption:   pgtype.Text{String: "See you at midnight", Valid: true},
+		Scale:     1,
+		Status:    PostStatusScheduled,
+		PublishAt: pgtype.Timestamp{Time: publishAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PostStatusScheduled, post.Status)
+	require.True(t, post.PublishAt.Valid)
+
+	return post
+}
+
+func TestPublishScheduledPost(t *testing.T) {
+	due := createScheduledPost(t, time.Now().Add(-time.Minute))
+	later := createScheduledPost(t, time.Now().Add(time.Hour))
+
+	posts, err := testHub.ListDueScheduledPosts(context.Background(), ListDueScheduledPostsParams{
+		PublishAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+
+	ids := make(map[pgtype.UUID]bool, len(posts))
+	for _, post := range posts {
+		ids[post.ID] = true
+	}
+	require.True(t, ids[due.ID])
+	require.False(t, ids[later.ID])
+
+	published, err := testHub.PublishScheduledPost(context.Background(), PublishScheduledPostParams{
+		ID:     due.ID,
+		Status: PostStatusApproved,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PostStatusApproved, published.Status)
+	require.False(t, published.CreatedAt.Time.Before(due.CreatedAt.Time))
+
+	// A post is only published once
+	_, err = testHub.PublishScheduledPost(context.Background(), PublishScheduledPostParams{
+		ID:     due.ID,
+		Status: PostStatusApproved,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestListAndReschedulePosts(t *testing.T) {
+	post := createScheduledPost(t, time.Now().Add(time.Hour))
+
+	posts, err := testHub.ListScheduledPostsByAuthor(context.Background(), ListScheduledPostsByAuthorParams{
+		Author: post.Author,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, post.ID, posts[0].ID)
+
+	publishAt := time.Now().Add(48 * time.Hour)
+	rescheduled, err := testHub.ReschedulePost(context.Background(), ReschedulePostParams{
+		ID:        post.ID,
+		PublishAt: pgtype.Timestamp{Time: publishAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, publishAt, rescheduled.PublishAt.Time, time.Second)
+
+	// Published posts can't be moved
+	_, err = testHub.ReschedulePost(context.Background(), ReschedulePostParams{
+		ID:        createRandomPost(t).ID,
+		PublishAt: pgtype.Timestamp{Time: publishAt, Valid: true},
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestListDueScheduledPostsSkipsDeletedWalls(t *testing.T) {
+	post := createScheduledPost(t, time.Now().Add(-time.Minute))
+
+	// Only the wall itself, its posts are left as they were
+	err := testHub.DeleteWall(context.Background(), post.WallID)
+	require.NoError(t, err)
+
+	posts, err := testHub.ListDueScheduledPosts(context.Background(), ListDueScheduledPostsParams{
+		PublishAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	for _, due := range posts {
+		require.NotEqual(t, post.ID, due.ID)
+	}
+}
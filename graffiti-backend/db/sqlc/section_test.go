@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func createRandomPostOnWall(t *testing.T, wall Wall, sectionID pgtype.UUID) Post {
+	post, err := testHub.CreatePost(context.Background(), CreatePostParams{
+		WallID:    wall.ID,
+		Author:    wall.UserID,
+		MediaUrl:  pgtype.Text{String: "https://example.com/media/" + util.RandomString(10) + ".jpg", Valid: true},
+		PostType:  NullPostType{PostType: PostTypeMedia, Valid: true},
+		Scale:     1,
+		Status:    PostStatusApproved,
+		SectionID: sectionID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sectionID, post.SectionID)
+	return post
+}
+
+func TestCreateWallSectionTx(t *testing.T) {
+	wall := createRandomWall(t)
+	post := createRandomPostOnWall(t, wall, pgtype.UUID{})
+
+	section, err := testHub.CreateWallSectionTx(context.Background(), wall.ID, "Wishes")
+	require.NoError(t, err)
+	require.Equal(t, "Wishes", section.Name)
+	require.False(t, section.IsDefault)
+
+	// The first section brings the default one, which takes the existing posts
+	defaultSection, err := testHub.GetDefaultWallSection(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Equal(t, DefaultWallSectionName, defaultSection.Name)
+	require.Less(t, defaultSection.Position, section.Position)
+
+	post, err = testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Equal(t, defaultSection.ID, post.SectionID)
+
+	_, err = testHub.CreateWallSectionTx(context.Background(), wall.ID, "Memories")
+	require.NoError(t, err)
+
+	sections, err := testHub.ListWallSections(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Len(t, sections, 3)
+	require.Equal(t, defaultSection.ID, sections[0].ID)
+	require.Equal(t, int64(1), sections[0].PostCount)
+}
+
+func TestReorderWallSectionsTx(t *testing.T) {
+	wall := createRandomWall(t)
+
+	wishes, err := testHub.CreateWallSectionTx(context.Background(), wall.ID, "Wishes")
+	require.NoError(t, err)
+	defaultSection, err := testHub.GetDefaultWallSection(context.Background(), wall.ID)
+	require.NoError(t, err)
+
+	_, err = testHub.ReorderWallSectionsTx(context.Background(), wall.ID, []pgtype.UUID{wishes.ID})
+	require.ErrorIs(t, err, ErrSectionOrderMismatch)
+
+	sections, err := testHub.ReorderWallSectionsTx(context.Background(), wall.ID, []pgtype.UUID{wishes.ID, defaultSection.ID})
+	require.NoError(t, err)
+	require.Len(t, sections, 2)
+
+	listed, err := testHub.ListWallSections(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Equal(t, wishes.ID, listed[0].ID)
+	require.Equal(t, defaultSection.ID, listed[1].ID)
+}
+
+func TestDeleteWallSectionTx(t *testing.T) {
+	wall := createRandomWall(t)
+
+	wishes, err := testHub.CreateWallSectionTx(context.Background(), wall.ID, "Wishes")
+	require.NoError(t, err)
+	defaultSection, err := testHub.GetDefaultWallSection(context.Background(), wall.ID)
+	require.NoError(t, err)
+
+	post := createRandomPostOnWall(t, wall, wishes.ID)
+
+	_, err = testHub.DeleteWallSectionTx(context.Background(), wall.ID, defaultSection.ID)
+	require.ErrorIs(t, err, ErrDefaultSection)
+
+	result, err := testHub.DeleteWallSectionTx(context.Background(), wall.ID, wishes.ID)
+	require.NoError(t, err)
+	require.Equal(t, defaultSection.ID, result.DefaultSection.ID)
+	require.Equal(t, int64(1), result.PostsMoved)
+
+	post, err = testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Equal(t, defaultSection.ID, post.SectionID)
+
+	_, err = testHub.DeleteWallSectionTx(context.Background(), wall.ID, wishes.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
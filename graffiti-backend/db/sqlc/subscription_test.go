@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowAndUnfollowWallTx(t *testing.T) {
+	wall := createRandomWall(t)
+	follower := createRandomUser(t)
+
+	subscription, err := testHub.FollowWallTx(context.Background(), wall.ID, follower.ID)
+	require.NoError(t, err)
+	require.Equal(t, wall.ID, subscription.WallID)
+	require.Equal(t, follower.ID, subscription.UserID)
+	require.False(t, subscription.Muted)
+
+	// Following again does not count twice
+	_, err = testHub.FollowWallTx(context.Background(), wall.ID, follower.ID)
+	require.NoError(t, err)
+
+	updated, err := testHub.GetWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), updated.FollowerCount)
+
+	err = testHub.UnfollowWallTx(context.Background(), wall.ID, follower.ID)
+	require.NoError(t, err)
+
+	updated, err = testHub.GetWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(0), updated.FollowerCount)
+
+	err = testHub.UnfollowWallTx(context.Background(), wall.ID, follower.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestListWallFollowersToNotify(t *testing.T) {
+	wall := createRandomWall(t)
+	follower := createRandomUser(t)
+	mutedFollower := createRandomUser(t)
+	author := createRandomUser(t)
+
+	for _, user := range []User{follower, mutedFollower, author} {
+		_, err := testHub.FollowWallTx(context.Background(), wall.ID, user.ID)
+		require.NoError(t, err)
+	}
+
+	_, err := testHub.SetWallSubscriptionMuted(context.Background(), SetWallSubscriptionMutedParams{
+		WallID: wall.ID,
+		UserID: mutedFollower.ID,
+		Muted:  true,
+	})
+	require.NoError(t, err)
+
+	followers, err := testHub.ListWallFollowersToNotify(context.Background(), ListWallFollowersToNotifyParams{
+		WallID: wall.ID,
+		UserID: author.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []pgtype.UUID{follower.ID}, followers)
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func randomTagName() string {
+	return "tag-" + strings.ToLower(util.RandomString(10))
+}
+
+func TestSetWallTagsTx(t *testing.T) {
+	wall := createRandomWall(t)
+	wall, err := testHub.PublicizeWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+
+	first := randomTagName()
+	second := randomTagName()
+
+	tags, err := testHub.SetWallTagsTx(context.Background(), wall.ID, []string{first, second})
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	for _, tag := range tags {
+		require.Equal(t, int32(1), tag.UsageCount)
+	}
+
+	walls, err := testHub.ListPublicWallsByTag(context.Background(), ListPublicWallsByTagParams{
+		Name:  first,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, walls, 1)
+	require.Equal(t, wall.ID, walls[0].ID)
+
+	// Replacing the tags drops the old ones from the wall and their counts
+	tags, err = testHub.SetWallTagsTx(context.Background(), wall.ID, []string{second})
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, second, tags[0].Name)
+
+	suggestions, err := testHub.SearchTags(context.Background(), SearchTagsParams{
+		Prefix:     first,
+		MaxResults: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+
+	suggestions, err = testHub.SearchTags(context.Background(), SearchTagsParams{
+		Prefix:     second[:8],
+		MaxResults: 10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+
+	tags, err = testHub.SetWallTagsTx(context.Background(), wall.ID, nil)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func TestListPublicWallsByTagSkipsPrivateWalls(t *testing.T) {
+	wall := createRandomWall(t)
+	name := randomTagName()
+
+	_, err := testHub.SetWallTagsTx(context.Background(), wall.ID, []string{name})
+	require.NoError(t, err)
+
+	walls, err := testHub.ListPublicWallsByTag(context.Background(), ListPublicWallsByTagParams{
+		Name:  name,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, walls)
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func TestListWallTemplates(t *testing.T) {
+	templates, err := testHub.ListWallTemplates(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, templates)
+
+	template, err := testHub.GetWallTemplate(context.Background(), templates[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, templates[0].Slug, template.Slug)
+}
+
+func TestCreateWallTx(t *testing.T) {
+	user := createRandomUser(t)
+	tag := randomTagName()
+
+	result, err := testHub.CreateWallTx(context.Background(), CreateWallTxParams{
+		Wall: CreateTestWallParams{
+			UserID:   user.ID,
+			Title:    "Wall Title" + util.RandomString(10),
+			IsPublic: pgtype.Bool{Bool: true, Valid: true},
+		},
+		ModerationEnabled: true,
+		Tags:              []string{tag},
+		Posts: []CreatePostParams{{
+			Author:   user.ID,
+			MediaUrl: pgtype.Text{String: "https://example.com/media/" + util.RandomString(10) + ".jpg", Valid: true},
+			PostType: NullPostType{PostType: PostTypeMedia, Valid: true},
+			Scale:    1,
+			Status:   PostStatusApproved,
+		}},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Wall.ModerationEnabled.Bool)
+	require.Len(t, result.Tags, 1)
+	require.Equal(t, tag, result.Tags[0].Name)
+	require.Len(t, result.Posts, 1)
+	require.Equal(t, result.Wall.ID, result.Posts[0].WallID)
+
+	posts, err := testHub.ListClonablePostsByWall(context.Background(), ListClonablePostsByWallParams{
+		WallID: result.Wall.ID,
+		Author: user.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+
+	// Posts hidden by moderators aren't cloned
+	_, err = testHub.HidePost(context.Background(), result.Posts[0].ID)
+	require.NoError(t, err)
+
+	posts, err = testHub.ListClonablePostsByWall(context.Background(), ListClonablePostsByWallParams{
+		WallID: result.Wall.ID,
+		Author: user.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, posts)
+}
+
+func TestCreateWallTxCopiesSectionsAlbumsAndDrawings(t *testing.T) {
+	source := createRandomWall(t)
+
+	section, err := testHub.CreateWallSectionTx(context.Background(), source.ID, "Wishes")
+	require.NoError(t, err)
+	sections, err := testHub.ListWallSections(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Len(t, sections, 2)
+
+	copied := make([]WallSection, 0, len(sections))
+	for _, s := range sections {
+		copied = append(copied, WallSection{ID: s.ID, Name: s.Name, IsDefault: s.IsDefault})
+	}
+
+	strokes := []byte(`[{"color":"#000000","width":4,"points":[[0,0],[10,10]]}]`)
+	result, err := testHub.CreateWallTx(context.Background(), CreateWallTxParams{
+		Wall: CreateTestWallParams{
+			UserID: source.UserID,
+			Title:  "Wall Title" + util.RandomString(10),
+		},
+		Sections: copied,
+		Posts: []CreatePostParams{
+			{
+				Author:    source.UserID,
+				MediaUrl:  pgtype.Text{String: "https://example.com/media/cover.jpg", Valid: true},
+				PostType:  NullPostType{PostType: PostTypeAlbum, Valid: true},
+				Scale:     1,
+				Status:    PostStatusApproved,
+				SectionID: section.ID,
+			},
+			{
+				Author:   source.UserID,
+				MediaUrl: pgtype.Text{String: "https://example.com/media/preview.png", Valid: true},
+				PostType: NullPostType{PostType: PostTypeDrawing, Valid: true},
+				Scale:    1,
+				Status:   PostStatusApproved,
+			},
+		},
+		PostMedia: map[int][]CreatePostMediaParams{
+			0: {
+				{MediaUrl: "https://example.com/media/cover.jpg", Width: 640, Height: 480},
+				{MediaUrl: "https://example.com/media/second.jpg", Width: 480, Height: 640, AltText: "cake"},
+			},
+		},
+		PostDrawings: map[int]CreatePostDrawingParams{
+			1: {Width: 800, Height: 600, Strokes: strokes},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Posts, 2)
+
+	newSections, err := testHub.ListWallSections(context.Background(), result.Wall.ID)
+	require.NoError(t, err)
+	require.Len(t, newSections, 2)
+	require.True(t, newSections[0].IsDefault)
+	require.Equal(t, "Wishes", newSections[1].Name)
+	require.NotEqual(t, section.ID, newSections[1].ID)
+	require.Equal(t, newSections[1].ID, result.Posts[0].SectionID)
+
+	media, err := testHub.ListPostMediaByPosts(context.Background(), []pgtype.UUID{result.Posts[0].ID})
+	require.NoError(t, err)
+	require.Len(t, media, 2)
+	require.Equal(t, int32(1), media[1].Position)
+	require.Equal(t, "cake", media[1].AltText)
+
+	drawing, err := testHub.GetPostDrawing(context.Background(), result.Posts[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(800), drawing.Width)
+	require.JSONEq(t, string(strokes), string(drawing.Strokes))
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/vittotedja/graffiti/graffiti-backend/util"
+)
+
+func createRandomUpload(t *testing.T, userID pgtype.UUID) Upload {
+	arg := CreateUploadParams{
+		Key:         "uploads/" + util.RandomString(12) + ".jpg",
+		UserID:      userID,
+		ContentType: "image/jpeg",
+		FileSize:    2048,
+	}
+
+	upload, err := testHub.CreateUpload(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Key, upload.Key)
+	require.Equal(t, arg.FileSize, upload.FileSize)
+	require.False(t, upload.ClaimedAt.Valid)
+
+	return upload
+}
+
+func TestCreatePostTxClaimsUpload(t *testing.T) {
+	wall := createRandomWall(t)
+	user := createRandomUser(t)
+	upload := createRandomUpload(t, user.ID)
+
+	arg := CreatePostParams{
+		WallID:   wall.ID,
+		Author:   user.ID,
+		MediaUrl: pgtype.Text{String: "https://cdn.example.com/" + upload.Key, Valid: true},
+		PostType: NullPostType{PostType: PostTypeMedia, Valid: true},
+		Scale:    1,
+		Status:   PostStatusApproved,
+	}
+
+	post, err := testHub.CreatePostTx(context.Background(), arg, upload.Key)
+	require.NoError(t, err)
+
+	claimed, err := testHub.GetUpload(context.Background(), upload.Key)
+	require.NoError(t, err)
+	require.Equal(t, post.ID, claimed.PostID)
+	require.True(t, claimed.ClaimedAt.Valid)
+
+	// A second post can't reuse the upload, and isn't created
+	_, err = testHub.CreatePostTx(context.Background(), arg, upload.Key)
+	require.ErrorIs(t, err, ErrUploadClaimed)
+
+	posts, err := testHub.ListPostsByWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+}
@@ -19,7 +19,7 @@ INSERT INTO users(
  hashed_password 
 ) VALUES (
   $1, $2, $3, $4
-) RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at
+) RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until
 `
 
 type CreateUserParams struct {
@@ -50,6 +50,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
@@ -78,7 +81,7 @@ func (q *Queries) FinishOnboarding(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at FROM users
+SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -98,12 +101,15 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at FROM users
+SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -123,12 +129,15 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at FROM users
+SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -148,12 +157,15 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at FROM users
+SELECT id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until FROM users
 ORDER BY id
 `
 
@@ -179,6 +191,9 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.OnboardingAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AutoArchiveDays,
+			&i.Role,
+			&i.SuspendedUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -294,7 +309,7 @@ SET
     bio = COALESCE($3, bio),
     background_image = COALESCE($4, background_image)
 WHERE id = $1
-RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at
+RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until
 `
 
 type UpdateProfileParams struct {
@@ -325,6 +340,9 @@ func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (U
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
@@ -337,7 +355,7 @@ SET
     email = COALESCE($4, email),
     hashed_password = COALESCE($5, hashed_password)
 WHERE id = $1
-RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at
+RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until
 `
 
 type UpdateUserParams struct {
@@ -370,6 +388,9 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
@@ -385,7 +406,7 @@ SET
     bio = COALESCE($7, bio),
     background_image = COALESCE($8, background_image)
 WHERE id = $1
-RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at
+RETURNING id, username, fullname, email, hashed_password, profile_picture, bio, has_onboarded, background_image, onboarding_at, created_at, updated_at, auto_archive_days, role, suspended_until
 `
 
 type UpdateUserNewParams struct {
@@ -424,6 +445,9 @@ func (q *Queries) UpdateUserNew(ctx context.Context, arg UpdateUserNewParams) (U
 		&i.OnboardingAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchiveDays,
+		&i.Role,
+		&i.SuspendedUntil,
 	)
 	return i, err
 }
@@ -13,16 +13,47 @@ import (
 
 const archiveWall = `-- name: ArchiveWall :exec
 UPDATE walls
-    set is_archived = true
+    set is_archived = true,
+    is_pinned = false,
+    pin_order = NULL
 WHERE id = $1
-RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
+// Archived walls lose their pin, so unarchiving can't take a user past the limit
 func (q *Queries) ArchiveWall(ctx context.Context, id pgtype.UUID) error {
 	_, err := q.db.Exec(ctx, archiveWall, id)
 	return err
 }
 
+const clearWallPins = `-- name: ClearWallPins :exec
+UPDATE walls
+    set is_pinned = false,
+    pin_order = NULL
+WHERE user_id = $1
+AND (is_pinned = true OR pin_order IS NOT NULL);
+`
+
+func (q *Queries) ClearWallPins(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, clearWallPins, userID)
+	return err
+}
+
+const countPinnedWalls = `-- name: CountPinnedWalls :one
+SELECT count(*) FROM walls
+WHERE user_id = $1
+AND is_pinned = true
+AND is_deleted = false
+AND is_archived = false;
+`
+
+func (q *Queries) CountPinnedWalls(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countPinnedWalls, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTestWall = `-- name: CreateTestWall :one
 INSERT INTO walls(
     user_id,
@@ -32,7 +63,7 @@ INSERT INTO walls(
     background_image
 ) VALUES (
     $1, $2, $3, $4, $5
-) RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+) RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 type CreateTestWallParams struct {
@@ -65,6 +96,14 @@ func (q *Queries) CreateTestWall(ctx context.Context, arg CreateTestWallParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
@@ -77,7 +116,7 @@ INSERT INTO walls(
     background_image
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+) RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 type CreateWallParams struct {
@@ -108,23 +147,35 @@ func (q *Queries) CreateWall(ctx context.Context, arg CreateWallParams) (Wall, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
 
 const deleteWall = `-- name: DeleteWall :exec
 UPDATE walls
-    set is_deleted = true
+    set is_deleted = true,
+    deleted_at = COALESCE(deleted_at, now()),
+    is_pinned = false,
+    pin_order = NULL
 WHERE id = $1
 `
 
+// Like archiving, deleting a wall unpins it
 func (q *Queries) DeleteWall(ctx context.Context, id pgtype.UUID) error {
 	_, err := q.db.Exec(ctx, deleteWall, id)
 	return err
 }
 
 const getArchivedWalls = `-- name: GetArchivedWalls :many
-SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned FROM walls
+SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous FROM walls
 WHERE user_id = $1
 AND is_deleted = false
 AND is_archived = true
@@ -153,6 +204,14 @@ func (q *Queries) GetArchivedWalls(ctx context.Context, userID pgtype.UUID) ([]W
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsPinned,
+			&i.ModerationEnabled,
+			&i.DeletedAt,
+			&i.FollowerCount,
+			&i.PinOrder,
+			&i.ArchiveWarnedAt,
+			&i.UnarchivedAt,
+			&i.IsHidden,
+			&i.AllowAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -165,7 +224,7 @@ func (q *Queries) GetArchivedWalls(ctx context.Context, userID pgtype.UUID) ([]W
 }
 
 const getWall = `-- name: GetWall :one
-SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned FROM walls
+SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous FROM walls
 WHERE id = $1 LIMIT 1
 `
 
@@ -185,12 +244,74 @@ func (q *Queries) GetWall(ctx context.Context, id pgtype.UUID) (Wall, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
 
+const listDeletedWallsByUser = `-- name: ListDeletedWallsByUser :many
+SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous FROM walls
+WHERE user_id = $1
+AND is_deleted = true
+AND deleted_at >= $2
+ORDER BY deleted_at DESC;
+`
+
+type ListDeletedWallsByUserParams struct {
+	UserID    pgtype.UUID
+	DeletedAt pgtype.Timestamp
+}
+
+func (q *Queries) ListDeletedWallsByUser(ctx context.Context, arg ListDeletedWallsByUserParams) ([]Wall, error) {
+	rows, err := q.db.Query(ctx, listDeletedWallsByUser, arg.UserID, arg.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Wall
+	for rows.Next() {
+		var i Wall
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.BackgroundImage,
+			&i.IsPublic,
+			&i.IsArchived,
+			&i.IsDeleted,
+			&i.PopularityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsPinned,
+			&i.ModerationEnabled,
+			&i.DeletedAt,
+			&i.FollowerCount,
+			&i.PinOrder,
+			&i.ArchiveWarnedAt,
+			&i.UnarchivedAt,
+			&i.IsHidden,
+			&i.AllowAnonymous,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWalls = `-- name: ListWalls :many
-SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned FROM walls
+SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous FROM walls
 ORDER BY id DESC
 `
 
@@ -216,6 +337,14 @@ func (q *Queries) ListWalls(ctx context.Context) ([]Wall, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsPinned,
+			&i.ModerationEnabled,
+			&i.DeletedAt,
+			&i.FollowerCount,
+			&i.PinOrder,
+			&i.ArchiveWarnedAt,
+			&i.UnarchivedAt,
+			&i.IsHidden,
+			&i.AllowAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -228,11 +357,11 @@ func (q *Queries) ListWalls(ctx context.Context) ([]Wall, error) {
 }
 
 const listWallsByUser = `-- name: ListWallsByUser :many
-SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned FROM walls
+SELECT id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous FROM walls
 WHERE user_id = $1
 AND is_deleted = false
 AND is_archived = false
-ORDER BY created_at DESC
+ORDER BY pin_order ASC NULLS LAST, created_at DESC
 `
 
 func (q *Queries) ListWallsByUser(ctx context.Context, userID pgtype.UUID) ([]Wall, error) {
@@ -257,6 +386,14 @@ func (q *Queries) ListWallsByUser(ctx context.Context, userID pgtype.UUID) ([]Wa
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsPinned,
+			&i.ModerationEnabled,
+			&i.DeletedAt,
+			&i.FollowerCount,
+			&i.PinOrder,
+			&i.ArchiveWarnedAt,
+			&i.UnarchivedAt,
+			&i.IsHidden,
+			&i.AllowAnonymous,
 		); err != nil {
 			return nil, err
 		}
@@ -270,9 +407,13 @@ func (q *Queries) ListWallsByUser(ctx context.Context, userID pgtype.UUID) ([]Wa
 
 const pinUnpinWall = `-- name: PinUnpinWall :one
 UPDATE walls
-    set is_pinned = not is_pinned
+    set is_pinned = not is_pinned,
+    pin_order = CASE WHEN is_pinned THEN NULL ELSE (
+        SELECT COALESCE(MAX(w.pin_order), 0) + 1 FROM walls w
+        WHERE w.user_id = walls.user_id
+    ) END
 WHERE id = $1
-RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 func (q *Queries) PinUnpinWall(ctx context.Context, id pgtype.UUID) (Wall, error) {
@@ -291,6 +432,14 @@ func (q *Queries) PinUnpinWall(ctx context.Context, id pgtype.UUID) (Wall, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
@@ -299,7 +448,7 @@ const privatizeWall = `-- name: PrivatizeWall :one
 UPDATE walls
     set is_public = false
 WHERE id = $1
-RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 func (q *Queries) PrivatizeWall(ctx context.Context, id pgtype.UUID) (Wall, error) {
@@ -318,6 +467,14 @@ func (q *Queries) PrivatizeWall(ctx context.Context, id pgtype.UUID) (Wall, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
@@ -326,7 +483,7 @@ const publicizeWall = `-- name: PublicizeWall :one
 UPDATE walls
     set is_public = true
 WHERE id = $1
-RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 func (q *Queries) PublicizeWall(ctx context.Context, id pgtype.UUID) (Wall, error) {
@@ -345,15 +502,141 @@ func (q *Queries) PublicizeWall(ctx context.Context, id pgtype.UUID) (Wall, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
+	)
+	return i, err
+}
+
+const restoreWall = `-- name: RestoreWall :one
+UPDATE walls
+    set is_deleted = false,
+    deleted_at = NULL
+WHERE id = $1 AND is_deleted = true
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous;
+`
+
+func (q *Queries) RestoreWall(ctx context.Context, id pgtype.UUID) (Wall, error) {
+	row := q.db.QueryRow(ctx, restoreWall, id)
+	var i Wall
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.BackgroundImage,
+		&i.IsPublic,
+		&i.IsArchived,
+		&i.IsDeleted,
+		&i.PopularityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
+	)
+	return i, err
+}
+
+const setWallAllowAnonymous = `-- name: SetWallAllowAnonymous :one
+UPDATE walls
+SET allow_anonymous = $2
+WHERE id = $1
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
+`
+
+type SetWallAllowAnonymousParams struct {
+	ID             pgtype.UUID
+	AllowAnonymous bool
+}
+
+func (q *Queries) SetWallAllowAnonymous(ctx context.Context, arg SetWallAllowAnonymousParams) (Wall, error) {
+	row := q.db.QueryRow(ctx, setWallAllowAnonymous, arg.ID, arg.AllowAnonymous)
+	var i Wall
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.BackgroundImage,
+		&i.IsPublic,
+		&i.IsArchived,
+		&i.IsDeleted,
+		&i.PopularityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
+	)
+	return i, err
+}
+
+const setWallPinOrder = `-- name: SetWallPinOrder :one
+UPDATE walls
+    set is_pinned = true,
+    pin_order = $2
+WHERE id = $1
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous;
+`
+
+type SetWallPinOrderParams struct {
+	ID       pgtype.UUID
+	PinOrder pgtype.Int4
+}
+
+func (q *Queries) SetWallPinOrder(ctx context.Context, arg SetWallPinOrderParams) (Wall, error) {
+	row := q.db.QueryRow(ctx, setWallPinOrder, arg.ID, arg.PinOrder)
+	var i Wall
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.BackgroundImage,
+		&i.IsPublic,
+		&i.IsArchived,
+		&i.IsDeleted,
+		&i.PopularityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
 
 const unarchiveWall = `-- name: UnarchiveWall :exec
 UPDATE walls
-    set is_archived = false
+    set is_archived = false,
+    unarchived_at = now()
 WHERE id = $1
-RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 func (q *Queries) UnarchiveWall(ctx context.Context, id pgtype.UUID) error {
@@ -369,7 +652,7 @@ SET
     background_image = COALESCE($4, background_image),
     is_public = COALESCE($5, is_public)
 WHERE id = $1
-RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned
+RETURNING id, user_id, title, description, background_image, is_public, is_archived, is_deleted, popularity_score, created_at, updated_at, is_pinned, moderation_enabled, deleted_at, follower_count, pin_order, archive_warned_at, unarchived_at, is_hidden, allow_anonymous
 `
 
 type UpdateWallParams struct {
@@ -402,6 +685,14 @@ func (q *Queries) UpdateWall(ctx context.Context, arg UpdateWallParams) (Wall, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsPinned,
+		&i.ModerationEnabled,
+		&i.DeletedAt,
+		&i.FollowerCount,
+		&i.PinOrder,
+		&i.ArchiveWarnedAt,
+		&i.UnarchivedAt,
+		&i.IsHidden,
+		&i.AllowAnonymous,
 	)
 	return i, err
 }
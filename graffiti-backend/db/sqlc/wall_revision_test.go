@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateWallTx(t *testing.T) {
+	wall := createRandomWall(t)
+
+	tags := []string{randomTagName()}
+	result, err := testHub.UpdateWallTx(context.Background(), UpdateWallTxParams{
+		Wall: UpdateWallParams{
+			ID:              wall.ID,
+			Title:           wall.Title + " updated",
+			Description:     wall.Description,
+			BackgroundImage: wall.BackgroundImage,
+			IsPublic:        wall.IsPublic,
+		},
+		ActorID: wall.UserID,
+		Tags:    &tags,
+	})
+	require.NoError(t, err)
+	updated := result.Wall
+	require.Equal(t, wall.Title+" updated", updated.Title)
+	require.True(t, updated.UpdatedAt.Time.After(wall.UpdatedAt.Time))
+	require.Len(t, result.Tags, 1)
+	require.Equal(t, tags[0], result.Tags[0].Name)
+
+	revisions, err := testHub.ListWallRevisions(context.Background(), ListWallRevisionsParams{WallID: wall.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, wall.Title, revisions[0].Title)
+	require.Equal(t, wall.UserID, revisions[0].ActorID)
+	require.Equal(t, []string{"title"}, revisions[0].ChangedFields)
+
+	// Saving without changes doesn't add to the history
+	result, err = testHub.UpdateWallTx(context.Background(), UpdateWallTxParams{
+		Wall:    UpdateWallParams{ID: wall.ID, Title: updated.Title},
+		ActorID: wall.UserID,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Tags)
+
+	revisions, err = testHub.ListWallRevisions(context.Background(), ListWallRevisionsParams{WallID: wall.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+}
+
+func TestSetWallVisibilityTx(t *testing.T) {
+	wall := createRandomWall(t)
+
+	public, err := testHub.SetWallVisibilityTx(context.Background(), wall.ID, wall.UserID, true)
+	require.NoError(t, err)
+	require.True(t, public.IsPublic.Bool)
+
+	revisions, err := testHub.ListWallRevisions(context.Background(), ListWallRevisionsParams{WallID: wall.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.False(t, revisions[0].IsPublic.Bool)
+	require.Equal(t, []string{"is_public"}, revisions[0].ChangedFields)
+}
+
+func TestRevertWallTx(t *testing.T) {
+	wall := createRandomWall(t)
+
+	_, err := testHub.UpdateWallTx(context.Background(), UpdateWallTxParams{
+		Wall: UpdateWallParams{
+			ID:          wall.ID,
+			Title:       "Renamed",
+			Description: pgtype.Text{String: "New description", Valid: true},
+		},
+		ActorID: wall.UserID,
+	})
+	require.NoError(t, err)
+
+	revisions, err := testHub.ListWallRevisions(context.Background(), ListWallRevisionsParams{WallID: wall.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+
+	reverted, err := testHub.RevertWallTx(context.Background(), wall.ID, revisions[0].ID, wall.UserID)
+	require.NoError(t, err)
+	require.Equal(t, wall.Title, reverted.Title)
+	require.Equal(t, wall.Description, reverted.Description)
+
+	// The revert is recorded too
+	revisions, err = testHub.ListWallRevisions(context.Background(), ListWallRevisionsParams{WallID: wall.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, "Renamed", revisions[0].Title)
+
+	// Revisions of another wall can't be applied
+	other := createRandomWall(t)
+	_, err = testHub.RevertWallTx(context.Background(), other.ID, revisions[0].ID, other.UserID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
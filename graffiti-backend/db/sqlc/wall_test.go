@@ -252,3 +252,106 @@ func TestPinUnpinWall(t *testing.T) {
     require.False(t, unpinnedWall.IsPinned.Bool) 
 }
 
+
+func TestDeleteAndRestoreWallTx(t *testing.T) {
+	like := createRandomLike(t)
+	post, err := testHub.GetPost(context.Background(), like.PostID)
+	require.NoError(t, err)
+
+	err = testHub.DeleteWallTx(context.Background(), post.WallID)
+	require.NoError(t, err)
+
+	deletedPost, err := testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.True(t, deletedPost.IsDeleted.Bool)
+
+	restored, err := testHub.RestoreWallTx(context.Background(), post.WallID)
+	require.NoError(t, err)
+	require.False(t, restored.IsDeleted.Bool)
+	require.False(t, restored.DeletedAt.Valid)
+
+	restoredPost, err := testHub.GetPost(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.False(t, restoredPost.IsDeleted.Bool)
+
+	// Restoring twice finds nothing in the trash
+	_, err = testHub.RestoreWallTx(context.Background(), post.WallID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestSetWallPinsTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	walls := make([]Wall, 3)
+	for i := range walls {
+		wall, err := testHub.CreateWall(context.Background(), CreateWallParams{
+			UserID: user.ID,
+			Title:  "Wall Title" + util.RandomString(10),
+		})
+		require.NoError(t, err)
+		walls[i] = wall
+	}
+
+	pinned, err := testHub.SetWallPinsTx(context.Background(), user.ID, []pgtype.UUID{walls[2].ID, walls[0].ID})
+	require.NoError(t, err)
+	require.Len(t, pinned, 2)
+	require.Equal(t, int32(1), pinned[0].PinOrder.Int32)
+	require.Equal(t, int32(2), pinned[1].PinOrder.Int32)
+
+	listed, err := testHub.ListWallsByUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	require.Equal(t, walls[2].ID, listed[0].ID)
+	require.Equal(t, walls[0].ID, listed[1].ID)
+	require.False(t, listed[2].IsPinned.Bool)
+
+	// A wall that belongs to someone else rolls back the whole change
+	other := createRandomWall(t)
+	_, err = testHub.SetWallPinsTx(context.Background(), user.ID, []pgtype.UUID{walls[1].ID, other.ID})
+	require.ErrorIs(t, err, ErrWallNotPinnable)
+
+	count, err := testHub.CountPinnedWalls(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	pinned, err = testHub.SetWallPinsTx(context.Background(), user.ID, nil)
+	require.NoError(t, err)
+	require.Empty(t, pinned)
+
+	count, err = testHub.CountPinnedWalls(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestArchiveWallUnpins(t *testing.T) {
+	wall := createRandomWall(t)
+
+	pinned, err := testHub.SetWallPinsTx(context.Background(), wall.UserID, []pgtype.UUID{wall.ID})
+	require.NoError(t, err)
+	require.Len(t, pinned, 1)
+
+	// Unarchiving doesn't bring the pin back, so it can't go past the limit
+	require.NoError(t, testHub.ArchiveWall(context.Background(), wall.ID))
+	require.NoError(t, testHub.UnarchiveWall(context.Background(), wall.ID))
+
+	unarchived, err := testHub.GetWall(context.Background(), wall.ID)
+	require.NoError(t, err)
+	require.False(t, unarchived.IsPinned.Bool)
+	require.False(t, unarchived.PinOrder.Valid)
+
+	count, err := testHub.CountPinnedWalls(context.Background(), wall.UserID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestSetWallAllowAnonymous(t *testing.T) {
+	wall := createRandomWall(t)
+	require.False(t, wall.AllowAnonymous)
+
+	updated, err := testHub.SetWallAllowAnonymous(context.Background(), SetWallAllowAnonymousParams{
+		ID:             wall.ID,
+		AllowAnonymous: true,
+	})
+	require.NoError(t, err)
+	require.True(t, updated.AllowAnonymous)
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxCaptionLength is the most characters a post caption can have once sanitized
+const MaxCaptionLength = 500
+
+var ErrInvalidCaption = errors.New("invalid caption")
+
+var (
+	// htmlComment matches comments, including one left open at the end
+	htmlComment = regexp.MustCompile(`(?s)<!--.*?(-->|$)`)
+	// htmlTag matches anything that a browser would parse as a tag, so "<3" and "a < b" are kept
+	htmlTag = regexp.MustCompile(`</?[a-zA-Z!?][^<>]*>?`)
+)
+
+// SanitizeCaption turns a caption into plain text, see StripMarkup
+func SanitizeCaption(caption string) (string, error) {
+	caption = StripMarkup(caption)
+
+	if utf8.RuneCountInString(caption) > MaxCaptionLength {
+		return "", fmt.Errorf("%w: caption is longer than %d characters", ErrInvalidCaption, MaxCaptionLength)
+	}
+
+	return caption, nil
+}
+
+// StripMarkup turns user input into plain text. HTML tags and comments are
+// removed, control characters other than newlines and tabs are dropped, line
+// endings are normalized and surrounding whitespace is trimmed.
+func StripMarkup(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	// Control characters go first, so dropping one can't join up a tag
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+	// Removing a tag can leave another one behind, as in "<<b>script>", so
+	// keep going until there's nothing left to remove
+	for {
+		stripped := htmlTag.ReplaceAllString(htmlComment.ReplaceAllString(text, ""), "")
+		if stripped == text {
+			break
+		}
+		text = stripped
+	}
+	return strings.TrimSpace(text)
+}
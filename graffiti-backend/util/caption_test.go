@@ -0,0 +1,46 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeCaption(t *testing.T) {
+	testCases := []struct {
+		caption  string
+		expected string
+		wantErr  bool
+	}{
+		{caption: "  Happy birthday!  ", expected: "Happy birthday!"},
+		{caption: "Miss you <3", expected: "Miss you <3"},
+		{caption: "a < b && b > c", expected: "a < b && b > c"},
+		{caption: "<b>bold</b> move", expected: "bold move"},
+		{caption: `<img src=x onerror="alert(1)">hi`, expected: "hi"},
+		{caption: "<script>alert(1)</script>", expected: "alert(1)"},
+		{caption: "hi <script", expected: "hi"},
+		{caption: "before<!-- hidden -->after", expected: "beforeafter"},
+		{caption: "line one\r\nline two", expected: "line one\nline two"},
+		{caption: "null\x00byte\x1b", expected: "nullbyte"},
+		{caption: "<p></p>", expected: ""},
+		// Tags rebuilt by stripping the ones nested inside them
+		{caption: "<<script>script>alert(1)<</script>/script>", expected: "alert(1)"},
+		{caption: "<<img src=x onerror=alert(1)>img src=x onerror=alert(1)>", expected: ""},
+		{caption: "<!<!-- x -->-- hidden -->shown", expected: "shown"},
+		{caption: "<\x00script>alert(1)", expected: "alert(1)"},
+		{caption: strings.Repeat("é", MaxCaptionLength), expected: strings.Repeat("é", MaxCaptionLength)},
+		{caption: "<i>" + strings.Repeat("a", MaxCaptionLength) + "</i>", expected: strings.Repeat("a", MaxCaptionLength)},
+		{caption: strings.Repeat("a", MaxCaptionLength+1), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		caption, err := SanitizeCaption(tc.caption)
+		if tc.wantErr {
+			require.ErrorIs(t, err, ErrInvalidCaption)
+			continue
+		}
+		require.NoError(t, err, tc.caption)
+		require.Equal(t, tc.expected, caption)
+	}
+}
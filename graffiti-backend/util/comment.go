@@ -0,0 +1,27 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxCommentLength is the most characters a comment can have once sanitized
+const MaxCommentLength = 1000
+
+var ErrInvalidComment = errors.New("invalid comment")
+
+// SanitizeComment turns a comment into plain text, see StripMarkup.
+// Unlike captions, a comment cannot be empty.
+func SanitizeComment(body string) (string, error) {
+	body = StripMarkup(body)
+
+	if body == "" {
+		return "", fmt.Errorf("%w: comment cannot be empty", ErrInvalidComment)
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidComment, MaxCommentLength)
+	}
+
+	return body, nil
+}
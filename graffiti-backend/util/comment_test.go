@@ -0,0 +1,20 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeComment(t *testing.T) {
+	body, err := SanitizeComment("  <b>Nice</b> one! ")
+	require.NoError(t, err)
+	require.Equal(t, "Nice one!", body)
+
+	_, err = SanitizeComment("<p> </p>")
+	require.ErrorIs(t, err, ErrInvalidComment)
+
+	_, err = SanitizeComment(strings.Repeat("a", MaxCommentLength+1))
+	require.ErrorIs(t, err, ErrInvalidComment)
+}
@@ -24,6 +24,11 @@ type Config struct {
 	IsProduction             bool   `mapstructure:"IS_PRODUCTION"`
 	SQSQueueURL             string `mapstructure:"SQS_QUEUE_URL"`
 	SQSDeadLetterURL		string `mapstructure:"SQS_DLQ_URL"`
+	PurgeRetentionDays       int    `mapstructure:"PURGE_RETENTION_DAYS"`
+	RevisionRetentionDays    int    `mapstructure:"POST_REVISION_RETENTION_DAYS"`
+	ExportRetentionDays      int    `mapstructure:"EXPORT_RETENTION_DAYS"`
+	ReactionEmojis           string `mapstructure:"REACTION_EMOJIS"`
+	ReportHideThreshold      int    `mapstructure:"REPORT_HIDE_THRESHOLD"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -0,0 +1,26 @@
+package cron
+
+import (
+	"context"
+	"github.com/robfig/cron/v3"
+	"log"
+	"time"
+)
+
+// ScheduleAutoArchive warns about and archives inactive walls once a day
+func ScheduleAutoArchive(archive func(ctx context.Context) error) {
+	c := cron.New(cron.WithLocation(time.FixedZone("Asia/Singapore", 8*3600)))
+	_, err := c.AddFunc("0 5 * * *", func() { // Every day at 5AM, after the purge
+		log.Println("Archiving inactive walls via cron...")
+		if err := archive(context.Background()); err != nil {
+			log.Printf("Error archiving inactive walls: %v", err)
+		} else {
+			log.Println("Auto-archive completed successfully.")
+		}
+	})
+	if err != nil {
+		log.Printf("Error scheduling auto-archive cron job: %v", err)
+		return
+	}
+	c.Start()
+}
@@ -0,0 +1,23 @@
+package cron
+
+import (
+	"context"
+	"github.com/robfig/cron/v3"
+	"log"
+	"time"
+)
+
+// SchedulePostPublishing publishes scheduled posts that are due every minute
+func SchedulePostPublishing(publish func(ctx context.Context) error) {
+	c := cron.New(cron.WithLocation(time.FixedZone("Asia/Singapore", 8*3600)))
+	_, err := c.AddFunc("* * * * *", func() { // Every minute
+		if err := publish(context.Background()); err != nil {
+			log.Printf("Error publishing scheduled posts: %v", err)
+		}
+	})
+	if err != nil {
+		log.Printf("Error scheduling post publishing cron job: %v", err)
+		return
+	}
+	c.Start()
+}
@@ -0,0 +1,26 @@
+package cron
+
+import (
+	"context"
+	"github.com/robfig/cron/v3"
+	"log"
+	"time"
+)
+
+// SchedulePurge runs the purge of soft-deleted content once a day
+func SchedulePurge(purge func(ctx context.Context) error) {
+	c := cron.New(cron.WithLocation(time.FixedZone("Asia/Singapore", 8*3600)))
+	_, err := c.AddFunc("0 4 * * *", func() { // Every day at 4AM, after the view refresh
+		log.Println("Purging soft-deleted walls and posts via cron...")
+		if err := purge(context.Background()); err != nil {
+			log.Printf("Error purging deleted content: %v", err)
+		} else {
+			log.Println("Purge completed successfully.")
+		}
+	})
+	if err != nil {
+		log.Printf("Error scheduling purge cron job: %v", err)
+		return
+	}
+	c.Start()
+}
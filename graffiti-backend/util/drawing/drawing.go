@@ -0,0 +1,250 @@
+package drawing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+)
+
+const (
+	// MaxBytes is the largest stroke payload that is parsed at all
+	MaxBytes = 512 << 10
+	// MaxCanvasSize is the widest and tallest a drawing's canvas can be
+	MaxCanvasSize = 2048
+	// MaxStrokes is how many strokes a single drawing can have
+	MaxStrokes = 1000
+	// MaxPoints is how many points all of a drawing's strokes can have together
+	MaxPoints = 20000
+	// MaxBrushSize is the widest a stroke can be, in canvas pixels
+	MaxBrushSize = 100
+	// MaxInkLength bounds the combined length of every stroke, in canvas
+	// pixels, which keeps rendering a preview cheap
+	MaxInkLength = 500000
+	// MaxPreviewSize is the longest side of a rendered preview, larger
+	// canvases are scaled down to fit
+	MaxPreviewSize = 1024
+
+	// minRadius keeps hairline strokes at least a pixel wide once scaled down
+	minRadius = 0.75
+)
+
+var (
+	ErrTooLarge = errors.New("drawing is too large")
+	ErrInvalid  = errors.New("invalid drawing")
+)
+
+// Point is an x, y position on the canvas, sent as a two number array
+type Point [2]float64
+
+// UnmarshalJSON only accepts arrays of exactly two numbers
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var coords []float64
+	if err := json.Unmarshal(data, &coords); err != nil {
+		return err
+	}
+	if len(coords) != 2 {
+		return fmt.Errorf("%w: points need exactly two coordinates", ErrInvalid)
+	}
+	p[0], p[1] = coords[0], coords[1]
+	return nil
+}
+
+// Stroke is a single line drawn without lifting the brush. Eraser strokes
+// clear what was drawn under them and have no color.
+type Stroke struct {
+	Points []Point `json:"points"`
+	Color  string  `json:"color,omitempty"`
+	Size   float64 `json:"size"`
+	Eraser bool    `json:"eraser,omitempty"`
+}
+
+// Drawing is a canvas and the strokes drawn on it, oldest first
+type Drawing struct {
+	Width   int32    `json:"width"`
+	Height  int32    `json:"height"`
+	Strokes []Stroke `json:"strokes"`
+}
+
+// Parse decodes and validates a drawing. Colors come back lowercased in their
+// six digit form, so a parsed drawing can be stored as is.
+func Parse(data []byte) (Drawing, error) {
+	if len(data) > MaxBytes {
+		return Drawing{}, ErrTooLarge
+	}
+
+	var d Drawing
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&d); err != nil {
+		if errors.Is(err, ErrInvalid) {
+			return Drawing{}, err
+		}
+		return Drawing{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	if err := d.validate(); err != nil {
+		return Drawing{}, err
+	}
+	return d, nil
+}
+
+func (d *Drawing) validate() error {
+	if d.Width < 1 || d.Width > MaxCanvasSize || d.Height < 1 || d.Height > MaxCanvasSize {
+		return fmt.Errorf("%w: the canvas must be between 1 and %d pixels on each side", ErrInvalid, MaxCanvasSize)
+	}
+	if len(d.Strokes) == 0 {
+		return fmt.Errorf("%w: nothing was drawn", ErrInvalid)
+	}
+	if len(d.Strokes) > MaxStrokes {
+		return fmt.Errorf("%w: more than %d strokes", ErrTooLarge, MaxStrokes)
+	}
+
+	points := 0
+	ink := 0.0
+	for i := range d.Strokes {
+		stroke := &d.Strokes[i]
+
+		if len(stroke.Points) == 0 {
+			return fmt.Errorf("%w: stroke %d has no points", ErrInvalid, i)
+		}
+		if stroke.Size <= 0 || stroke.Size > MaxBrushSize {
+			return fmt.Errorf("%w: stroke %d must be between 0 and %d pixels wide", ErrInvalid, i, MaxBrushSize)
+		}
+
+		if stroke.Eraser {
+			stroke.Color = ""
+		} else {
+			normalized, ok := normalizeColor(stroke.Color)
+			if !ok {
+				return fmt.Errorf("%w: stroke %d needs a #rrggbb color", ErrInvalid, i)
+			}
+			stroke.Color = normalized
+		}
+
+		points += len(stroke.Points)
+		if points > MaxPoints {
+			return fmt.Errorf("%w: more than %d points", ErrTooLarge, MaxPoints)
+		}
+
+		for j, p := range stroke.Points {
+			if p[0] < 0 || p[0] > float64(d.Width) || p[1] < 0 || p[1] > float64(d.Height) {
+				return fmt.Errorf("%w: stroke %d has a point outside the canvas", ErrInvalid, i)
+			}
+			if j > 0 {
+				ink += math.Hypot(p[0]-stroke.Points[j-1][0], p[1]-stroke.Points[j-1][1])
+			}
+		}
+		if ink > MaxInkLength {
+			return fmt.Errorf("%w: strokes are longer than %d pixels altogether", ErrTooLarge, MaxInkLength)
+		}
+	}
+
+	return nil
+}
+
+// normalizeColor turns #rgb and #rrggbb colors into lowercase #rrggbb
+func normalizeColor(c string) (string, bool) {
+	c = strings.ToLower(c)
+	if !strings.HasPrefix(c, "#") || (len(c) != 4 && len(c) != 7) {
+		return "", false
+	}
+	for _, r := range c[1:] {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return "", false
+		}
+	}
+	if len(c) == 4 {
+		c = string([]byte{'#', c[1], c[1], c[2], c[2], c[3], c[3]})
+	}
+	return c, true
+}
+
+// parseColor reads an already normalized #rrggbb color
+func parseColor(c string) color.NRGBA {
+	var rgb [3]uint8
+	for i := range rgb {
+		var v uint8
+		for _, r := range c[1+2*i : 3+2*i] {
+			if r <= '9' {
+				v = v<<4 | uint8(r-'0')
+			} else {
+				v = v<<4 | uint8(r-'a'+10)
+			}
+		}
+		rgb[i] = v
+	}
+	return color.NRGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 255}
+}
+
+// Render rasterizes a parsed drawing to a PNG with a transparent background,
+// scaled down to fit MaxPreviewSize. Strokes have round ends and joins, like
+// the frontend's canvas draws them.
+func Render(d Drawing) ([]byte, error) {
+	scale := 1.0
+	if longest := math.Max(float64(d.Width), float64(d.Height)); longest > MaxPreviewSize {
+		scale = MaxPreviewSize / longest
+	}
+
+	width := int(math.Ceil(float64(d.Width) * scale))
+	height := int(math.Ceil(float64(d.Height) * scale))
+	canvas := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for _, stroke := range d.Strokes {
+		var c color.NRGBA
+		if !stroke.Eraser {
+			c = parseColor(stroke.Color)
+		}
+		radius := math.Max(stroke.Size*scale/2, minRadius)
+
+		prev := stroke.Points[0]
+		stamp(canvas, prev[0]*scale, prev[1]*scale, radius, c)
+		for _, p := range stroke.Points[1:] {
+			line(canvas, prev[0]*scale, prev[1]*scale, p[0]*scale, p[1]*scale, radius, c)
+			prev = p
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// line stamps discs along a segment, close enough together that the edges
+// look smooth
+func line(canvas *image.NRGBA, x0, y0, x1, y1, radius float64, c color.NRGBA) {
+	length := math.Hypot(x1-x0, y1-y0)
+	step := math.Max(radius/2, 0.5)
+	steps := int(math.Ceil(length / step))
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		stamp(canvas, x0+(x1-x0)*t, y0+(y1-y0)*t, radius, c)
+	}
+}
+
+// stamp fills every pixel whose center lies within radius of cx, cy
+func stamp(canvas *image.NRGBA, cx, cy, radius float64, c color.NRGBA) {
+	bounds := canvas.Bounds()
+	minX := max(int(math.Floor(cx-radius)), bounds.Min.X)
+	maxX := min(int(math.Ceil(cx+radius)), bounds.Max.X-1)
+	minY := max(int(math.Floor(cy-radius)), bounds.Min.Y)
+	maxY := min(int(math.Ceil(cy+radius)), bounds.Max.Y-1)
+
+	r2 := radius * radius
+	for y := minY; y <= maxY; y++ {
+		dy := float64(y) + 0.5 - cy
+		for x := minX; x <= maxX; x++ {
+			dx := float64(x) + 0.5 - cx
+			if dx*dx+dy*dy <= r2 {
+				canvas.SetNRGBA(x, y, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package drawing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	d, err := Parse([]byte(`{
+		"width": 200,
+		"height": 100,
+		"strokes": [
+			{"points": [[10, 10], [190, 10]], "color": "#F00", "size": 4},
+			{"points": [[100, 50]], "color": "#123abc", "size": 20, "eraser": true}
+		]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, int32(200), d.Width)
+	require.Len(t, d.Strokes, 2)
+	require.Equal(t, "#ff0000", d.Strokes[0].Color)
+	require.Equal(t, Point{190, 10}, d.Strokes[0].Points[1])
+	// Eraser strokes don't keep a color
+	require.Empty(t, d.Strokes[1].Color)
+
+	// A parsed drawing survives being stored and read back
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	again, err := Parse(data)
+	require.NoError(t, err)
+	require.Equal(t, d, again)
+}
+
+func TestParseInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{name: "NotJSON", body: `strokes`, err: ErrInvalid},
+		{name: "UnknownField", body: `{"width": 10, "height": 10, "strokes": [{"points": [[1, 1]], "color": "#000", "size": 1}], "opacity": 1}`, err: ErrInvalid},
+		{name: "NoCanvas", body: `{"strokes": [{"points": [[1, 1]], "color": "#000", "size": 1}]}`, err: ErrInvalid},
+		{name: "CanvasTooBig", body: `{"width": 4096, "height": 10, "strokes": [{"points": [[1, 1]], "color": "#000", "size": 1}]}`, err: ErrInvalid},
+		{name: "NoStrokes", body: `{"width": 10, "height": 10, "strokes": []}`, err: ErrInvalid},
+		{name: "NoPoints", body: `{"width": 10, "height": 10, "strokes": [{"points": [], "color": "#000", "size": 1}]}`, err: ErrInvalid},
+		{name: "ThreeCoordinates", body: `{"width": 10, "height": 10, "strokes": [{"points": [[1, 1, 1]], "color": "#000", "size": 1}]}`, err: ErrInvalid},
+		{name: "OutsideCanvas", body: `{"width": 10, "height": 10, "strokes": [{"points": [[11, 1]], "color": "#000", "size": 1}]}`, err: ErrInvalid},
+		{name: "BadColor", body: `{"width": 10, "height": 10, "strokes": [{"points": [[1, 1]], "color": "red", "size": 1}]}`, err: ErrInvalid},
+		{name: "NoSize", body: `{"width": 10, "height": 10, "strokes": [{"points": [[1, 1]], "color": "#000"}]}`, err: ErrInvalid},
+		{name: "BrushTooWide", body: `{"width": 10, "height": 10, "strokes": [{"points": [[1, 1]], "color": "#000", "size": 101}]}`, err: ErrInvalid},
+		{name: "TooManyBytes", body: strings.Repeat(" ", MaxBytes+1), err: ErrTooLarge},
+		{name: "TooMuchInk", body: longDrawing(), err: ErrTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.body))
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+// longDrawing zigzags across the canvas until its strokes are too long
+func longDrawing() string {
+	points := make([]string, 0, 300)
+	for i := 0; i < 300; i++ {
+		points = append(points, fmt.Sprintf("[%d, %d]", (i%2)*2000, i))
+	}
+	return fmt.Sprintf(`{"width": 2000, "height": 2000, "strokes": [{"points": [%s], "color": "#000", "size": 1}]}`, strings.Join(points, ","))
+}
+
+func TestRender(t *testing.T) {
+	d := Drawing{
+		Width:  100,
+		Height: 40,
+		Strokes: []Stroke{
+			{Points: []Point{{10, 20}, {90, 20}}, Color: "#0000ff", Size: 10},
+			{Points: []Point{{50, 20}}, Size: 10, Eraser: true},
+		},
+	}
+
+	data, err := Render(d)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 100, 40), img.Bounds())
+
+	blue := color.NRGBAModel.Convert(color.NRGBA{B: 255, A: 255})
+	require.Equal(t, blue, color.NRGBAModel.Convert(img.At(20, 20)))
+	// Round caps reach past the last point
+	require.Equal(t, blue, color.NRGBAModel.Convert(img.At(92, 20)))
+	// The eraser cleared the middle, and nothing was drawn off the line
+	require.Zero(t, color.NRGBAModel.Convert(img.At(50, 20)).(color.NRGBA).A)
+	require.Zero(t, color.NRGBAModel.Convert(img.At(20, 5)).(color.NRGBA).A)
+}
+
+func TestRenderScalesDown(t *testing.T) {
+	d := Drawing{
+		Width:   MaxCanvasSize,
+		Height:  MaxCanvasSize / 2,
+		Strokes: []Stroke{{Points: []Point{{0, 0}, {MaxCanvasSize, MaxCanvasSize / 2}}, Color: "#000000", Size: 8}},
+	}
+
+	data, err := Render(d)
+	require.NoError(t, err)
+
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, MaxPreviewSize, config.Width)
+	require.Equal(t, MaxPreviewSize/2, config.Height)
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Manifest describes the contents of an exported wall archive
+type Manifest struct {
+	Wall       WallInfo    `json:"wall"`
+	ExportedAt time.Time   `json:"exported_at"`
+	Posts      []PostEntry `json:"posts"`
+}
+
+// WallInfo holds the wall details written to the manifest
+type WallInfo struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// PostEntry holds a single post's details written to the manifest
+type PostEntry struct {
+	ID             string    `json:"id"`
+	AuthorID       string    `json:"author_id"`
+	AuthorUsername string    `json:"author_username"`
+	AuthorFullname string    `json:"author_fullname,omitempty"`
+	PostType       string    `json:"post_type"`
+	MediaURL       string    `json:"media_url,omitempty"`
+	Caption        string    `json:"caption,omitempty"`
+	File           string    `json:"file,omitempty"`
+	LikesCount     int32     `json:"likes_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	// Media lists an album's items in order, the first one's file is also File
+	Media []MediaEntry `json:"media,omitempty"`
+}
+
+// MediaEntry holds one of an album's items written to the manifest
+type MediaEntry struct {
+	MediaURL string `json:"media_url"`
+	AltText  string `json:"alt_text,omitempty"`
+	File     string `json:"file,omitempty"`
+}
+
+// File is a media object stored inside the archive
+type File struct {
+	Name string
+	Data []byte
+}
+
+// ManifestName is the name of the manifest inside the archive
+const ManifestName = "manifest.json"
+
+// Archive writes an export zip as its files come in, so they don't all have to
+// be held in memory at once. The manifest goes last, once every post is known.
+type Archive struct {
+	zw *zip.Writer
+}
+
+// NewArchive starts an archive written to w
+func NewArchive(w io.Writer) *Archive {
+	return &Archive{zw: zip.NewWriter(w)}
+}
+
+// AddFile writes a media object to the archive
+func (a *Archive) AddFile(file File) error {
+	fw, err := a.zw.Create(file.Name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", file.Name, err)
+	}
+	if _, err := fw.Write(file.Data); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", file.Name, err)
+	}
+	return nil
+}
+
+// Close writes the manifest and finishes the archive
+func (a *Archive) Close(manifest Manifest) error {
+	mw, err := a.zw.Create(ManifestName)
+	if err != nil {
+		return fmt.Errorf("failed to add manifest to archive: %w", err)
+	}
+
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return a.zw.Close()
+}
+
+// WriteArchive writes the manifest and files to w as a zip archive
+func WriteArchive(w io.Writer, manifest Manifest, files []File) error {
+	archive := NewArchive(w)
+	for _, file := range files {
+		if err := archive.AddFile(file); err != nil {
+			return err
+		}
+	}
+	return archive.Close(manifest)
+}
@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"math"
+)
+
+const (
+	tileSize    = 320
+	tilePadding = 16
+	maxColumns  = 8
+	// MaxTiles caps how many images a collage shows, the rest are left out
+	MaxTiles = maxColumns * maxColumns
+	// MaxImagePixels bounds the images decoded for a collage, so a small file
+	// claiming huge dimensions can't make us allocate gigabytes
+	MaxImagePixels = 24_000_000
+)
+
+var (
+	// ErrNoImages is returned when none of the given images could be decoded
+	ErrNoImages = errors.New("no decodable images to render")
+	// ErrImageTooLarge is returned for images with more than MaxImagePixels
+	ErrImageTooLarge = fmt.Errorf("image has more than %d pixels", MaxImagePixels)
+)
+
+// Collage builds a grid of images one at a time. Each image is shrunk to its
+// tile as soon as it's added, so only the thumbnails are kept around.
+type Collage struct {
+	tiles []*image.RGBA
+}
+
+// Full reports whether the collage already has MaxTiles images
+func (c *Collage) Full() bool {
+	return len(c.tiles) >= MaxTiles
+}
+
+// Add decodes an image and adds it to the collage. Images are ignored once
+// the collage is full.
+func (c *Collage) Add(data []byte) error {
+	if c.Full() {
+		return nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	tile := image.NewRGBA(fitRect(img.Bounds(), image.Rect(0, 0, tileSize, tileSize)))
+	drawScaled(tile, tile.Bounds(), img)
+	c.tiles = append(c.tiles, tile)
+	return nil
+}
+
+// Render lays the collage's images out on a grid and returns the result encoded as PNG
+func (c *Collage) Render() ([]byte, error) {
+	if len(c.tiles) == 0 {
+		return nil, ErrNoImages
+	}
+
+	columns := int(math.Ceil(math.Sqrt(float64(len(c.tiles)))))
+	if columns > maxColumns {
+		columns = maxColumns
+	}
+	rows := (len(c.tiles) + columns - 1) / columns
+
+	cell := tileSize + tilePadding
+	canvas := image.NewRGBA(image.Rect(0, 0, columns*cell+tilePadding, rows*cell+tilePadding))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	for i, tile := range c.tiles {
+		x := tilePadding + (i%columns)*cell
+		y := tilePadding + (i/columns)*cell
+		draw.Draw(canvas, tile.Bounds().Add(image.Pt(x, y)), tile, tile.Bounds().Min, draw.Src)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderCollage lays the given images out on a grid and returns the result encoded as PNG.
+// Images that cannot be decoded or are too large are skipped.
+func RenderCollage(images [][]byte) ([]byte, error) {
+	var collage Collage
+	for _, data := range images {
+		// Skipping an image is fine, the collage is only a preview
+		_ = collage.Add(data)
+	}
+	return collage.Render()
+}
+
+// fitRect returns the largest rectangle with src's aspect ratio centered inside tile
+func fitRect(src, tile image.Rectangle) image.Rectangle {
+	scale := math.Min(float64(tile.Dx())/float64(src.Dx()), float64(tile.Dy())/float64(src.Dy()))
+	w := int(math.Max(1, math.Round(float64(src.Dx())*scale)))
+	h := int(math.Max(1, math.Round(float64(src.Dy())*scale)))
+
+	x := tile.Min.X + (tile.Dx()-w)/2
+	y := tile.Min.Y + (tile.Dy()-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// drawScaled draws src into rect of dst using nearest-neighbour sampling
+func drawScaled(dst *image.RGBA, rect image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		sy := sb.Min.Y + (y-rect.Min.Y)*sb.Dy()/rect.Dy()
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			sx := sb.Min.X + (x-rect.Min.X)*sb.Dx()/rect.Dx()
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+}
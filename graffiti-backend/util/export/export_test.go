@@ -0,0 +1,142 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestWriteArchive(t *testing.T) {
+	manifest := Manifest{
+		Wall:       WallInfo{ID: "wall-1", Title: "Birthday"},
+		ExportedAt: time.Now(),
+		Posts: []PostEntry{
+			{ID: "post-1", AuthorUsername: "alice", PostType: "media", File: "images/post-1.png", LikesCount: 3},
+		},
+	}
+	files := []File{{Name: "images/post-1.png", Data: encodePNG(t, 4, 4)}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, manifest, files))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	require.Equal(t, "images/post-1.png", zr.File[0].Name)
+	require.Equal(t, ManifestName, zr.File[1].Name)
+
+	rc, err := zr.File[1].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+
+	var got Manifest
+	require.NoError(t, json.NewDecoder(rc).Decode(&got))
+	require.Equal(t, manifest.Wall, got.Wall)
+	require.Len(t, got.Posts, 1)
+	require.Equal(t, "alice", got.Posts[0].AuthorUsername)
+	require.Equal(t, int32(3), got.Posts[0].LikesCount)
+}
+
+func TestRenderCollage(t *testing.T) {
+	images := [][]byte{
+		encodePNG(t, 40, 20),
+		encodePNG(t, 20, 40),
+		[]byte("not an image"),
+		encodePNG(t, 10, 10),
+	}
+
+	data, err := RenderCollage(images)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	// Three decodable images are laid out on a 2x2 grid
+	cell := tileSize + tilePadding
+	require.Equal(t, 2*cell+tilePadding, img.Bounds().Dx())
+	require.Equal(t, 2*cell+tilePadding, img.Bounds().Dy())
+}
+
+func TestRenderCollageNoImages(t *testing.T) {
+	_, err := RenderCollage([][]byte{[]byte("garbage")})
+	require.ErrorIs(t, err, ErrNoImages)
+
+	_, err = RenderCollage(nil)
+	require.ErrorIs(t, err, ErrNoImages)
+}
+
+// hugePNG returns a tiny PNG whose header claims it's width x height
+func hugePNG(t *testing.T, width, height uint32) []byte {
+	data := encodePNG(t, 1, 1)
+
+	// IHDR is the first chunk: length, type, then width and height
+	ihdr := data[8+4 : 8+4+4+13]
+	binary.BigEndian.PutUint32(ihdr[4:8], width)
+	binary.BigEndian.PutUint32(ihdr[8:12], height)
+	binary.BigEndian.PutUint32(data[8+4+4+13:], crc32.ChecksumIEEE(ihdr))
+	return data
+}
+
+func TestCollageRejectsHugeImages(t *testing.T) {
+	var collage Collage
+	require.ErrorIs(t, collage.Add(hugePNG(t, 30000, 30000)), ErrImageTooLarge)
+	require.NoError(t, collage.Add(encodePNG(t, 10, 10)))
+
+	_, err := collage.Render()
+	require.NoError(t, err)
+}
+
+func TestCollageMaxTiles(t *testing.T) {
+	var collage Collage
+	img := encodePNG(t, 2, 2)
+	for i := 0; i < MaxTiles+5; i++ {
+		require.NoError(t, collage.Add(img))
+	}
+	require.True(t, collage.Full())
+
+	data, err := collage.Render()
+	require.NoError(t, err)
+
+	rendered, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	// Images past the cap are left out, so the grid stops at maxColumns rows
+	cell := tileSize + tilePadding
+	require.Equal(t, maxColumns*cell+tilePadding, rendered.Bounds().Dx())
+	require.Equal(t, maxColumns*cell+tilePadding, rendered.Bounds().Dy())
+}
+
+func TestArchiveStreamsFiles(t *testing.T) {
+	var buf bytes.Buffer
+	archive := NewArchive(&buf)
+	require.NoError(t, archive.AddFile(File{Name: "images/a.png", Data: encodePNG(t, 2, 2)}))
+	require.NoError(t, archive.AddFile(File{Name: "images/b.png", Data: encodePNG(t, 2, 2)}))
+	require.NoError(t, archive.Close(Manifest{Wall: WallInfo{ID: "wall-1"}}))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 3)
+	require.Equal(t, ManifestName, zr.File[2].Name)
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MaxMentions is how many different users a single caption or comment can mention
+const MaxMentions = 10
+
+// mentionPattern matches @username, where a username may contain dots but not
+// end with one, so "thanks @bob." mentions bob
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9_](?:[A-Za-z0-9_.]*[A-Za-z0-9_])?)`)
+
+// MentionSpan is an @username found in a piece of text. Start and End are
+// offsets in UTF-16 code units, the way JavaScript indexes strings; the span
+// includes the @ and End is exclusive.
+type MentionSpan struct {
+	Username string
+	Start    int
+	End      int
+}
+
+// ParseMentions returns the @username mentions in text, in order. An @ that
+// follows a letter, digit or another mention character is not a mention, so
+// email addresses are left alone. Only the first MaxMentions different
+// usernames are returned.
+func ParseMentions(text string) []MentionSpan {
+	var spans []MentionSpan
+	usernames := map[string]bool{}
+
+	// Track UTF-16 offsets as we go rather than recounting from the start for every match
+	offset, scanned := 0, 0
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[0], match[1]
+		username := text[match[2]:match[3]]
+
+		if prev, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && mentionRune(prev) {
+			continue
+		}
+		// The pattern stops at the first character it can't take, which must not be
+		// one that would have made this a longer username
+		if next, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && mentionRune(next) && next != '.' && next != '@' {
+			continue
+		}
+
+		if !usernames[username] {
+			if len(usernames) == MaxMentions {
+				continue
+			}
+			usernames[username] = true
+		}
+
+		offset += utf16Len(text[scanned:start])
+		spanStart := offset
+		offset += utf16Len(text[start:end])
+		scanned = end
+
+		spans = append(spans, MentionSpan{Username: username, Start: spanStart, End: offset})
+	}
+
+	return spans
+}
+
+func mentionRune(r rune) bool {
+	return r == '_' || r == '.' || r == '@' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
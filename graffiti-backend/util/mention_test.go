@@ -0,0 +1,81 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMentions(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []MentionSpan
+	}{
+		{
+			name: "Single",
+			text: "hi @bob",
+			want: []MentionSpan{{Username: "bob", Start: 3, End: 7}},
+		},
+		{
+			name: "Several",
+			text: "@ann and @bob_2",
+			want: []MentionSpan{{Username: "ann", Start: 0, End: 4}, {Username: "bob_2", Start: 9, End: 15}},
+		},
+		{
+			name: "TrailingPunctuation",
+			text: "thanks @bob.smith. and @ann!",
+			want: []MentionSpan{{Username: "bob.smith", Start: 7, End: 17}, {Username: "ann", Start: 23, End: 27}},
+		},
+		{
+			name: "Email",
+			text: "mail me at bob@example.com",
+			want: nil,
+		},
+		{
+			name: "BareAt",
+			text: "meet @ 5",
+			want: nil,
+		},
+		{
+			name: "NonASCIIUsername",
+			text: "@josé",
+			want: nil,
+		},
+		{
+			// The emoji takes two UTF-16 code units
+			name: "UTF16Offsets",
+			text: "🎉 é @ann",
+			want: []MentionSpan{{Username: "ann", Start: 5, End: 9}},
+		},
+		{
+			name: "Repeated",
+			text: "@ann @ann",
+			want: []MentionSpan{{Username: "ann", Start: 0, End: 4}, {Username: "ann", Start: 5, End: 9}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, ParseMentions(tc.text))
+		})
+	}
+}
+
+func TestParseMentionsLimit(t *testing.T) {
+	var words []string
+	for i := 0; i <= MaxMentions; i++ {
+		words = append(words, fmt.Sprintf("@user%d", i))
+	}
+	// Users already mentioned can still be mentioned again past the limit
+	words = append(words, "@user0")
+
+	spans := ParseMentions(strings.Join(words, " "))
+	require.Len(t, spans, MaxMentions+1)
+	require.Equal(t, "user0", spans[MaxMentions].Username)
+	for _, span := range spans {
+		require.NotEqual(t, fmt.Sprintf("user%d", MaxMentions), span.Username)
+	}
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxWallTags is the most tags a single wall can have
+	MaxWallTags = 5
+	// MaxTagLength is the most characters a tag can have once normalized
+	MaxTagLength = 32
+)
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// NormalizeTag lowercases a tag, drops a leading '#' and joins words with '-'.
+// Only letters, digits, '-' and '_' are allowed.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+
+	if tag == "" {
+		return "", fmt.Errorf("%w: tag cannot be empty", ErrInvalidTag)
+	}
+	if utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidTag, tag, MaxTagLength)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return "", fmt.Errorf("%w: %q contains %q", ErrInvalidTag, tag, r)
+		}
+	}
+
+	return tag, nil
+}
+
+// NormalizeTags normalizes and dedupes a list of tags, keeping their order
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		name, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	if len(normalized) > MaxWallTags {
+		return nil, fmt.Errorf("%w: a wall can have at most %d tags", ErrInvalidTag, MaxWallTags)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	testCases := []struct {
+		tag      string
+		expected string
+		wantErr  bool
+	}{
+		{tag: "Travel", expected: "travel"},
+		{tag: "  #Street Art ", expected: "street-art"},
+		{tag: "year_2024", expected: "year_2024"},
+		{tag: "Café", expected: "café"},
+		{tag: "", wantErr: true},
+		{tag: "#", wantErr: true},
+		{tag: "c++", wantErr: true},
+		{tag: strings.Repeat("a", MaxTagLength+1), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		name, err := NormalizeTag(tc.tag)
+		if tc.wantErr {
+			require.ErrorIs(t, err, ErrInvalidTag, tc.tag)
+			continue
+		}
+		require.NoError(t, err, tc.tag)
+		require.Equal(t, tc.expected, name)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{"Music", "#music", "live music", "MUSIC"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"music", "live-music"}, tags)
+
+	tags, err = NormalizeTags(nil)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	_, err = NormalizeTags([]string{"a", "b", "c", "d", "e", "f"})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	_, err = NormalizeTags([]string{"ok", "not ok!"})
+	require.ErrorIs(t, err, ErrInvalidTag)
+}
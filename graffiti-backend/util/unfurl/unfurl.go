@@ -0,0 +1,427 @@
+package unfurl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// DefaultTimeout bounds a whole fetch, redirects and oEmbed lookup included
+	DefaultTimeout = 5 * time.Second
+	// DefaultMaxBytes is the most of a response body that is read
+	DefaultMaxBytes = 1 << 20
+	// maxRedirects is how many redirects a fetch follows before giving up
+	maxRedirects = 5
+
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+	maxProviderLength    = 100
+	maxURLLength         = 2048
+)
+
+var (
+	ErrUnsupportedURL = errors.New("only http and https links can be unfurled")
+	ErrBlockedAddress = errors.New("link points to a private or reserved address")
+	ErrNotHTML        = errors.New("link does not point to an html page")
+)
+
+// Preview is the metadata shown in place of a bare link
+type Preview struct {
+	Title        string
+	Description  string
+	ThumbnailURL string
+	Provider     string
+	// EmbedHTML is a single rebuilt iframe, or empty if the provider didn't offer one
+	EmbedHTML string
+}
+
+// oEmbedProviders are the sites whose pages are too heavy or script-driven to
+// read OpenGraph tags from, so their oEmbed endpoint is asked directly
+var oEmbedProviders = map[string]string{
+	"youtube.com": "https://www.youtube.com/oembed",
+	"youtu.be":    "https://www.youtube.com/oembed",
+	"tiktok.com":  "https://www.tiktok.com/oembed",
+	"spotify.com": "https://open.spotify.com/oembed",
+}
+
+// Fetcher loads link previews over HTTP. It refuses to connect to private,
+// loopback and link-local addresses, which is checked on the resolved IP of
+// every connection so redirects and DNS tricks can't get around it.
+type Fetcher struct {
+	client    *http.Client
+	maxBytes  int64
+	providers map[string]string
+}
+
+// NewFetcher returns a Fetcher with the default timeout and size limit
+func NewFetcher() *Fetcher {
+	return newFetcher(DefaultTimeout, DefaultMaxBytes, false)
+}
+
+func newFetcher(timeout time.Duration, maxBytes int64, allowPrivate bool) *Fetcher {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if allowPrivate {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blockedIP(ip) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		// Never go through a proxy, it would make the dialer check the proxy's address instead
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &Fetcher{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				if !supportedURL(req.URL) {
+					return ErrUnsupportedURL
+				}
+				return nil
+			},
+		},
+		maxBytes:  maxBytes,
+		providers: oEmbedProviders,
+	}
+}
+
+// blockedIP reports whether an address is somewhere a link preview must never reach
+func blockedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		// 0.0.0.0/8 "this network" and 100.64.0.0/10 carrier-grade NAT
+		return ip4[0] == 0 || (ip4[0] == 100 && ip4[1]&0xc0 == 64)
+	}
+	return false
+}
+
+func supportedURL(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != ""
+}
+
+// Fetch loads the preview for a link from the provider's oEmbed endpoint if it
+// has a known one, otherwise from the page's OpenGraph tags and any oEmbed
+// endpoint the page advertises.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !supportedURL(u) || len(rawURL) > maxURLLength {
+		return Preview{}, ErrUnsupportedURL
+	}
+
+	if endpoint := f.providerEndpoint(u.Hostname()); endpoint != "" {
+		preview, err := f.fetchOEmbed(ctx, oEmbedURL(endpoint, rawURL))
+		if err == nil && preview.Title != "" {
+			return finish(preview, u), nil
+		}
+	}
+
+	preview, oEmbedHref, err := f.fetchPage(ctx, u)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	if oEmbedHref != "" {
+		if embed, err := f.fetchOEmbed(ctx, oEmbedHref); err == nil {
+			preview = merge(embed, preview)
+		}
+	}
+
+	return finish(preview, u), nil
+}
+
+func (f *Fetcher) providerEndpoint(host string) string {
+	host = strings.ToLower(host)
+	for domain, endpoint := range f.providers {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return endpoint
+		}
+	}
+	return ""
+}
+
+func oEmbedURL(endpoint, rawURL string) string {
+	return endpoint + "?format=json&url=" + url.QueryEscape(rawURL)
+}
+
+func (f *Fetcher) get(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", "GraffitiBot/1.0 (+link preview)")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+	}
+	return resp, nil
+}
+
+// oEmbedResponse holds the oEmbed fields a preview is made from
+type oEmbedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	HTML         string `json:"html"`
+}
+
+func (f *Fetcher) fetchOEmbed(ctx context.Context, endpoint string) (Preview, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || !supportedURL(u) {
+		return Preview{}, ErrUnsupportedURL
+	}
+
+	resp, err := f.get(ctx, endpoint, "application/json")
+	if err != nil {
+		return Preview{}, err
+	}
+	defer resp.Body.Close()
+
+	var data oEmbedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, f.maxBytes)).Decode(&data); err != nil {
+		return Preview{}, fmt.Errorf("invalid oembed response: %w", err)
+	}
+
+	return Preview{
+		Title:        data.Title,
+		Description:  data.AuthorName,
+		ThumbnailURL: data.ThumbnailURL,
+		Provider:     data.ProviderName,
+		EmbedHTML:    sanitizeEmbed(data.HTML),
+	}, nil
+}
+
+// fetchPage reads a page's head for its preview tags, and returns the oEmbed
+// endpoint it links to if there is one
+func (f *Fetcher) fetchPage(ctx context.Context, u *url.URL) (Preview, string, error) {
+	resp, err := f.get(ctx, u.String(), "text/html")
+	if err != nil {
+		return Preview{}, "", err
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return Preview{}, "", ErrNotHTML
+	}
+
+	meta, title, oEmbedHref := parseHead(io.LimitReader(resp.Body, f.maxBytes))
+
+	// Relative links are resolved against where the redirects ended up
+	base := resp.Request.URL
+	preview := Preview{
+		Title:        first(meta["og:title"], meta["twitter:title"], title),
+		Description:  first(meta["og:description"], meta["twitter:description"], meta["description"]),
+		ThumbnailURL: resolve(base, first(meta["og:image"], meta["og:image:url"], meta["twitter:image"])),
+		Provider:     meta["og:site_name"],
+	}
+
+	return preview, resolve(base, oEmbedHref), nil
+}
+
+// parseHead collects meta tags, the title and the oEmbed link from an html
+// document, stopping at the body since they all belong in the head
+func parseHead(r io.Reader) (map[string]string, string, string) {
+	meta := map[string]string{}
+	var title, oEmbedHref string
+	inTitle := false
+
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return meta, strings.TrimSpace(title), oEmbedHref
+		case html.TextToken:
+			if inTitle {
+				title += string(z.Text())
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				return meta, strings.TrimSpace(title), oEmbedHref
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := map[string]string{}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				attrs[string(key)] = string(val)
+			}
+
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = title == ""
+			case atom.Body:
+				return meta, strings.TrimSpace(title), oEmbedHref
+			case atom.Meta:
+				key := strings.ToLower(first(attrs["property"], attrs["name"]))
+				if key != "" && meta[key] == "" {
+					meta[key] = strings.TrimSpace(attrs["content"])
+				}
+			case atom.Link:
+				if strings.EqualFold(attrs["rel"], "alternate") &&
+					strings.EqualFold(attrs["type"], "application/json+oembed") && oEmbedHref == "" {
+					oEmbedHref = attrs["href"]
+				}
+			}
+		}
+	}
+}
+
+// sanitizeEmbed only lets through a lone https iframe, rebuilt from a short
+// list of attributes, since the html is rendered as-is by the frontend
+func sanitizeEmbed(raw string) string {
+	var iframe *html.Token
+
+	z := html.NewTokenizer(strings.NewReader(raw))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		tok := z.Token()
+		switch {
+		case tt == html.TextToken && strings.TrimSpace(tok.Data) == "":
+		case tok.DataAtom == atom.Iframe && tt == html.EndTagToken:
+		case tok.DataAtom == atom.Iframe && iframe == nil && (tt == html.StartTagToken || tt == html.SelfClosingTagToken):
+			iframe = &tok
+		default:
+			return ""
+		}
+	}
+	if iframe == nil {
+		return ""
+	}
+
+	allowed := map[string]bool{
+		"src": true, "width": true, "height": true, "title": true,
+		"allow": true, "allowfullscreen": true, "frameborder": true, "loading": true,
+	}
+
+	var b strings.Builder
+	b.WriteString("<iframe")
+	hasSrc := false
+	for _, attr := range iframe.Attr {
+		key := strings.ToLower(attr.Key)
+		if !allowed[key] {
+			continue
+		}
+		if key == "src" {
+			src, err := url.Parse(attr.Val)
+			if err != nil || src.Scheme != "https" || src.Host == "" {
+				return ""
+			}
+			hasSrc = true
+		}
+		fmt.Fprintf(&b, ` %s="%s"`, key, html.EscapeString(attr.Val))
+	}
+	b.WriteString("></iframe>")
+
+	if !hasSrc {
+		return ""
+	}
+	return b.String()
+}
+
+// merge fills the gaps in a preview with another one's fields
+func merge(preview, fallback Preview) Preview {
+	preview.Title = first(preview.Title, fallback.Title)
+	preview.Description = first(preview.Description, fallback.Description)
+	preview.ThumbnailURL = first(preview.ThumbnailURL, fallback.ThumbnailURL)
+	preview.Provider = first(preview.Provider, fallback.Provider)
+	preview.EmbedHTML = first(preview.EmbedHTML, fallback.EmbedHTML)
+	return preview
+}
+
+// finish trims a preview's fields to their limits and drops thumbnails that
+// aren't web links
+func finish(preview Preview, u *url.URL) Preview {
+	if thumb, err := url.Parse(preview.ThumbnailURL); err != nil || !supportedURL(thumb) || len(preview.ThumbnailURL) > maxURLLength {
+		preview.ThumbnailURL = ""
+	}
+
+	preview.Provider = first(preview.Provider, strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."))
+	preview.Title = truncate(strings.TrimSpace(preview.Title), maxTitleLength)
+	preview.Description = truncate(strings.TrimSpace(preview.Description), maxDescriptionLength)
+	preview.Provider = truncate(strings.TrimSpace(preview.Provider), maxProviderLength)
+	return preview
+}
+
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
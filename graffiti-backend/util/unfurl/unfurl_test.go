@@ -0,0 +1,205 @@
+package unfurl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFetcher lets the fetcher reach the stub server on loopback
+func newTestFetcher() *Fetcher {
+	return newFetcher(time.Second, 4096, true)
+}
+
+func TestFetchOpenGraph(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<!doctype html><html><head>
+			<title>Fallback &amp; title</title>
+			<meta property="og:title" content="Street art week">
+			<meta name="description" content="Murals all over town">
+			<meta property="og:image" content="/images/cover.jpg">
+			<meta property="og:site_name" content="City Blog">
+			<link rel="alternate" type="application/json+oembed" href="/oembed?url=article">
+		</head><body><meta property="og:title" content="Ignored"></body></html>`)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"html": "<iframe src=\"https://player.example.com/1\" width=\"480\" onload=\"alert(1)\"></iframe>"}`)
+	})
+
+	preview, err := newTestFetcher().Fetch(context.Background(), server.URL+"/article")
+	require.NoError(t, err)
+	require.Equal(t, "Street art week", preview.Title)
+	require.Equal(t, "Murals all over town", preview.Description)
+	require.Equal(t, server.URL+"/images/cover.jpg", preview.ThumbnailURL)
+	require.Equal(t, "City Blog", preview.Provider)
+	require.Equal(t, `<iframe src="https://player.example.com/1" width="480"></iframe>`, preview.EmbedHTML)
+}
+
+func TestFetchTitleFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title> Fish &amp; chips </title></head></html>`)
+	}))
+	defer server.Close()
+
+	preview, err := newTestFetcher().Fetch(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "Fish & chips", preview.Title)
+	require.Equal(t, "127.0.0.1", preview.Provider)
+	require.Empty(t, preview.EmbedHTML)
+}
+
+func TestFetchKnownProvider(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("url")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"title": "A video", "author_name": "Someone", "provider_name": "YouTube",
+			"thumbnail_url": "https://i.ytimg.com/vi/abc/hqdefault.jpg",
+			"html": "<iframe src=\"https://www.youtube.com/embed/abc\" allowfullscreen></iframe>"}`)
+	}))
+	defer server.Close()
+
+	fetcher := newTestFetcher()
+	fetcher.providers = map[string]string{"youtube.com": server.URL + "/oembed"}
+
+	link := "https://www.youtube.com/watch?v=abc"
+	preview, err := fetcher.Fetch(context.Background(), link)
+	require.NoError(t, err)
+	require.Equal(t, link, requested)
+	require.Equal(t, "A video", preview.Title)
+	require.Equal(t, "YouTube", preview.Provider)
+	require.Equal(t, "https://i.ytimg.com/vi/abc/hqdefault.jpg", preview.ThumbnailURL)
+	require.Equal(t, `<iframe src="https://www.youtube.com/embed/abc" allowfullscreen=""></iframe>`, preview.EmbedHTML)
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher().Fetch(context.Background(), server.URL)
+	require.ErrorIs(t, err, ErrBlockedAddress)
+	require.False(t, hit)
+}
+
+func TestFetchRejectsUnsupportedURLs(t *testing.T) {
+	for _, link := range []string{"ftp://example.com/file", "file:///etc/passwd", "not a url", "https://"} {
+		_, err := newTestFetcher().Fetch(context.Background(), link)
+		require.ErrorIs(t, err, ErrUnsupportedURL, link)
+	}
+}
+
+func TestFetchRejectsNonHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 'P', 'N', 'G'})
+	}))
+	defer server.Close()
+
+	_, err := newTestFetcher().Fetch(context.Background(), server.URL)
+	require.ErrorIs(t, err, ErrNotHTML)
+}
+
+func TestFetchLimitsBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		// The title sits past the 4KB limit of the test fetcher
+		fmt.Fprintf(w, `<html><head><!-- %s --><title>Too far</title></head></html>`, strings.Repeat("x", 8192))
+	}))
+	defer server.Close()
+
+	preview, err := newTestFetcher().Fetch(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Empty(t, preview.Title)
+}
+
+func TestFetchTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	fetcher := newFetcher(100*time.Millisecond, 4096, true)
+	start := time.Now()
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestFetchErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := newTestFetcher().Fetch(context.Background(), server.URL)
+	require.Error(t, err)
+}
+
+func TestBlockedIP(t *testing.T) {
+	testCases := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fc00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"93.184.216.34", false},
+		{"100.128.0.1", false},
+		{"2606:2800:220:1::", false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.blocked, blockedIP(net.ParseIP(tc.ip)), tc.ip)
+	}
+}
+
+func TestSanitizeEmbed(t *testing.T) {
+	testCases := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"Iframe", `<iframe src="https://a.example/e" title="x"></iframe>`, `<iframe src="https://a.example/e" title="x"></iframe>`},
+		{"DropsHandlers", `<iframe src="https://a.example/e" onload="x()"></iframe>`, `<iframe src="https://a.example/e"></iframe>`},
+		{"EscapesValues", `<iframe src="https://a.example/e" title='"><script>'></iframe>`, `<iframe src="https://a.example/e" title="&#34;&gt;&lt;script&gt;"></iframe>`},
+		{"Script", `<iframe src="https://a.example/e"></iframe><script>alert(1)</script>`, ""},
+		{"Blockquote", `<blockquote class="tiktok-embed">hi</blockquote>`, ""},
+		{"HTTPSource", `<iframe src="http://a.example/e"></iframe>`, ""},
+		{"JavaScriptSource", `<iframe src="javascript:alert(1)"></iframe>`, ""},
+		{"NoSource", `<iframe></iframe>`, ""},
+		{"TwoIframes", `<iframe src="https://a.example/1"></iframe><iframe src="https://a.example/2"></iframe>`, ""},
+		{"Empty", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, sanitizeEmbed(tc.raw))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+export type DailyViews = {
+	date: string;
+	views: number;
+};
+
+export type Contributor = {
+	user_id: string;
+	username: string;
+	fullname: string;
+	profile_picture: string;
+	post_count: number;
+	likes_received: number;
+};
+
+export type PostLikes = {
+	id: string;
+	post_type: "media" | "embed_link" | "text";
+	media_url: string;
+	caption: string;
+	likes_count: number;
+	created_at: string;
+};
+
+export type WallAnalytics = {
+	wall_id: string;
+	days: number;
+	total_views: number;
+	unique_visitors: number;
+	views_over_time: DailyViews[];
+	top_contributors: Contributor[];
+	posts: PostLikes[];
+};
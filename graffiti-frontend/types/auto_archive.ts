@@ -0,0 +1,16 @@
+import { Wall } from "./wall";
+
+export type AutoArchiveSettings = {
+	enabled: boolean;
+	days: number;
+};
+
+export type AutoArchivePreviewWall = Wall & {
+	last_activity_at: string;
+	archive_at: string;
+};
+
+export type AutoArchivePreview = {
+	days: number;
+	walls: AutoArchivePreviewWall[];
+};
@@ -0,0 +1,31 @@
+import { Mention } from "./post";
+
+export type Comment = {
+	id: string;
+	post_id: string;
+	// Set on replies, which can only be made to top-level comments
+	parent_id?: string;
+	// Author fields and body are empty on a deleted comment kept for its replies
+	author?: string;
+	username?: string;
+	fullname?: string;
+	profile_picture?: string;
+	body: string;
+	is_deleted: boolean;
+	edited_at?: string;
+	created_at: string;
+	reply_count: number;
+	mentions?: Mention[];
+};
+
+export type CommentsPage = {
+	page: number;
+	page_size: number;
+	has_more: boolean;
+	comments: Comment[];
+};
+
+export type RequestComment = {
+	body: string;
+	parent_id?: string;
+};
@@ -2,7 +2,19 @@ export type NotificationType =
   | 'friend_request'
   | 'friend_request_accepted'
   | 'post_like'
-  | 'wall_post';
+  | 'wall_post'
+  | 'wall_export'
+  | 'wall_export_failed'
+  | 'wall_post_pending'
+  | 'post_approved'
+  | 'post_rejected'
+  | 'followed_wall_post'
+  | 'wall_archive_warning'
+  | 'post_comment'
+  | 'comment_reply'
+  | 'post_reaction'
+  | 'mention'
+  | 'report_resolved';
 
 export interface Notification {
   id: string;
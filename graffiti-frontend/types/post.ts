@@ -2,23 +2,146 @@ export type Post = {
 	id: string;
 	wall_id: string;
 	author: string;
+	// On albums, the cover: the first of its media. On drawings, a PNG
+	// rendered from the strokes
 	media_url: string;
-	post_type: "media" | "embed_link";
+	post_type: "media" | "embed_link" | "text" | "album" | "drawing";
 	caption: string;
 	is_highlighted: boolean;
 	likes_count: number;
+	comments_count: number;
+	// Keyed by emoji; emojis nobody reacted with are left out
+	reaction_counts: Record<string, number>;
 	is_deleted: boolean;
+	// Hidden by moderators; only the author still sees it
+	is_hidden: boolean;
+	// Everyone but the author gets an empty author and "Anonymous" as username
+	is_anonymous: boolean;
 	created_at: string;
+	pos_x: number;
+	pos_y: number;
+	rotation: number;
+	scale: number;
+	z_index: number;
+	layout_version: number;
+	// Scheduled posts are only seen by their author until publish_at
+	status: "pending" | "approved" | "rejected" | "scheduled";
+	section_id?: string;
+	// Set once the author has changed the post's content
+	edited_at?: string;
+	// Only on posts that were scheduled
+	publish_at?: string;
+	// Only on embed links whose metadata could be fetched
+	link_preview?: LinkPreview;
+	// Users mentioned in the caption, in order of appearance
+	mentions?: Mention[];
+	// Only on albums, in display order
+	media?: PostMedia[];
 	profile_picture: string;
 	username: string;
 	fullname: string;
 };
 
+export type LinkPreview = {
+	title?: string;
+	description?: string;
+	thumbnail_url?: string;
+	provider?: string;
+	// A single sandboxable iframe, already sanitized by the backend
+	embed_html?: string;
+};
+
+export type PostMedia = {
+	id: string;
+	position: number;
+	media_url: string;
+	width: number;
+	height: number;
+	alt_text: string;
+};
+
+// A resolved @username; start and end are UTF-16 offsets, so they can be
+// used with String.prototype.slice directly
+export type Mention = {
+	user_id: string;
+	username: string;
+	start: number;
+	end: number;
+};
+
+// How a post looked before an edit, visible to the wall owner and the author
+export type PostRevision = {
+	id: string;
+	post_id: string;
+	editor_id?: string;
+	editor_username?: string;
+	editor_fullname?: string;
+	editor_profile_picture?: string;
+	media_url: string;
+	post_type: "media" | "embed_link" | "text";
+	caption: string;
+	created_at: string;
+};
+
+export type ScheduledPostsPage = {
+	page: number;
+	page_size: number;
+	has_more: boolean;
+	posts: Post[];
+};
+
+export type PostRevisionsPage = {
+	page: number;
+	page_size: number;
+	has_more: boolean;
+	revisions: PostRevision[];
+};
+
 export type RequestPost = {
 	wall_id: string;
+	// Left empty on albums, whose cover is their first item, and on drawings
 	media_url: string | null;
-	post_type: "media" | "embed_link";
-	// caption: string;
+	post_type: "media" | "embed_link" | "text" | "album" | "drawing";
+	section_id?: string;
+	caption?: string;
+	// Between 2 and 10 items, only on albums
+	media?: RequestPostMedia[];
+	// Only on drawings
+	drawing?: Drawing;
+	// ISO 8601, up to a year ahead; leave out to post right away
+	publish_at?: string;
+	// Only on walls that allow anonymous posts
+	anonymous?: boolean;
+};
+
+export type RequestPostMedia = {
+	media_url: string;
+	width: number;
+	height: number;
+	alt_text?: string;
+};
+
+// A point on the canvas as [x, y]
+export type DrawingPoint = [number, number];
+
+export type DrawingStroke = {
+	points: DrawingPoint[];
+	// #rrggbb, left out on eraser strokes
+	color?: string;
+	size: number;
+	eraser?: boolean;
+};
+
+// Up to 2048x2048, with strokes in the order they were drawn
+export type Drawing = {
+	width: number;
+	height: number;
+	strokes: DrawingStroke[];
+};
+
+// GET /api/v1/posts/:id/strokes
+export type PostStrokes = Drawing & {
+	post_id: string;
 };
 
 export type Platform = "youtube" | "tiktok" | "spotify" | "others";
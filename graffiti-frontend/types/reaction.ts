@@ -0,0 +1,22 @@
+export type ReactionSet = {
+	// The first emoji is always the thumbs-up that likes stand for
+	reactions: string[];
+};
+
+export type RequestReaction = {
+	reaction: string;
+};
+
+export type ReactionResult = {
+	post_id: string;
+	// Empty once the reaction is removed
+	reaction: string;
+	likes_count: number;
+	reaction_counts: Record<string, number>;
+};
+
+export type LikeStatus = {
+	// Only true when the user reacted with a thumbs-up
+	liked: boolean;
+	reaction: string | null;
+};
@@ -0,0 +1,37 @@
+export type ReportTargetType = "post" | "wall" | "user";
+
+export type ReportReason =
+	| "spam"
+	| "harassment"
+	| "hate_speech"
+	| "nudity"
+	| "violence"
+	| "self_harm"
+	| "impersonation"
+	| "other";
+
+export type ReportStatus = "open" | "resolved" | "dismissed";
+
+export type Report = {
+	id: string;
+	reporter_id: string;
+	reporter_username?: string;
+	target_type: ReportTargetType;
+	target_id: string;
+	reason: ReportReason;
+	detail: string;
+	status: ReportStatus;
+	resolved_by?: string;
+	resolution_note?: string;
+	resolved_at?: string;
+	created_at: string;
+	// Open reports on the same target, only set when listing
+	open_reports?: number;
+};
+
+export type ReportsPage = {
+	page: number;
+	page_size: number;
+	has_more: boolean;
+	reports: Report[];
+};
@@ -0,0 +1,15 @@
+export type WallSection = {
+	id: string;
+	wall_id: string;
+	name: string;
+	position: number;
+	// Posts fall back to the default section when theirs is deleted
+	is_default: boolean;
+	post_count: number;
+	created_at: string;
+};
+
+export type DeletedWallSection = {
+	default_section_id: string;
+	posts_moved: number;
+};
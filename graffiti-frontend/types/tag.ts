@@ -0,0 +1,14 @@
+import { Wall } from "./wall";
+
+export type Tag = {
+	name: string;
+	usage_count: number;
+};
+
+export type TagWallsPage = {
+	tag: string;
+	page: number;
+	page_size: number;
+	has_more: boolean;
+	walls: Wall[];
+};
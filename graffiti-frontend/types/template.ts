@@ -0,0 +1,9 @@
+export type WallTemplate = {
+	id: string;
+	slug: string;
+	title: string;
+	description?: string;
+	background_image?: string;
+	moderation_enabled: boolean;
+	tags: string[];
+};
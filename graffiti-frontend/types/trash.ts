@@ -0,0 +1,18 @@
+import { Post } from "./post";
+import { Wall } from "./wall";
+
+export type TrashWall = Wall & {
+	deleted_at: string;
+	purge_at: string;
+};
+
+export type TrashPost = Omit<Post, "profile_picture" | "username" | "fullname"> & {
+	wall_title: string;
+	deleted_at: string;
+	purge_at: string;
+};
+
+export type Trash = {
+	walls: TrashWall[];
+	posts: TrashPost[];
+};
@@ -10,6 +10,7 @@ export type User = {
 	onboarding_at: Date;
 	createdAt: Date;
 	updatedAt: Date;
+	role?: "user" | "moderator" | "admin";
 };
 
 export type UserWithMutualFriends = User & {
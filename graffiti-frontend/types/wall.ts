@@ -7,8 +7,48 @@ export type Wall = {
 	is_public: boolean;
 	is_archived: boolean;
 	is_deleted: boolean;
+	// Hidden by moderators; only the owner still sees it
+	is_hidden: boolean;
 	is_pinned: boolean;
+	pin_order?: number;
+	moderation_enabled: boolean;
+	allow_anonymous: boolean;
+	follower_count: number;
+	tags?: string[];
 	popularity_score: number;
 	created_at: string;
 	updated_at: string;
 };
+
+export type FollowedWall = Wall & {
+	muted: boolean;
+};
+
+export type ClonedWall = Wall & {
+	posts_copied: number;
+};
+
+export type WallRevisionField = "title" | "description" | "background_image" | "is_public";
+
+// How the wall looked before the change made by the actor
+export type WallRevision = {
+	id: string;
+	wall_id: string;
+	actor_id?: string;
+	actor_username?: string;
+	actor_fullname?: string;
+	actor_profile_picture?: string;
+	title: string;
+	description: string;
+	background_image: string;
+	is_public: boolean;
+	changed_fields: WallRevisionField[];
+	created_at: string;
+};
+
+export type WallRevisionsPage = {
+	page: number;
+	page_size: number;
+	has_more: boolean;
+	revisions: WallRevision[];
+};